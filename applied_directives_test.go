@@ -0,0 +1,245 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+)
+
+var roleEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Role",
+	Values: graphql.EnumValueConfigMap{
+		"ADMIN":  &graphql.EnumValueConfig{Value: "ADMIN"},
+		"VIEWER": &graphql.EnumValueConfig{Value: "VIEWER"},
+	},
+})
+
+var authDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "auth",
+	Locations: []string{graphql.DirectiveLocationObject, graphql.DirectiveLocationFieldDefinition},
+	Args: graphql.FieldConfigArgument{
+		"role": &graphql.ArgumentConfig{Type: graphql.NewNonNull(roleEnum)},
+	},
+})
+
+func TestAppliedDirectives_AvailableToResolvers(t *testing.T) {
+	resolveRole := func(p graphql.ResolveParams) (interface{}, error) {
+		if directive := p.Info.AppliedDirective("auth"); directive != nil {
+			return directive.Args["role"], nil
+		}
+		return "PUBLIC", nil
+	}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"secret": &graphql.Field{
+				Type:    graphql.String,
+				Resolve: resolveRole,
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+				},
+			},
+			"open": &graphql.Field{Type: graphql.String, Resolve: resolveRole},
+		},
+		AppliedDirectives: []*graphql.AppliedDirective{
+			{Name: "auth", Args: map[string]interface{}{"role": "VIEWER"}},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:      query,
+		Directives: append([]*graphql.Directive{authDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ secret open }`})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{"secret": "ADMIN", "open": "PUBLIC"}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+	if got := schema.QueryType().AppliedDirectives(); len(got) != 1 || got[0].Args["role"] != "VIEWER" {
+		t.Fatalf("expected @auth(role: VIEWER) on Query, got %v", got)
+	}
+}
+
+func TestAppliedDirectives_ValidatedAgainstDefinitions(t *testing.T) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"missingRole": &graphql.Field{
+				Type:              graphql.String,
+				AppliedDirectives: []*graphql.AppliedDirective{{Name: "auth"}},
+			},
+			"badRole": &graphql.Field{
+				Type: graphql.String,
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "auth", Args: map[string]interface{}{"role": "OWNER", "scope": "all"}},
+				},
+			},
+			"twice": &graphql.Field{
+				Type: graphql.String,
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+					{Name: "auth", Args: map[string]interface{}{"role": "VIEWER"}},
+				},
+			},
+			"unknown": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{
+						Type:              graphql.ID,
+						AppliedDirectives: []*graphql.AppliedDirective{{Name: "cached"}},
+					},
+				},
+			},
+		},
+	})
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED": &graphql.EnumValueConfig{
+				Value: 0,
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+				},
+			},
+		},
+	})
+
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:      query,
+		Types:      []graphql.Type{color},
+		Directives: append([]*graphql.Directive{authDirective}, graphql.SpecifiedDirectives...),
+	})
+	errs, ok := err.(graphql.SchemaErrors)
+	if !ok {
+		t.Fatalf("expected SchemaErrors, got %T: %v", err, err)
+	}
+	expected := graphql.SchemaErrors{
+		{Coordinate: "Color.RED", Message: `Directive "@auth" may not be used on ENUM_VALUE Color.RED.`},
+		{Coordinate: "Query.badRole", Message: `Unknown argument "scope" on directive "@auth" applied to Query.badRole.`},
+		{Coordinate: "Query.badRole", Message: `Directive "@auth" argument "role" applied to Query.badRole has invalid value OWNER.` + "\n" + `Expected type "Role", found "OWNER".`},
+		{Coordinate: "Query.missingRole", Message: `Directive "@auth" argument "role" of type "Role!" is required but not provided on Query.missingRole.`},
+		{Coordinate: "Query.twice", Message: `The directive "@auth" can only be used once on Query.twice.`},
+		{Coordinate: "Query.unknown(id:)", Message: `Unknown directive "@cached" applied to Query.unknown(id:).`},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Fatalf("unexpected errors:\n%v\nexpected:\n%v", errs, expected)
+	}
+}
+
+func TestAppliedDirectives_BuiltFromSDL(t *testing.T) {
+	schema := mustBuildSchema(t, `
+		directive @auth(role: Role!) on OBJECT | FIELD_DEFINITION
+		directive @tag(names: [String!]) on ARGUMENT_DEFINITION | ENUM_VALUE | INPUT_FIELD_DEFINITION | SCHEMA
+
+		schema @tag(names: ["public"]) {
+			query: Query
+		}
+
+		enum Role {
+			ADMIN
+			VIEWER @tag(names: ["default"])
+		}
+
+		input Filter {
+			term: String @tag(names: ["search"])
+		}
+
+		type Query @auth(role: VIEWER) {
+			users(filter: Filter @tag(names: ["a", "b"])): [String] @auth(role: ADMIN) @deprecated(reason: "Use members.")
+		}
+	`)
+
+	if got := schema.AppliedDirectives(); len(got) != 1 || !reflect.DeepEqual(got[0].Args["names"], []interface{}{"public"}) {
+		t.Fatalf("unexpected schema directives: %v", got)
+	}
+	users := schema.QueryType().Fields()["users"]
+	if users.DeprecationReason != "Use members." {
+		t.Fatalf("expected deprecation reason, got %q", users.DeprecationReason)
+	}
+	expected := []*graphql.AppliedDirective{{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}}}
+	if !reflect.DeepEqual(users.AppliedDirectives, expected) {
+		t.Fatalf("expected %v, got %v", expected, users.AppliedDirectives)
+	}
+	if got := users.Args[0].AppliedDirectives; len(got) != 1 || !reflect.DeepEqual(got[0].Args["names"], []interface{}{"a", "b"}) {
+		t.Fatalf("unexpected argument directives: %v", got)
+	}
+	term := schema.Type("Filter").(*graphql.InputObject).Fields()["term"]
+	if len(term.AppliedDirectives) != 1 || term.AppliedDirectives[0].Name != "tag" {
+		t.Fatalf("unexpected input field directives: %v", term.AppliedDirectives)
+	}
+	for _, value := range schema.Type("Role").(*graphql.Enum).Values() {
+		if (value.Name == "VIEWER") != (len(value.AppliedDirectives) == 1) {
+			t.Fatalf("unexpected directives on %v: %v", value.Name, value.AppliedDirectives)
+		}
+	}
+
+	_, err := graphql.BuildSchema(`
+		directive @auth(role: String!) on OBJECT
+		type Query {
+			name: String @auth(role: "ADMIN")
+		}
+	`)
+	if err == nil || err.Error() != `Directive "@auth" may not be used on FIELD_DEFINITION Query.name.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAppliedDirectives_Repeatable(t *testing.T) {
+	sdl := `directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION
+
+type Query @tag(name: "a") @tag(name: "b") {
+  name: String @tag(name: "c") @tag(name: "d")
+}
+`
+	schema := mustBuildSchema(t, sdl)
+	if !schema.Directive("tag").IsRepeatable {
+		t.Fatalf("expected @tag to be repeatable")
+	}
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __schema { directives { name isRepeatable } } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	repeatable := map[string]interface{}{}
+	for _, directive := range result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["directives"].([]interface{}) {
+		directive := directive.(map[string]interface{})
+		repeatable[directive["name"].(string)] = directive["isRepeatable"]
+	}
+	expected := map[string]interface{}{"tag": true, "include": false, "skip": false, "deprecated": false, "specifiedBy": false, "oneOf": false}
+	if !reflect.DeepEqual(repeatable, expected) {
+		t.Fatalf("expected %v, got %v", expected, repeatable)
+	}
+
+	_, err := graphql.BuildSchema(`
+		directive @tag(name: String!) on OBJECT
+		type Query @tag(name: "a") @tag(name: "b") {
+			name: String
+		}
+	`)
+	if err == nil || err.Error() != `The directive "@tag" can only be used once on Query.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changes := graphql.FindBreakingChanges(schema, mustBuildSchema(t, `
+		directive @tag(name: String!) on OBJECT | FIELD_DEFINITION
+		type Query {
+			name: String
+		}
+	`))
+	if len(changes) != 1 || changes[0].Type != graphql.BreakingChangeDirectiveRepeatableRemoved {
+		t.Fatalf("unexpected breaking changes: %v", changes)
+	}
+}
@@ -0,0 +1,230 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/kinds"
+	"github.com/GannettDigital/graphql/language/visitor"
+)
+
+// ForbiddenErrorCode is the code of the errors of fields whose authorization
+// was denied.
+const ForbiddenErrorCode = "FORBIDDEN"
+
+// AuthorizeFn authorizes the resolution of a field, returning an error to deny
+// it.
+type AuthorizeFn func(p ResolveParams) error
+
+// ForbiddenError is the error of a field whose authorization was denied. Its
+// formatted error has the FORBIDDEN code in its extensions.
+type ForbiddenError struct {
+	Err error
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Err.Error()
+}
+
+// Extensions returns the code of the error.
+func (e *ForbiddenError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": ForbiddenErrorCode}
+}
+
+// AuthDirective restricts access to the fields of a type or to a field to the
+// requests meeting each of its requirements, as authorized by AuthorizeRequires.
+var AuthDirective = NewDirective(DirectiveConfig{
+	Name:        "auth",
+	Description: "Restricts access to the requests meeting each of the requirements.",
+	Args: FieldConfigArgument{
+		"requires": &ArgumentConfig{
+			Type:        NewNonNull(NewList(NewNonNull(String))),
+			Description: "The requirements to meet, such as roles or scopes.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationObject,
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// AuthorizeRequires returns an authorizer of the fields with the @auth
+// directive, or whose parent types have it, denying the fields whose
+// requirements are not all granted to the request.
+//
+// It is meant to be the Authorize function of Params, authorizing the fields
+// which have no Authorize function of their own.
+func AuthorizeRequires(granted func(ctx context.Context, requirement string) bool) AuthorizeFn {
+	return func(p ResolveParams) error {
+		directives := []*AppliedDirective{}
+		if object, ok := p.Info.ParentType.(*Object); ok {
+			directives = append(directives, findAppliedDirective(object.AppliedDirectives(), AuthDirective.Name))
+		}
+		directives = append(directives, p.Info.AppliedDirective(AuthDirective.Name))
+		for _, directive := range directives {
+			if directive == nil {
+				continue
+			}
+			for _, requirement := range requirementsOf(directive) {
+				if !granted(p.Context, requirement) {
+					return fmt.Errorf(`Access to %v.%v requires "%v".`, p.Info.ParentType.Name(), p.Info.FieldName, requirement)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// requirementsOf returns the requirements of an @auth directive, given as a
+// list of strings in Go or as a list literal in SDL.
+func requirementsOf(directive *AppliedDirective) []string {
+	switch requires := directive.Args["requires"].(type) {
+	case []string:
+		return requires
+	case []interface{}:
+		requirements := []string{}
+		for _, requirement := range requires {
+			requirements = append(requirements, fmt.Sprintf("%v", requirement))
+		}
+		return requirements
+	case string:
+		return []string{requires}
+	}
+	return nil
+}
+
+// withAuthorization wraps the resolver of a field with its authorization,
+// resolving a denied field to null with a located ForbiddenError.
+func withAuthorization(authorize AuthorizeFn, resolveFn FieldResolveFn) FieldResolveFn {
+	if authorize == nil {
+		return resolveFn
+	}
+	return func(p ResolveParams) (interface{}, error) {
+		if err := authorize(p); err != nil {
+			return nil, gqlerrors.FormatError(NewLocatedError(&ForbiddenError{Err: err}, FieldASTsToNodeASTs(p.Info.FieldASTs)))
+		}
+		return resolveFn(p)
+	}
+}
+
+// AuthorizationRule returns a validation rule rejecting the documents which
+// select fields whose authorization is denied, so that the operations touching
+// forbidden fields are rejected as a whole rather than executed with null
+// fields.
+//
+// Fields are authorized by their Authorize function or, when they have none,
+// by authorize, with the parameters known before execution: the context, the
+// arguments, and the info of the field. The source of the field is not known,
+// and neither are the values of the variables, so that the fields whose
+// arguments use variables are left to be authorized during execution.
+//
+// The object type of a field selected on an interface or a union is not known
+// either, so the field is authorized as a field of each of the possible types
+// of its parent type, and rejected when any of them denies it. Selecting the
+// field in fragments on the allowed types avoids this.
+func AuthorizationRule(ctx context.Context, authorize AuthorizeFn) ValidationRuleFn {
+	return func(context *ValidationContext) *ValidationRuleInstance {
+		visitorOpts := &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.Field: {
+					Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+						fieldAST, ok := p.Node.(*ast.Field)
+						fieldDef := context.FieldDef()
+						parentType := context.ParentType()
+						if !ok || fieldDef == nil || parentType == nil || hasVariables(fieldAST.Arguments) {
+							return visitor.ActionNoChange, nil
+						}
+						parents := []Composite{parentType}
+						if _, ok := parentType.(*Object); !ok {
+							parents = []Composite{}
+							for _, object := range context.Schema().PossibleTypes(parentType) {
+								parents = append(parents, object)
+							}
+						}
+						for _, parent := range parents {
+							parentFieldDef := fieldDef
+							if object, ok := parent.(*Object); ok && parent != parentType {
+								if objectFieldDef, ok := object.Fields()[fieldDef.Name]; ok {
+									parentFieldDef = objectFieldDef
+								}
+							}
+							if err := authorizeOnValidation(ctx, context, authorize, parent, parentFieldDef, fieldAST); err != nil {
+								context.ReportError(NewLocatedError(err, []ast.Node{fieldAST}))
+								break
+							}
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}
+		return &ValidationRuleInstance{
+			VisitorOpts: visitorOpts,
+		}
+	}
+}
+
+// authorizeOnValidation authorizes a field of parentType with the parameters
+// known before execution. It returns a ForbiddenError when the field is denied,
+// or the error of its arguments.
+func authorizeOnValidation(ctx context.Context, context *ValidationContext, authorize AuthorizeFn, parentType Composite, fieldDef *FieldDefinition, fieldAST *ast.Field) error {
+	fieldAuthorize := fieldDef.Authorize
+	if fieldAuthorize == nil {
+		fieldAuthorize = authorize
+	}
+	if fieldAuthorize == nil {
+		return nil
+	}
+	args, err := getArgumentValues(fieldDef.Args, fieldAST.Arguments, nil)
+	if err != nil {
+		return err
+	}
+	err = fieldAuthorize(ResolveParams{
+		Args: args,
+		Info: ResolveInfo{
+			FieldName:       fieldDef.Name,
+			FieldASTs:       []*ast.Field{fieldAST},
+			ReturnType:      fieldDef.Type,
+			ParentType:      parentType,
+			Schema:          *context.Schema(),
+			FieldDefinition: fieldDef,
+		},
+		Context: ctx,
+	})
+	if err != nil {
+		return &ForbiddenError{Err: err}
+	}
+	return nil
+}
+
+// hasVariables reports whether arguments use variables.
+func hasVariables(arguments []*ast.Argument) bool {
+	for _, argument := range arguments {
+		if valueHasVariables(argument.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueHasVariables(value ast.Value) bool {
+	switch value := value.(type) {
+	case *ast.Variable:
+		return true
+	case *ast.ListValue:
+		for _, value := range value.Values {
+			if valueHasVariables(value) {
+				return true
+			}
+		}
+	case *ast.ObjectValue:
+		for _, field := range value.Fields {
+			if valueHasVariables(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+package graphql_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+type scopesKey struct{}
+
+// grantedScopes grants the scopes of the context of the request.
+func grantedScopes(ctx context.Context, requirement string) bool {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	for _, scope := range scopes {
+		if scope == requirement {
+			return true
+		}
+	}
+	return false
+}
+
+var authorizeAsset = graphql.NewInterface(graphql.InterfaceConfig{
+	Name: "Asset",
+	Fields: graphql.Fields{
+		"balance": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var authorizeAccount = graphql.NewObject(graphql.ObjectConfig{
+	Name:       "Account",
+	Interfaces: []*graphql.Interface{authorizeAsset},
+	Fields: graphql.Fields{
+		"balance": &graphql.Field{Type: graphql.Int},
+	},
+	AppliedDirectives: []*graphql.AppliedDirective{
+		{Name: "auth", Args: map[string]interface{}{"requires": []interface{}{"billing"}}},
+	},
+	IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+		return p.Value.(map[string]interface{})["kind"] == "account"
+	},
+})
+
+var authorizeWallet = graphql.NewObject(graphql.ObjectConfig{
+	Name:       "Wallet",
+	Interfaces: []*graphql.Interface{authorizeAsset},
+	Fields: graphql.Fields{
+		"balance": &graphql.Field{Type: graphql.Int},
+	},
+	IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+		return p.Value.(map[string]interface{})["kind"] == "wallet"
+	},
+})
+
+var authorizeTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "Ada", nil
+				},
+			},
+			"email": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "ada@example.com", nil
+				},
+				Authorize: func(p graphql.ResolveParams) error {
+					if !grantedScopes(p.Context, "email") {
+						return errors.New("Access to the email is denied.")
+					}
+					return nil
+				},
+			},
+			"account": &graphql.Field{
+				Type: authorizeAccount,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"kind": "account", "balance": 42}, nil
+				},
+			},
+			"assets": &graphql.Field{
+				Type: graphql.NewList(authorizeAsset),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []interface{}{
+						map[string]interface{}{"kind": "wallet", "balance": 7},
+						map[string]interface{}{"kind": "account", "balance": 42},
+					}, nil
+				},
+			},
+			"statement": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"year": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return fmt.Sprintf("Statement of %v", p.Args["year"]), nil
+				},
+				Authorize: func(p graphql.ResolveParams) error {
+					if year, _ := p.Args["year"].(int); year < 2020 && !grantedScopes(p.Context, "archive") {
+						return errors.New("Statements before 2020 are archived.")
+					}
+					return nil
+				},
+			},
+			"notes": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "VIP", nil
+				},
+				AppliedDirectives: []*graphql.AppliedDirective{
+					{Name: "auth", Args: map[string]interface{}{"requires": []interface{}{"admin"}}},
+				},
+			},
+		},
+	}),
+	Types:      []graphql.Type{authorizeAccount, authorizeWallet},
+	Directives: append([]*graphql.Directive{graphql.AuthDirective}, graphql.SpecifiedDirectives...),
+})
+
+func TestAuthorize_DeniedFieldsResolveToNullWithForbiddenErrors(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        authorizeTestSchema,
+		RequestString: `{ name email }`,
+		Context:       context.Background(),
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"name":  "Ada",
+			"email": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:    "Access to the email is denied.",
+				Locations:  []location.SourceLocation{{Line: 1, Column: 8}},
+				Extensions: map[string]interface{}{"code": graphql.ForbiddenErrorCode},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        authorizeTestSchema,
+		RequestString: `{ name email }`,
+		Context:       context.WithValue(context.Background(), scopesKey{}, []string{"email"}),
+	})
+	expected = &graphql.Result{
+		Data: map[string]interface{}{
+			"name":  "Ada",
+			"email": "ada@example.com",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestAuthorize_AuthDirectiveRequirements(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        authorizeTestSchema,
+		RequestString: `{ account { balance } notes }`,
+		Context:       context.WithValue(context.Background(), scopesKey{}, []string{"admin"}),
+		Authorize:     graphql.AuthorizeRequires(grantedScopes),
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"account": map[string]interface{}{"balance": nil},
+			"notes":   "VIP",
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:    `Access to Account.balance requires "billing".`,
+				Locations:  []location.SourceLocation{{Line: 1, Column: 13}},
+				Extensions: map[string]interface{}{"code": graphql.ForbiddenErrorCode},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestAuthorize_OnValidationRejectsWholeOperation(t *testing.T) {
+	forbidden := func(message string, column int) gqlerrors.FormattedError {
+		return gqlerrors.FormattedError{
+			Message:    message,
+			Locations:  []location.SourceLocation{{Line: 1, Column: column}},
+			Extensions: map[string]interface{}{"code": graphql.ForbiddenErrorCode},
+		}
+	}
+	tests := []struct {
+		description string
+		query       string
+		variables   map[string]interface{}
+		scopes      []string
+		expected    *graphql.Result
+	}{
+		{
+			description: "field denied for its literal arguments",
+			query:       `{ statement(year: 2010) }`,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{forbidden("Statements before 2020 are archived.", 3)},
+			},
+		},
+		{
+			description: "field whose arguments use variables, authorized during execution",
+			query:       `query ($year: Int) { statement(year: $year) }`,
+			variables:   map[string]interface{}{"year": 2010},
+			expected: &graphql.Result{
+				Data:   map[string]interface{}{"statement": nil},
+				Errors: []gqlerrors.FormattedError{forbidden("Statements before 2020 are archived.", 22)},
+			},
+		},
+		{
+			description: "field denied by its Authorize function",
+			query:       `{ name email notes }`,
+			scopes:      []string{"admin"},
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{forbidden("Access to the email is denied.", 8)},
+			},
+		},
+		{
+			description: "field of an interface denied on one of its possible types",
+			query:       `{ assets { balance } }`,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{forbidden(`Access to Account.balance requires "billing".`, 12)},
+			},
+		},
+		{
+			description: "field of an interface selected on its allowed possible types",
+			query:       `{ assets { ... on Wallet { balance } } }`,
+			expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"assets": []interface{}{
+						map[string]interface{}{"balance": 7},
+						map[string]interface{}{},
+					},
+				},
+			},
+		},
+		{
+			description: "field of an interface allowed on all of its possible types",
+			query:       `{ assets { balance } }`,
+			scopes:      []string{"billing"},
+			expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"assets": []interface{}{
+						map[string]interface{}{"balance": 7},
+						map[string]interface{}{"balance": 42},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:                authorizeTestSchema,
+			RequestString:         test.query,
+			VariableValues:        test.variables,
+			Context:               context.WithValue(context.Background(), scopesKey{}, test.scopes),
+			Authorize:             graphql.AuthorizeRequires(grantedScopes),
+			AuthorizeOnValidation: true,
+		})
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test %q - Diff: %v", test.description, testutil.Diff(test.expected, result))
+		}
+	}
+}
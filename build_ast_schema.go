@@ -0,0 +1,622 @@
+package graphql
+
+import (
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/language/source"
+)
+
+// BuildSchema parses a schema written in the GraphQL schema definition
+// language and builds a Schema from it. See BuildASTSchema.
+func BuildSchema(sdl string, scalars ...*Scalar) (Schema, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(sdl),
+			Name: "GraphQL SDL",
+		}),
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+	return BuildASTSchema(doc, scalars...)
+}
+
+// BuildASTSchema builds a Schema from a parsed schema definition language
+// document.
+//
+// The root types are taken from the schema definition, or default to the types
+// named Query, Mutation and Subscription. Fields have no resolvers, so the
+// default resolver is used and abstract types resolve their runtime type from
+// a "__typename" key when the source value is a map.
+//
+// Scalars defined by the document are built as scalars passing their values
+// through unchanged, unless they are given in scalars: those are used in place
+// of the definitions with their names, so that their values are parsed and
+// serialized by Go code.
+func BuildASTSchema(doc *ast.Document, scalars ...*Scalar) (Schema, error) {
+	if doc == nil {
+		return Schema{}, invariant(false, "Must provide a document ast.")
+	}
+
+	builder := &astSchemaBuilder{
+		types:         map[string]Type{},
+		defs:          map[string]ast.Node{},
+		extensions:    map[string][]*ast.ObjectDefinition{},
+		directiveDefs: map[string]*ast.DirectiveDefinition{},
+		directives:    map[string]*Directive{},
+		scalars:       map[string]*Scalar{},
+	}
+	for _, scalar := range scalars {
+		builder.scalars[scalar.Name()] = scalar
+	}
+	for _, ttype := range []Type{Int, Float, String, Boolean, ID} {
+		builder.types[ttype.Name()] = ttype
+	}
+
+	var schemaDef *ast.SchemaDefinition
+	typeNames := []string{}
+	directiveDefs := []*ast.DirectiveDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			if schemaDef != nil {
+				return Schema{}, invariant(false, "Must provide only one schema definition.")
+			}
+			schemaDef = def
+		case *ast.ScalarDefinition, *ast.ObjectDefinition, *ast.InterfaceDefinition,
+			*ast.UnionDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition:
+			name := typeDefinitionName(def)
+			if _, ok := builder.defs[name]; ok {
+				return Schema{}, invariantf(false, `Type "%v" was defined more than once.`, name)
+			}
+			builder.defs[name] = def
+			typeNames = append(typeNames, name)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil && def.Definition.Name != nil {
+				name := def.Definition.Name.Value
+				builder.extensions[name] = append(builder.extensions[name], def.Definition)
+			}
+		case *ast.DirectiveDefinition:
+			directiveDefs = append(directiveDefs, def)
+			if def.Name != nil {
+				builder.directiveDefs[def.Name.Value] = def
+			}
+		default:
+			return Schema{}, invariantf(false, "Schema definition language cannot contain a %v.", def.GetKind())
+		}
+	}
+	for name := range builder.extensions {
+		if _, ok := builder.defs[name]; !ok {
+			return Schema{}, invariantf(false, `Cannot extend type "%v" because it does not exist.`, name)
+		}
+	}
+
+	// Directives are built first, so that the arguments of the directives
+	// applied to types can be read with the types of their definitions.
+	config := SchemaConfig{}
+	definedDirectives := map[string]bool{}
+	for _, def := range directiveDefs {
+		directive, err := builder.buildDirective(def)
+		if err != nil {
+			return Schema{}, err
+		}
+		definedDirectives[directive.Name] = true
+		config.Directives = append(config.Directives, directive)
+	}
+	for _, directive := range SpecifiedDirectives {
+		if !definedDirectives[directive.Name] {
+			config.Directives = append(config.Directives, directive)
+		}
+	}
+
+	for _, name := range typeNames {
+		ttype, err := builder.namedType(name)
+		if err != nil {
+			return Schema{}, err
+		}
+		config.Types = append(config.Types, ttype)
+	}
+
+	operationTypes := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}
+	if schemaDef != nil {
+		config.Description = descriptionFromAST(schemaDef.Description)
+		config.AppliedDirectives = builder.appliedDirectives(schemaDef.Directives)
+		operationTypes = map[string]string{}
+		for _, operationType := range schemaDef.OperationTypes {
+			if operationType.Type == nil || operationType.Type.Name == nil {
+				continue
+			}
+			operationTypes[operationType.Operation] = operationType.Type.Name.Value
+		}
+	}
+	for operation, typeName := range operationTypes {
+		ttype, ok := builder.types[typeName]
+		if !ok {
+			if schemaDef != nil {
+				return Schema{}, invariantf(false, `Specified %v type "%v" not found in document.`, operation, typeName)
+			}
+			continue
+		}
+		object, ok := ttype.(*Object)
+		if !ok {
+			return Schema{}, invariantf(false, `Specified %v type "%v" must be an Object type.`, operation, typeName)
+		}
+		switch operation {
+		case ast.OperationTypeQuery:
+			config.Query = object
+		case ast.OperationTypeMutation:
+			config.Mutation = object
+		case ast.OperationTypeSubscription:
+			config.Subscription = object
+		}
+	}
+	if config.Query == nil {
+		return Schema{}, invariant(false, "Must provide schema definition with query type or a type named Query.")
+	}
+
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	if builder.err != nil {
+		return schema, builder.err
+	}
+	return schema, nil
+}
+
+// astSchemaBuilder builds named types and directives on demand from their definitions.
+type astSchemaBuilder struct {
+	types         map[string]Type
+	defs          map[string]ast.Node
+	extensions    map[string][]*ast.ObjectDefinition
+	directiveDefs map[string]*ast.DirectiveDefinition
+	// directives holds a nil entry while a directive is being built.
+	directives map[string]*Directive
+	// scalars are the scalars given in place of the scalar definitions.
+	scalars map[string]*Scalar
+	err     error
+}
+
+func (b *astSchemaBuilder) namedType(name string) (Type, error) {
+	if ttype, ok := b.types[name]; ok {
+		return ttype, nil
+	}
+	def, ok := b.defs[name]
+	if !ok {
+		return nil, invariantf(false, `Type "%v" not found in document.`, name)
+	}
+
+	var ttype Type
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		if scalar, ok := b.scalars[name]; ok {
+			ttype = scalar
+			break
+		}
+		scalar := newPassThroughScalar(name, descriptionFromAST(def.Description))
+		scalar.scalarConfig.SpecifiedByURL = specifiedByURLFromAST(def.Directives)
+		scalar.scalarConfig.AppliedDirectives = b.appliedDirectives(def.Directives)
+		ttype = scalar
+	case *ast.ObjectDefinition:
+		// The definitions are copied so that appending the extensions does
+		// not write into the arrays of the document.
+		fieldDefs := append([]*ast.FieldDefinition{}, def.Fields...)
+		interfaceNames := append([]*ast.Named{}, def.Interfaces...)
+		directives := append([]*ast.Directive{}, def.Directives...)
+		for _, extension := range b.extensions[name] {
+			fieldDefs = append(fieldDefs, extension.Fields...)
+			interfaceNames = append(interfaceNames, extension.Interfaces...)
+			directives = append(directives, extension.Directives...)
+		}
+		ttype = NewObject(ObjectConfig{
+			Name:              name,
+			Description:       descriptionFromAST(def.Description),
+			AppliedDirectives: b.appliedDirectives(directives),
+			Interfaces: InterfacesThunk(func() []*Interface {
+				return b.interfaces(name, interfaceNames)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return b.fields(name, fieldDefs)
+			}),
+		})
+	case *ast.InterfaceDefinition:
+		ttype = NewInterface(InterfaceConfig{
+			Name:              name,
+			Description:       descriptionFromAST(def.Description),
+			AppliedDirectives: b.appliedDirectives(def.Directives),
+			Interfaces: InterfacesThunk(func() []*Interface {
+				return b.interfaces(name, def.Interfaces)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return b.fields(name, def.Fields)
+			}),
+			ResolveType: resolveTypeFromTypename(b.types),
+		})
+	case *ast.UnionDefinition:
+		// Objects define their fields lazily, so members can be built up front.
+		types := []*Object{}
+		for _, member := range def.Types {
+			memberType, err := b.typeFromAST(member)
+			if err != nil {
+				return nil, err
+			}
+			object, ok := memberType.(*Object)
+			if !ok {
+				return nil, invariantf(false, `Union "%v" may only contain Object types, it cannot contain: %v.`, name, memberType)
+			}
+			types = append(types, object)
+		}
+		ttype = NewUnion(UnionConfig{
+			Name:              name,
+			Description:       descriptionFromAST(def.Description),
+			Types:             types,
+			ResolveType:       resolveTypeFromTypename(b.types),
+			AppliedDirectives: b.appliedDirectives(def.Directives),
+		})
+	case *ast.EnumDefinition:
+		values := EnumValueConfigMap{}
+		for _, value := range def.Values {
+			if value.Name == nil {
+				continue
+			}
+			values[value.Name.Value] = &EnumValueConfig{
+				Value:             value.Name.Value,
+				Description:       descriptionFromAST(value.Description),
+				DeprecationReason: deprecationReasonFromAST(value.Directives),
+				AppliedDirectives: b.appliedDirectives(value.Directives),
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:              name,
+			Description:       descriptionFromAST(def.Description),
+			Values:            values,
+			AppliedDirectives: b.appliedDirectives(def.Directives),
+		})
+	case *ast.InputObjectDefinition:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:              name,
+			Description:       descriptionFromAST(def.Description),
+			IsOneOf:           hasDirective(def.Directives, OneOfDirective.Name),
+			AppliedDirectives: b.appliedDirectives(def.Directives),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				return b.inputFields(def.Fields)
+			}),
+		})
+	}
+	if err := ttype.Error(); err != nil {
+		return nil, err
+	}
+	b.types[name] = ttype
+	return ttype, nil
+}
+
+func (b *astSchemaBuilder) typeFromAST(typeAST ast.Type) (Type, error) {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		ofType, err := b.typeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewList(ofType), nil
+	case *ast.NonNull:
+		ofType, err := b.typeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(ofType), nil
+	case *ast.Named:
+		if typeAST.Name == nil {
+			return nil, invariant(false, "Must be a named type.")
+		}
+		return b.namedType(typeAST.Name.Value)
+	}
+	return nil, invariantf(false, "Unknown type reference: %v.", typeAST)
+}
+
+func (b *astSchemaBuilder) interfaces(typeName string, names []*ast.Named) []*Interface {
+	interfaces := []*Interface{}
+	for _, name := range names {
+		ttype, err := b.typeFromAST(name)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		iface, ok := ttype.(*Interface)
+		if !ok {
+			b.fail(invariantf(false, `%v may only implement Interface types, it cannot implement: %v.`, typeName, ttype))
+			continue
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+func (b *astSchemaBuilder) fields(typeName string, fieldDefs []*ast.FieldDefinition) Fields {
+	fields := Fields{}
+	for _, fieldDef := range fieldDefs {
+		if fieldDef.Name == nil {
+			continue
+		}
+		name := fieldDef.Name.Value
+		if _, ok := fields[name]; ok {
+			b.fail(invariantf(false, `Field "%v.%v" can only be defined once.`, typeName, name))
+			continue
+		}
+		ttype, err := b.typeFromAST(fieldDef.Type)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		output, ok := ttype.(Output)
+		if !ok || !IsOutputType(ttype) {
+			b.fail(invariantf(false, `%v.%v field type must be Output Type but got: %v.`, typeName, name, ttype))
+			continue
+		}
+		args, err := b.argumentConfigs(fieldDef.Arguments)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		fields[name] = &Field{
+			Type:              output,
+			Args:              args,
+			Description:       descriptionFromAST(fieldDef.Description),
+			DeprecationReason: deprecationReasonFromAST(fieldDef.Directives),
+			AppliedDirectives: b.appliedDirectives(fieldDef.Directives),
+		}
+	}
+	return fields
+}
+
+func (b *astSchemaBuilder) argumentConfigs(argDefs []*ast.InputValueDefinition) (FieldConfigArgument, error) {
+	args := FieldConfigArgument{}
+	for _, argDef := range argDefs {
+		if argDef.Name == nil {
+			continue
+		}
+		ttype, defaultValue, err := b.inputValue(argDef)
+		if err != nil {
+			return nil, err
+		}
+		args[argDef.Name.Value] = &ArgumentConfig{
+			Type:              ttype,
+			Description:       descriptionFromAST(argDef.Description),
+			DefaultValue:      defaultValue,
+			DeprecationReason: deprecationReasonFromAST(argDef.Directives),
+			AppliedDirectives: b.appliedDirectives(argDef.Directives),
+		}
+	}
+	return args, nil
+}
+
+func (b *astSchemaBuilder) inputFields(fieldDefs []*ast.InputValueDefinition) InputObjectConfigFieldMap {
+	fields := InputObjectConfigFieldMap{}
+	for _, fieldDef := range fieldDefs {
+		if fieldDef.Name == nil {
+			continue
+		}
+		ttype, defaultValue, err := b.inputValue(fieldDef)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		fields[fieldDef.Name.Value] = &InputObjectFieldConfig{
+			Type:              ttype,
+			Description:       descriptionFromAST(fieldDef.Description),
+			DefaultValue:      defaultValue,
+			DeprecationReason: deprecationReasonFromAST(fieldDef.Directives),
+			AppliedDirectives: b.appliedDirectives(fieldDef.Directives),
+		}
+	}
+	return fields
+}
+
+// inputValue returns the type and the default value of an argument or input field definition.
+func (b *astSchemaBuilder) inputValue(def *ast.InputValueDefinition) (Input, interface{}, error) {
+	ttype, err := b.typeFromAST(def.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	input, ok := ttype.(Input)
+	if !ok || !IsInputType(ttype) {
+		return nil, nil, invariantf(false, `Argument "%v" must be Input Type but got: %v.`, def.Name.Value, ttype)
+	}
+	if def.DefaultValue == nil {
+		return input, nil, nil
+	}
+	return input, valueFromAST(def.DefaultValue, input, nil), nil
+}
+
+func (b *astSchemaBuilder) buildDirective(def *ast.DirectiveDefinition) (*Directive, error) {
+	if def.Name == nil {
+		return nil, invariant(false, "Directive must be named.")
+	}
+	if directive, ok := b.directives[def.Name.Value]; ok && directive != nil {
+		return directive, nil
+	}
+	b.directives[def.Name.Value] = nil
+	locations := []string{}
+	for _, location := range def.Locations {
+		locations = append(locations, location.Value)
+	}
+	args, err := b.argumentConfigs(def.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	directive := NewDirective(DirectiveConfig{
+		Name:         def.Name.Value,
+		Description:  descriptionFromAST(def.Description),
+		Locations:    locations,
+		Args:         args,
+		IsRepeatable: def.Repeatable,
+	})
+	b.directives[directive.Name] = directive
+	return directive, directive.err
+}
+
+// directive returns the definition of the named directive, or nil when it is
+// unknown or still being built.
+func (b *astSchemaBuilder) directive(name string) *Directive {
+	if directive, ok := b.directives[name]; ok {
+		return directive
+	}
+	if def, ok := b.directiveDefs[name]; ok {
+		directive, err := b.buildDirective(def)
+		if err != nil {
+			b.fail(err)
+			return nil
+		}
+		return directive
+	}
+	for _, directive := range SpecifiedDirectives {
+		if directive.Name == name {
+			return directive
+		}
+	}
+	return nil
+}
+
+// appliedDirectives returns the directives applied to a schema element, other
+// than @deprecated which is read into the deprecation reason. Argument values
+// are read with the types of the directive definition, and are left for
+// ValidateSchema to report when the directive or argument is unknown.
+func (b *astSchemaBuilder) appliedDirectives(directives []*ast.Directive) []*AppliedDirective {
+	applied := []*AppliedDirective{}
+	for _, directiveAST := range directives {
+		if directiveAST.Name == nil || directiveAST.Name.Value == DeprecatedDirective.Name ||
+			directiveAST.Name.Value == SpecifiedByDirective.Name || directiveAST.Name.Value == OneOfDirective.Name {
+			continue
+		}
+		argTypes := map[string]Input{}
+		if directive := b.directive(directiveAST.Name.Value); directive != nil {
+			for _, arg := range directive.Args {
+				argTypes[arg.Name()] = arg.Type
+			}
+		}
+		args := map[string]interface{}{}
+		for _, argAST := range directiveAST.Arguments {
+			if argAST.Name == nil || argAST.Value == nil {
+				continue
+			}
+			if argType, ok := argTypes[argAST.Name.Value]; ok {
+				args[argAST.Name.Value] = valueFromAST(argAST.Value, argType, nil)
+			} else {
+				args[argAST.Name.Value] = argAST.Value.GetValue()
+			}
+		}
+		applied = append(applied, &AppliedDirective{Name: directiveAST.Name.Value, Args: args})
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+	return applied
+}
+
+func (b *astSchemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func typeDefinitionName(def ast.Node) string {
+	var name *ast.Name
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		name = def.Name
+	case *ast.ObjectDefinition:
+		name = def.Name
+	case *ast.InterfaceDefinition:
+		name = def.Name
+	case *ast.UnionDefinition:
+		name = def.Name
+	case *ast.EnumDefinition:
+		name = def.Name
+	case *ast.InputObjectDefinition:
+		name = def.Name
+	}
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+func descriptionFromAST(description *ast.StringValue) string {
+	if description == nil {
+		return ""
+	}
+	return description.Value
+}
+
+// deprecationReasonFromAST returns the reason given by a @deprecated directive, if any.
+func deprecationReasonFromAST(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		args, _ := getArgumentValues(DeprecatedDirective.Args, directive.Arguments, nil)
+		if reason, ok := args["reason"].(string); ok && reason != "" {
+			return reason
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// specifiedByURLFromAST returns the URL given by a @specifiedBy directive, if any.
+func specifiedByURLFromAST(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != SpecifiedByDirective.Name {
+			continue
+		}
+		args, _ := getArgumentValues(SpecifiedByDirective.Args, directive.Arguments, nil)
+		url, _ := args["url"].(string)
+		return url
+	}
+	return ""
+}
+
+// hasDirective reports whether a directive with the given name is used.
+func hasDirective(directives []*ast.Directive, name string) bool {
+	for _, directive := range directives {
+		if directive.Name != nil && directive.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newPassThroughScalar returns a scalar which accepts and returns values as they are,
+// used for custom scalars of schemas built without their Go implementation.
+func newPassThroughScalar(name string, description string) *Scalar {
+	return NewScalar(ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize: func(value interface{}) interface{} {
+			return value
+		},
+		ParseValue: func(value interface{}) interface{} {
+			return value
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			return valueAST.GetValue()
+		},
+	})
+}
+
+// resolveTypeFromTypename returns a ResolveTypeFn which looks up the "__typename"
+// key of map values in the given types.
+func resolveTypeFromTypename(types map[string]Type) ResolveTypeFn {
+	return func(p ResolveTypeParams) *Object {
+		value, ok := p.Value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		typename, _ := value[TypeNameMetaFieldDef.Name].(string)
+		object, _ := types[typename].(*Object)
+		return object
+	}
+}
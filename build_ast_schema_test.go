@@ -0,0 +1,173 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+const petSchemaSDL = `
+schema {
+  query: Root
+}
+
+"""A pet which may be owned."""
+interface Pet {
+  name: String!
+}
+
+type Dog implements Pet {
+  name: String!
+  barks(loudly: Boolean = false): Boolean
+}
+
+type Cat implements Pet {
+  name: String!
+  meows: Boolean @deprecated(reason: "Cats are quiet.")
+}
+
+union DogOrCat = Dog | Cat
+
+enum Size {
+  SMALL
+  LARGE @deprecated
+}
+
+input PetFilter {
+  size: Size = SMALL
+  names: [String!]
+}
+
+scalar Time
+
+type Root {
+  pets(filter: PetFilter): [Pet]
+  any: DogOrCat
+  born: Time
+}
+
+extend type Root {
+  size: Size
+}
+
+directive @cached(ttl: Int = 60) on FIELD | QUERY
+`
+
+func TestBuildSchema_BuildsTypes(t *testing.T) {
+	schema, err := graphql.BuildSchema(petSchemaSDL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if schema.QueryType().Name() != "Root" {
+		t.Fatalf("expected Root query type, got %v", schema.QueryType())
+	}
+	fields := schema.QueryType().Fields()
+	for _, name := range []string{"pets", "any", "born", "size"} {
+		if _, ok := fields[name]; !ok {
+			t.Fatalf("expected Root.%v to be defined", name)
+		}
+	}
+	if got := fields["pets"].Type.String(); got != "[Pet]" {
+		t.Fatalf("expected Root.pets to be [Pet], got %v", got)
+	}
+	pet := schema.Type("Pet").(*graphql.Interface)
+	if pet.Description() != "A pet which may be owned." {
+		t.Fatalf("unexpected description: %q", pet.Description())
+	}
+	if len(schema.PossibleTypes(pet)) != 2 {
+		t.Fatalf("expected Pet to have two possible types")
+	}
+	cat := schema.Type("Cat").(*graphql.Object)
+	if cat.Fields()["meows"].DeprecationReason != "Cats are quiet." {
+		t.Fatalf("expected Cat.meows to be deprecated, got %q", cat.Fields()["meows"].DeprecationReason)
+	}
+	for _, value := range schema.Type("Size").(*graphql.Enum).Values() {
+		if value.Name == "LARGE" && value.DeprecationReason != graphql.DefaultDeprecationReason {
+			t.Fatalf("expected LARGE to be deprecated, got %q", value.DeprecationReason)
+		}
+	}
+	filter := schema.Type("PetFilter").(*graphql.InputObject)
+	if filter.Fields()["size"].DefaultValue != "SMALL" {
+		t.Fatalf("expected size default value SMALL, got %#v", filter.Fields()["size"].DefaultValue)
+	}
+	directive := schema.Directive("cached")
+	if directive == nil || !reflect.DeepEqual(directive.Locations, []string{"FIELD", "QUERY"}) {
+		t.Fatalf("expected @cached on FIELD | QUERY, got %v", directive)
+	}
+	if schema.Directive("skip") == nil {
+		t.Fatalf("expected specified directives to be included")
+	}
+}
+
+func TestBuildSchema_Executes(t *testing.T) {
+	schema, err := graphql.BuildSchema(petSchemaSDL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ pets { name ... on Dog { barks } } any { __typename } }`,
+		RootObject: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{"__typename": "Dog", "name": "Odie", "barks": true},
+				map[string]interface{}{"__typename": "Cat", "name": "Garfield"},
+			},
+			"any": map[string]interface{}{"__typename": "Cat"},
+		},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"pets": []interface{}{
+			map[string]interface{}{"name": "Odie", "barks": true},
+			map[string]interface{}{"name": "Garfield"},
+		},
+		"any": map[string]interface{}{"__typename": "Cat"},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("unexpected result: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestBuildSchema_RejectsInvalidDocuments(t *testing.T) {
+	tests := map[string]string{
+		"missing query type":  `type Foo { bar: String }`,
+		"unknown type":        `type Query { bar: Baz }`,
+		"duplicate type":      `type Query { a: String } type Query { b: String }`,
+		"extended unknown":    `type Query { a: String } extend type Foo { b: String }`,
+		"input as output":     `input In { a: String } type Query { a: In }`,
+		"operation":           `type Query { a: String } { a }`,
+		"unknown root":        `schema { query: Foo } type Query { a: String }`,
+		"non object in union": `interface I { a: String } union U = I type Query { u: U }`,
+	}
+	for name, sdl := range tests {
+		if _, err := graphql.BuildSchema(sdl); err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestBuildASTSchema_DoesNotModifyExtendedDefinitions(t *testing.T) {
+	doc := testutil.TestParse(t, `type Query { a: String } extend type Query { b: String }`)
+	query := doc.Definitions[0].(*ast.ObjectDefinition)
+	// Spare capacity lets an append write into the array of the document.
+	fields := make([]*ast.FieldDefinition, len(query.Fields), len(query.Fields)+1)
+	copy(fields, query.Fields)
+	query.Fields = fields
+
+	schema, err := graphql.BuildASTSchema(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := schema.QueryType().Fields()["b"]; !ok {
+		t.Fatalf("expected Query.b to be defined")
+	}
+	if spare := fields[:cap(fields)][len(fields)]; spare != nil {
+		t.Fatalf("expected the document to be left unchanged, got %v in its fields", spare.Name.Value)
+	}
+}
@@ -0,0 +1,436 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/GannettDigital/graphql/language/parser"
+)
+
+// BuildClientSchema builds a Schema from the result of an introspection query
+// such as IntrospectionQuery. The introspection argument is the
+// "data" of the query result, either as returned by Do or decoded from JSON.
+//
+// The resulting schema describes every type, field, argument, directive and
+// deprecation found in the introspection result. It has no resolvers, so it is
+// meant for validating documents and computing complexity rather than for
+// execution. Abstract types resolve their runtime type from a "__typename"
+// key when the source value is a map.
+func BuildClientSchema(introspection map[string]interface{}) (Schema, error) {
+	if data, ok := introspection["data"].(map[string]interface{}); ok {
+		introspection = data
+	}
+	schemaIntrospection, ok := introspection["__schema"].(map[string]interface{})
+	if !ok {
+		return Schema{}, fmt.Errorf(`Invalid or incomplete introspection result. Ensure that you `+
+			`are passing the "data" property of the introspection response and no "errors" `+
+			`were returned alongside: %v.`, introspection)
+	}
+
+	builder := &clientSchemaBuilder{
+		types: map[string]Type{},
+	}
+	for _, ttype := range []Type{Int, Float, String, Boolean, ID} {
+		builder.types[ttype.Name()] = ttype
+	}
+	for _, ttype := range []Type{SchemaType, DirectiveType, TypeType, FieldType,
+		InputValueType, EnumValueType, TypeKindEnumType, DirectiveLocationEnumType} {
+		builder.types[ttype.Name()] = ttype
+	}
+
+	typeIntrospections, ok := schemaIntrospection["types"].([]interface{})
+	if !ok {
+		return Schema{}, fmt.Errorf("Invalid or incomplete schema, unknown type list: %v.", schemaIntrospection["types"])
+	}
+
+	// Unions refer to their member objects directly rather than through a
+	// thunk, so every other named type is defined before any union.
+	config := SchemaConfig{}
+	config.Description, _ = schemaIntrospection["description"].(string)
+	var unions []map[string]interface{}
+	for _, typeIntrospection := range typeIntrospections {
+		typeIntrospection, ok := typeIntrospection.(map[string]interface{})
+		if !ok {
+			return Schema{}, fmt.Errorf("Invalid or incomplete schema, unknown type: %v.", typeIntrospection)
+		}
+		name, _ := typeIntrospection["name"].(string)
+		if _, ok := builder.types[name]; ok {
+			continue
+		}
+		if typeIntrospection["kind"] == TypeKindUnion {
+			unions = append(unions, typeIntrospection)
+			continue
+		}
+		ttype, err := builder.buildType(typeIntrospection)
+		if err != nil {
+			return Schema{}, err
+		}
+		builder.types[name] = ttype
+		config.Types = append(config.Types, ttype)
+	}
+	for _, typeIntrospection := range unions {
+		ttype, err := builder.buildUnion(typeIntrospection)
+		if err != nil {
+			return Schema{}, err
+		}
+		builder.types[ttype.Name()] = ttype
+		config.Types = append(config.Types, ttype)
+	}
+
+	var err error
+	if config.Query, err = builder.rootType(schemaIntrospection["queryType"]); err != nil {
+		return Schema{}, err
+	}
+	if config.Query == nil {
+		return Schema{}, fmt.Errorf("Invalid or incomplete schema, missing query type: %v.", schemaIntrospection["queryType"])
+	}
+	if config.Mutation, err = builder.rootType(schemaIntrospection["mutationType"]); err != nil {
+		return Schema{}, err
+	}
+	if config.Subscription, err = builder.rootType(schemaIntrospection["subscriptionType"]); err != nil {
+		return Schema{}, err
+	}
+
+	if directiveIntrospections, ok := schemaIntrospection["directives"].([]interface{}); ok {
+		for _, directiveIntrospection := range directiveIntrospections {
+			directive, err := builder.buildDirective(directiveIntrospection)
+			if err != nil {
+				return Schema{}, err
+			}
+			config.Directives = append(config.Directives, directive)
+		}
+	}
+
+	// Field and argument types are resolved lazily, any reference to an
+	// unknown type is recorded while the schema walks them.
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	if builder.err != nil {
+		return schema, builder.err
+	}
+	return schema, nil
+}
+
+// clientSchemaBuilder holds the named types built so far from an introspection result.
+type clientSchemaBuilder struct {
+	types map[string]Type
+	err   error
+}
+
+func (b *clientSchemaBuilder) buildType(typeIntrospection map[string]interface{}) (Type, error) {
+	name, _ := typeIntrospection["name"].(string)
+	description, _ := typeIntrospection["description"].(string)
+
+	switch typeIntrospection["kind"] {
+	case TypeKindScalar:
+		scalar := newPassThroughScalar(name, description)
+		scalar.scalarConfig.SpecifiedByURL, _ = typeIntrospection["specifiedByURL"].(string)
+		return scalar, nil
+	case TypeKindObject:
+		object := NewObject(ObjectConfig{
+			Name:        name,
+			Description: description,
+			Interfaces: InterfacesThunk(func() []*Interface {
+				return b.interfaces(typeIntrospection)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return b.fields(typeIntrospection)
+			}),
+		})
+		return object, object.Error()
+	case TypeKindInterface:
+		iface := NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: description,
+			Interfaces: InterfacesThunk(func() []*Interface {
+				return b.interfaces(typeIntrospection)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return b.fields(typeIntrospection)
+			}),
+			ResolveType: resolveTypeFromTypename(b.types),
+		})
+		return iface, iface.Error()
+	case TypeKindEnum:
+		valueIntrospections, ok := typeIntrospection["enumValues"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Introspection result missing enumValues: %v.", typeIntrospection)
+		}
+		values := EnumValueConfigMap{}
+		for _, valueIntrospection := range valueIntrospections {
+			valueIntrospection, _ := valueIntrospection.(map[string]interface{})
+			valueName, _ := valueIntrospection["name"].(string)
+			valueDescription, _ := valueIntrospection["description"].(string)
+			values[valueName] = &EnumValueConfig{
+				Value:             valueName,
+				Description:       valueDescription,
+				DeprecationReason: deprecationReasonFromIntrospection(valueIntrospection),
+			}
+		}
+		enum := NewEnum(EnumConfig{
+			Name:        name,
+			Description: description,
+			Values:      values,
+		})
+		return enum, enum.Error()
+	case TypeKindInputObject:
+		if _, ok := typeIntrospection["inputFields"].([]interface{}); !ok {
+			return nil, fmt.Errorf("Introspection result missing inputFields: %v.", typeIntrospection)
+		}
+		isOneOf, _ := typeIntrospection["isOneOf"].(bool)
+		inputObject := NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: description,
+			IsOneOf:     isOneOf,
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				return b.inputFields(typeIntrospection)
+			}),
+		})
+		return inputObject, inputObject.Error()
+	}
+	return nil, fmt.Errorf(`Invalid or incomplete introspection result. Received type with unknown kind: %v.`, typeIntrospection)
+}
+
+func (b *clientSchemaBuilder) buildUnion(typeIntrospection map[string]interface{}) (*Union, error) {
+	name, _ := typeIntrospection["name"].(string)
+	description, _ := typeIntrospection["description"].(string)
+	typeRefs, ok := typeIntrospection["possibleTypes"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Introspection result missing possibleTypes: %v.", typeIntrospection)
+	}
+	types := []*Object{}
+	for _, typeRef := range typeRefs {
+		ttype, err := b.typeRef(typeRef)
+		if err != nil {
+			return nil, err
+		}
+		object, ok := ttype.(*Object)
+		if !ok {
+			return nil, fmt.Errorf("Introspection must provide object type for possibleTypes of %v.", name)
+		}
+		types = append(types, object)
+	}
+	union := NewUnion(UnionConfig{
+		Name:        name,
+		Description: description,
+		Types:       types,
+		ResolveType: resolveTypeFromTypename(b.types),
+	})
+	return union, union.Error()
+}
+
+func (b *clientSchemaBuilder) buildDirective(directiveIntrospection interface{}) (*Directive, error) {
+	introspection, ok := directiveIntrospection.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Invalid or incomplete introspection result, unknown directive: %v.", directiveIntrospection)
+	}
+	name, _ := introspection["name"].(string)
+	for _, directive := range SpecifiedDirectives {
+		if directive.Name == name {
+			return directive, nil
+		}
+	}
+	description, _ := introspection["description"].(string)
+	isRepeatable, _ := introspection["isRepeatable"].(bool)
+	locations := []string{}
+	if locationList, ok := introspection["locations"].([]interface{}); ok {
+		for _, location := range locationList {
+			if location, ok := location.(string); ok {
+				locations = append(locations, location)
+			}
+		}
+	}
+	args, err := b.argumentConfigs(introspection["args"])
+	if err != nil {
+		return nil, err
+	}
+	directive := NewDirective(DirectiveConfig{
+		Name:         name,
+		Description:  description,
+		Locations:    locations,
+		Args:         args,
+		IsRepeatable: isRepeatable,
+	})
+	return directive, directive.err
+}
+
+func (b *clientSchemaBuilder) rootType(typeRef interface{}) (*Object, error) {
+	if typeRef == nil {
+		return nil, nil
+	}
+	ttype, err := b.typeRef(typeRef)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := ttype.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("Root type %v must be an object type.", ttype)
+	}
+	return object, nil
+}
+
+// typeRef resolves a possibly wrapped type reference, as produced by the
+// TypeRef fragment of the introspection query.
+func (b *clientSchemaBuilder) typeRef(typeRef interface{}) (Type, error) {
+	ref, ok := typeRef.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Invalid or incomplete introspection result, unknown type reference: %v.", typeRef)
+	}
+	switch ref["kind"] {
+	case TypeKindList:
+		ofType, err := b.typeRef(ref["ofType"])
+		if err != nil {
+			return nil, err
+		}
+		return NewList(ofType), nil
+	case TypeKindNonNull:
+		ofType, err := b.typeRef(ref["ofType"])
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(ofType), nil
+	}
+	name, _ := ref["name"].(string)
+	ttype, ok := b.types[name]
+	if !ok {
+		return nil, fmt.Errorf(`Invalid or incomplete schema, unknown type: %v. Ensure that a full `+
+			`introspection query is used in order to build a client schema.`, name)
+	}
+	return ttype, nil
+}
+
+func (b *clientSchemaBuilder) interfaces(typeIntrospection map[string]interface{}) []*Interface {
+	interfaces := []*Interface{}
+	typeRefs, _ := typeIntrospection["interfaces"].([]interface{})
+	for _, typeRef := range typeRefs {
+		ttype, err := b.typeRef(typeRef)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		iface, ok := ttype.(*Interface)
+		if !ok {
+			b.fail(fmt.Errorf("Introspection must provide interface type for interfaces of %v.", typeIntrospection["name"]))
+			continue
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+func (b *clientSchemaBuilder) fields(typeIntrospection map[string]interface{}) Fields {
+	fields := Fields{}
+	fieldIntrospections, ok := typeIntrospection["fields"].([]interface{})
+	if !ok {
+		b.fail(fmt.Errorf("Introspection result missing fields: %v.", typeIntrospection["name"]))
+		return fields
+	}
+	for _, fieldIntrospection := range fieldIntrospections {
+		fieldIntrospection, _ := fieldIntrospection.(map[string]interface{})
+		name, _ := fieldIntrospection["name"].(string)
+		description, _ := fieldIntrospection["description"].(string)
+		ttype, err := b.typeRef(fieldIntrospection["type"])
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		output, ok := ttype.(Output)
+		if !ok || !IsOutputType(ttype) {
+			b.fail(fmt.Errorf("Introspection must provide output type for fields, but received: %v.", ttype))
+			continue
+		}
+		args, err := b.argumentConfigs(fieldIntrospection["args"])
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		fields[name] = &Field{
+			Type:              output,
+			Args:              args,
+			Description:       description,
+			DeprecationReason: deprecationReasonFromIntrospection(fieldIntrospection),
+		}
+	}
+	return fields
+}
+
+func (b *clientSchemaBuilder) argumentConfigs(argIntrospections interface{}) (FieldConfigArgument, error) {
+	args := FieldConfigArgument{}
+	argList, _ := argIntrospections.([]interface{})
+	for _, argIntrospection := range argList {
+		argIntrospection, _ := argIntrospection.(map[string]interface{})
+		name, _ := argIntrospection["name"].(string)
+		description, _ := argIntrospection["description"].(string)
+		ttype, defaultValue, err := b.inputValue(argIntrospection)
+		if err != nil {
+			return nil, err
+		}
+		args[name] = &ArgumentConfig{
+			Type:              ttype,
+			Description:       description,
+			DefaultValue:      defaultValue,
+			DeprecationReason: deprecationReasonFromIntrospection(argIntrospection),
+		}
+	}
+	return args, nil
+}
+
+func (b *clientSchemaBuilder) inputFields(typeIntrospection map[string]interface{}) InputObjectConfigFieldMap {
+	fields := InputObjectConfigFieldMap{}
+	fieldList, _ := typeIntrospection["inputFields"].([]interface{})
+	for _, fieldIntrospection := range fieldList {
+		fieldIntrospection, _ := fieldIntrospection.(map[string]interface{})
+		name, _ := fieldIntrospection["name"].(string)
+		description, _ := fieldIntrospection["description"].(string)
+		ttype, defaultValue, err := b.inputValue(fieldIntrospection)
+		if err != nil {
+			b.fail(err)
+			continue
+		}
+		fields[name] = &InputObjectFieldConfig{
+			Type:              ttype,
+			Description:       description,
+			DefaultValue:      defaultValue,
+			DeprecationReason: deprecationReasonFromIntrospection(fieldIntrospection),
+		}
+	}
+	return fields
+}
+
+// inputValue returns the type and the default value of an __InputValue.
+func (b *clientSchemaBuilder) inputValue(introspection map[string]interface{}) (Input, interface{}, error) {
+	ttype, err := b.typeRef(introspection["type"])
+	if err != nil {
+		return nil, nil, err
+	}
+	input, ok := ttype.(Input)
+	if !ok || !IsInputType(ttype) {
+		return nil, nil, fmt.Errorf("Introspection must provide input type for arguments, but received: %v.", ttype)
+	}
+	defaultValueStr, ok := introspection["defaultValue"].(string)
+	if !ok {
+		return input, nil, nil
+	}
+	valueAST, err := parser.ParseValue(parser.ParseParams{Source: defaultValueStr})
+	if err != nil {
+		return nil, nil, err
+	}
+	return input, valueFromAST(valueAST, input, nil), nil
+}
+
+func (b *clientSchemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func deprecationReasonFromIntrospection(introspection map[string]interface{}) string {
+	if isDeprecated, _ := introspection["isDeprecated"].(bool); !isDeprecated {
+		return ""
+	}
+	if reason, ok := introspection["deprecationReason"].(string); ok && reason != "" {
+		return reason
+	}
+	return DefaultDeprecationReason
+}
@@ -0,0 +1,235 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+func introspectSchema(t *testing.T, schema graphql.Schema) map[string]interface{} {
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: testutil.IntrospectionQuery,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("introspection failed: %v", result.Errors)
+	}
+	data, ok := result.Data.(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected introspection result: %v", result.Data)
+	}
+	return data
+}
+
+func TestBuildClientSchema_StarWars(t *testing.T) {
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, testutil.StarWarsSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	serverTypes := testutil.StarWarsSchema.TypeMap()
+	clientTypes := clientSchema.TypeMap()
+	if len(clientTypes) != len(serverTypes) {
+		t.Fatalf("expected %d types, got %d", len(serverTypes), len(clientTypes))
+	}
+	for name, serverType := range serverTypes {
+		clientType, ok := clientTypes[name]
+		if !ok {
+			t.Fatalf("missing type %v", name)
+		}
+		if reflect.TypeOf(clientType) != reflect.TypeOf(serverType) {
+			t.Fatalf("type %v: expected %T, got %T", name, serverType, clientType)
+		}
+	}
+
+	human := clientSchema.Type("Human").(*graphql.Object)
+	if got := human.Fields()["id"].Type.String(); got != "String!" {
+		t.Fatalf("expected Human.id to be String!, got %v", got)
+	}
+	if got := human.Interfaces(); len(got) != 1 || got[0].Name() != "Character" {
+		t.Fatalf("expected Human to implement Character, got %v", got)
+	}
+	hero := clientSchema.QueryType().Fields()["hero"]
+	if len(hero.Args) != 1 || hero.Args[0].Type != clientSchema.Type("Episode") {
+		t.Fatalf("expected hero(episode: Episode), got %v", hero.Args)
+	}
+	if len(clientSchema.PossibleTypes(clientSchema.Type("Character").(*graphql.Interface))) != 2 {
+		t.Fatalf("expected Character to have two possible types")
+	}
+}
+
+func TestBuildClientSchema_ValidatesDocuments(t *testing.T) {
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, testutil.StarWarsSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	valid := testutil.TestParse(t, `
+		query HeroNameAndFriends($episode: Episode) {
+			hero(episode: $episode) {
+				name
+				friends { name }
+				... on Droid { primaryFunction }
+			}
+		}
+	`)
+	if result := graphql.ValidateDocument(&clientSchema, valid, nil); !result.IsValid {
+		t.Fatalf("expected document to be valid, got %v", result.Errors)
+	}
+
+	invalid := testutil.TestParse(t, `{ hero(episode: CLONES) { name height } }`)
+	result := graphql.ValidateDocument(&clientSchema, invalid, nil)
+	if result.IsValid || len(result.Errors) != 2 {
+		t.Fatalf("expected two validation errors, got %v", result.Errors)
+	}
+}
+
+func TestBuildClientSchema_FromJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]interface{}{"data": introspectSchema(t, testutil.StarWarsSchema)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var introspection map[string]interface{}
+	if err := json.Unmarshal(b, &introspection); err != nil {
+		t.Fatal(err)
+	}
+	clientSchema, err := graphql.BuildClientSchema(introspection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clientSchema.Type("Droid") == nil {
+		t.Fatalf("expected Droid type in client schema")
+	}
+}
+
+func TestBuildClientSchema_PreservesSchemaDetails(t *testing.T) {
+	colorEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0},
+			"GREEN": &graphql.EnumValueConfig{Value: 1, DeprecationReason: "Use RED."},
+		},
+	})
+	geoPoint := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "GeoPoint",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"lat": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+			"alt": &graphql.InputObjectFieldConfig{Type: graphql.Float, DefaultValue: 1.5},
+		},
+	})
+	dateTime := graphql.NewScalar(graphql.ScalarConfig{
+		Name:         "Timestamp",
+		Serialize:    func(value interface{}) interface{} { return value },
+		ParseValue:   func(value interface{}) interface{} { return value },
+		ParseLiteral: graphql.String.ParseLiteral,
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:     "Dog",
+		Fields:   graphql.Fields{"barks": &graphql.Field{Type: graphql.Boolean}},
+		IsTypeOf: func(p graphql.IsTypeOfParams) bool { return true },
+	})
+	petUnion := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType},
+	})
+	customDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "cached",
+		Locations: []string{graphql.DirectiveLocationField},
+		Args: graphql.FieldConfigArgument{
+			"ttl": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 60},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"color": &graphql.Field{
+					Type: colorEnum,
+					Args: graphql.FieldConfigArgument{
+						"near":  &graphql.ArgumentConfig{Type: geoPoint},
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					},
+				},
+				"pet":     &graphql.Field{Type: petUnion},
+				"updated": &graphql.Field{Type: dateTime, DeprecationReason: "Gone."},
+			},
+		}),
+		Directives: append([]*graphql.Directive{customDirective}, graphql.SpecifiedDirectives...),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fields := clientSchema.QueryType().Fields()
+	if fields["updated"].DeprecationReason != "Gone." {
+		t.Fatalf("expected field deprecation to be preserved, got %q", fields["updated"].DeprecationReason)
+	}
+	for _, arg := range fields["color"].Args {
+		if arg.Name() == "limit" && arg.DefaultValue != 10 {
+			t.Fatalf("expected limit default value 10, got %#v", arg.DefaultValue)
+		}
+	}
+	for _, value := range clientSchema.Type("Color").(*graphql.Enum).Values() {
+		if value.Name == "GREEN" && value.DeprecationReason != "Use RED." {
+			t.Fatalf("expected enum value deprecation to be preserved, got %q", value.DeprecationReason)
+		}
+	}
+	if alt := clientSchema.Type("GeoPoint").(*graphql.InputObject).Fields()["alt"]; alt.DefaultValue != 1.5 {
+		t.Fatalf("expected alt default value 1.5, got %#v", alt.DefaultValue)
+	}
+	if _, ok := clientSchema.Type("Pet").(*graphql.Union); !ok {
+		t.Fatalf("expected Pet to be a union")
+	}
+	directive := clientSchema.Directive("cached")
+	if directive == nil || len(directive.Args) != 1 || directive.Args[0].DefaultValue != 60 {
+		t.Fatalf("expected @cached(ttl: Int = 60), got %v", directive)
+	}
+	if clientSchema.Directive("include") != graphql.IncludeDirective {
+		t.Fatalf("expected specified directives to be reused")
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: `{ color(near: {lat: 1}) @cached(ttl: 5) pet { ... on Dog { barks } } }`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := graphql.ValidateDocument(&clientSchema, doc, nil); !result.IsValid {
+		t.Fatalf("expected document to be valid, got %v", result.Errors)
+	}
+	cost, _, err := graphql.QueryComplexity(graphql.ExecuteParams{Schema: clientSchema, AST: doc})
+	if err != nil || cost != 0 {
+		t.Fatalf("expected zero cost, got %d, %v", cost, err)
+	}
+}
+
+func TestBuildClientSchema_RejectsIncompleteIntrospection(t *testing.T) {
+	if _, err := graphql.BuildClientSchema(map[string]interface{}{}); err == nil {
+		t.Fatalf("expected an error for a missing __schema")
+	}
+
+	introspection := introspectSchema(t, testutil.StarWarsSchema)
+	schemaIntrospection := introspection["__schema"].(map[string]interface{})
+	types := []interface{}{}
+	for _, ttype := range schemaIntrospection["types"].([]interface{}) {
+		if ttype.(map[string]interface{})["name"] != "Episode" {
+			types = append(types, ttype)
+		}
+	}
+	schemaIntrospection["types"] = types
+	_, err := graphql.BuildClientSchema(introspection)
+	if err == nil {
+		t.Fatalf("expected an error for a missing type")
+	}
+	expected := "Invalid or incomplete schema, unknown type: Episode. Ensure that a full introspection query is used in order to build a client schema."
+	if err.Error() != expected {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
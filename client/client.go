@@ -0,0 +1,156 @@
+// Package client sends GraphQL requests to remote servers.
+//
+// It is the runtime of the code generated by graphql-gen from operation
+// documents, and can be used on its own:
+//
+//	c := client.New("https://example.com/graphql")
+//	var data struct {
+//		Hero struct {
+//			Name string `json:"name"`
+//		} `json:"hero"`
+//	}
+//	err := c.Do(ctx, &client.Request{Query: "{ hero { name } }"}, &data)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName,omitempty"`
+	// Variables is encoded as JSON, so it may be a map or a struct.
+	Variables interface{} `json:"variables,omitempty"`
+}
+
+// Response is a GraphQL response, with its data left encoded.
+type Response struct {
+	Data       json.RawMessage        `json:"data"`
+	Errors     Errors                 `json:"errors,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Location is the line and column of an error in the request document.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is an error returned by the server.
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
+
+// Errors are the errors returned by the server for a request.
+type Errors []Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Transport sends requests to a server.
+type Transport interface {
+	Do(ctx context.Context, request *Request) (*Response, error)
+}
+
+// TransportFunc adapts a function to a Transport.
+type TransportFunc func(ctx context.Context, request *Request) (*Response, error)
+
+// Do calls f(ctx, request).
+func (f TransportFunc) Do(ctx context.Context, request *Request) (*Response, error) {
+	return f(ctx, request)
+}
+
+// HTTPTransport posts requests as JSON to a URL.
+type HTTPTransport struct {
+	URL string
+	// Client sends the requests. http.DefaultClient is used when it is nil.
+	Client *http.Client
+	// Header is added to every request.
+	Header http.Header
+}
+
+// Do posts the request and decodes the response. Responses with a status
+// other than 200 are errors, unless their body is a GraphQL response.
+func (t *HTTPTransport) Do(ctx context.Context, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest = httpRequest.WithContext(ctx)
+	for name, values := range t.Header {
+		httpRequest.Header[name] = values
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	body, err = ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &Response{}
+	if err := json.Unmarshal(body, response); err != nil || (httpResponse.StatusCode != http.StatusOK && response.Data == nil && len(response.Errors) == 0) {
+		return nil, fmt.Errorf("GraphQL server responded with status %v.", httpResponse.Status)
+	}
+	return response, nil
+}
+
+// Client sends requests through a Transport and decodes their data.
+type Client struct {
+	Transport Transport
+}
+
+// New returns a Client posting requests to url.
+func New(url string) *Client {
+	return &Client{Transport: &HTTPTransport{URL: url}}
+}
+
+// Do sends a request and decodes the data of its response into data, which
+// may be nil. When the server returns errors, data is still decoded and the
+// errors are returned as Errors.
+func (c *Client) Do(ctx context.Context, request *Request, data interface{}) error {
+	response, err := c.Transport.Do(ctx, request)
+	if err != nil {
+		return err
+	}
+	if data != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql/client"
+)
+
+func TestClient_PostsRequestsOverHTTP(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected headers: %v", r.Header)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		w.Write([]byte(`{"data": {"hero": {"name": "R2-D2"}}, "errors": [{"message": "Partial failure.", "path": ["hero", "friends"]}]}`))
+	}))
+	defer server.Close()
+
+	c := &client.Client{Transport: &client.HTTPTransport{URL: server.URL, Header: http.Header{"Authorization": {"token"}}}}
+	var data struct {
+		Hero struct {
+			Name string `json:"name"`
+		} `json:"hero"`
+	}
+	err := c.Do(context.Background(), &client.Request{
+		Query:         "query Hero($episode: Episode) { hero(episode: $episode) { name } }",
+		OperationName: "Hero",
+		Variables: struct {
+			Episode string `json:"episode"`
+		}{"JEDI"},
+	}, &data)
+
+	expected := map[string]interface{}{
+		"query":         "query Hero($episode: Episode) { hero(episode: $episode) { name } }",
+		"operationName": "Hero",
+		"variables":     map[string]interface{}{"episode": "JEDI"},
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected request %v, got %v", expected, received)
+	}
+	if data.Hero.Name != "R2-D2" {
+		t.Fatalf("expected data to be decoded, got %+v", data)
+	}
+	errs, ok := err.(client.Errors)
+	if !ok || len(errs) != 1 || errs[0].Message != "Partial failure." || len(errs[0].Path) != 2 {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
+
+func TestClient_ReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := client.New(server.URL).Do(context.Background(), &client.Request{Query: "{ hero { name } }"}, nil)
+	if err == nil || err.Error() != "GraphQL server responded with status 503 Service Unavailable." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Command graphql-diff compares two schemas written in the GraphQL schema
+// definition language and reports the changes which break or may break
+// existing clients.
+//
+//	graphql-diff old.graphql new.graphql
+//
+// It exits with status 1 when breaking changes are found and with status 2
+// when a schema cannot be read or built.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/GannettDigital/graphql"
+)
+
+func main() {
+	dangerous := flag.Bool("dangerous", true, "also report dangerous changes")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] old.graphql new.graphql\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	oldSchema, err := loadSchema(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	newSchema, err := loadSchema(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	breakingChanges := graphql.FindBreakingChanges(oldSchema, newSchema)
+	for _, change := range breakingChanges {
+		fmt.Printf("BREAKING %v: %v\n", change.Type, change.Description)
+	}
+	if *dangerous {
+		for _, change := range graphql.FindDangerousChanges(oldSchema, newSchema) {
+			fmt.Printf("DANGEROUS %v: %v\n", change.Type, change.Description)
+		}
+	}
+	if len(breakingChanges) > 0 {
+		os.Exit(1)
+	}
+}
+
+func loadSchema(path string) (graphql.Schema, error) {
+	sdl, err := ioutil.ReadFile(path)
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	schema, err := graphql.BuildSchema(string(sdl))
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("%v: %v", path, err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,641 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/printer"
+)
+
+// clientGenerator writes the Go code for the operations of a document.
+type clientGenerator struct {
+	*generator
+	document  *ast.Document
+	fragments map[string]*ast.FragmentDefinition
+	// fragmentStructs are the structs of the fragments generated so far.
+	fragmentStructs map[string]*selectionStruct
+	// structs are the generated structs, in the order they are written.
+	structs []*selectionStruct
+	// names are the Go names declared so far.
+	names map[string]bool
+	// enums and inputs are the names of the enum and input object types used
+	// by the operations.
+	enums  map[string]bool
+	inputs map[string]bool
+}
+
+// selectionStruct is the struct decoding the data of a selection set.
+type selectionStruct struct {
+	name       string
+	parentType graphql.Type
+	// fragment is set for the structs of fragment definitions, which are
+	// embedded into others and so cannot implement json.Unmarshaler.
+	fragment   bool
+	typename   bool
+	fields     []*selectionField
+	embedded   []*selectionStruct
+	conditions []*condition
+}
+
+// selectionField is a field of a selection set, with its sub-selections
+// merged across the selections sharing its response key.
+type selectionField struct {
+	key        string
+	definition *graphql.FieldDefinition
+	selections []ast.Selection
+	goType     string
+}
+
+// condition is a part of a selection set which only applies to some of its
+// possible types, from an inline fragment or a fragment spread. It is decoded
+// into a pointer field when the data has one of those types.
+type condition struct {
+	field      string
+	typeName   string
+	selections []ast.Selection
+	fragment   string
+	target     *selectionStruct
+}
+
+// generateClient returns the formatted Go code of a client for the operations
+// of a document in package pkg. The document must be valid for the schema.
+func generateClient(schema graphql.Schema, document *ast.Document, pkg string) ([]byte, error) {
+	if result := graphql.ValidateDocument(&schema, document, nil); !result.IsValid {
+		messages := []string{}
+		for _, err := range result.Errors {
+			messages = append(messages, err.Message)
+		}
+		return nil, fmt.Errorf("Invalid operations: %v", strings.Join(messages, " "))
+	}
+	c := &clientGenerator{
+		generator:       &generator{schema: schema},
+		document:        document,
+		fragments:       map[string]*ast.FragmentDefinition{},
+		fragmentStructs: map[string]*selectionStruct{},
+		names:           map[string]bool{},
+		enums:           map[string]bool{},
+		inputs:          map[string]bool{},
+	}
+	operations := []*ast.OperationDefinition{}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if definition.Name == nil {
+				return nil, fmt.Errorf("Operations must be named to generate a client, found an anonymous %v.", definition.Operation)
+			}
+			if definition.Operation == ast.OperationTypeSubscription {
+				return nil, fmt.Errorf("Cannot generate a client for subscription %q.", definition.Name.Value)
+			}
+			operations = append(operations, definition)
+		}
+	}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			c.addTypenames(definition.SelectionSet, schema.Type(definition.TypeCondition.Name.Value))
+		case *ast.OperationDefinition:
+			c.addTypenames(definition.SelectionSet, c.rootType(definition))
+		}
+	}
+
+	operationCode := &bytes.Buffer{}
+	names := []string{}
+	for _, operation := range operations {
+		names = append(names, operation.Name.Value)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, operation := range operations {
+			if operation.Name.Value == name {
+				if err := c.operation(operation, operationCode); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.enums) {
+		if err := c.declare(goName(name)); err != nil {
+			return nil, err
+		}
+		c.enum(schema.Type(name).(*graphql.Enum))
+	}
+	for _, name := range sortedKeys(c.inputs) {
+		if err := c.declare(goName(name)); err != nil {
+			return nil, err
+		}
+		c.clientInputObject(schema.Type(name).(*graphql.InputObject))
+	}
+	usesJSON := false
+	for _, s := range c.structs {
+		c.selectionStruct(s)
+		usesJSON = usesJSON || s.needsDecoding()
+	}
+
+	header := &bytes.Buffer{}
+	fmt.Fprintf(header, "// Code generated by graphql-gen. DO NOT EDIT.\n\npackage %v\n\nimport (\n\t\"context\"\n", pkg)
+	if usesJSON {
+		fmt.Fprintf(header, "\t\"encoding/json\"\n")
+	}
+	if c.usesTime {
+		fmt.Fprintf(header, "\t\"time\"\n")
+	}
+	fmt.Fprintf(header, "\n\t\"github.com/GannettDigital/graphql/client\"\n)\n\n")
+
+	code, err := format.Source(append(append(header.Bytes(), operationCode.Bytes()...), c.buf.Bytes()...))
+	if err != nil {
+		return nil, fmt.Errorf("Generated code is invalid: %v.", err)
+	}
+	return code, nil
+}
+
+func (c *clientGenerator) rootType(operation *ast.OperationDefinition) *graphql.Object {
+	if operation.Operation == ast.OperationTypeMutation {
+		return c.schema.MutationType()
+	}
+	return c.schema.QueryType()
+}
+
+// operation writes the document constant, the variables struct and the
+// function of an operation, and declares the structs of its data.
+func (c *clientGenerator) operation(operation *ast.OperationDefinition, w *bytes.Buffer) error {
+	name := goName(operation.Name.Value)
+	documentName := name + "Document"
+	responseName := name + "Response"
+	variablesName := name + "Variables"
+	for _, declared := range []string{name, documentName} {
+		if err := c.declare(declared); err != nil {
+			return err
+		}
+	}
+
+	definitions := []ast.Node{operation}
+	for _, fragment := range c.usedFragments(operation.SelectionSet, map[string]bool{}) {
+		definitions = append(definitions, c.fragments[fragment])
+	}
+	document := printer.Print(ast.NewDocument(&ast.Document{Definitions: definitions})).(string)
+	fmt.Fprintf(w, "// %v is the document of the %v %q.\n", documentName, operation.Operation, operation.Name.Value)
+	if strings.Contains(document, "`") {
+		fmt.Fprintf(w, "const %v = %q\n\n", documentName, document)
+	} else {
+		fmt.Fprintf(w, "const %v = `%v`\n\n", documentName, document)
+	}
+
+	if len(operation.VariableDefinitions) > 0 {
+		if err := c.declare(variablesName); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "// %v are the variables of the %v %q.\n", variablesName, operation.Operation, operation.Name.Value)
+		fmt.Fprintf(w, "type %v struct {\n", variablesName)
+		for _, definition := range operation.VariableDefinitions {
+			ttype := c.typeFromAST(definition.Type)
+			c.useInputType(ttype)
+			tag := definition.Variable.Name.Value
+			if _, ok := ttype.(*graphql.NonNull); !ok {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(w, "\t%v %v `json:%q`\n", goName(definition.Variable.Name.Value), c.goType(ttype), tag)
+		}
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	if _, err := c.build(responseName, name, c.rootType(operation), operation.SelectionSet.Selections, false); err != nil {
+		return err
+	}
+
+	params, variables := "", "nil"
+	if len(operation.VariableDefinitions) > 0 {
+		params, variables = fmt.Sprintf(", variables %v", variablesName), "variables"
+	}
+	fmt.Fprintf(w, "// %v sends the %v %q.\n", name, operation.Operation, operation.Name.Value)
+	fmt.Fprintf(w, "// Its data is returned along with the errors of the response, as client.Errors.\n")
+	fmt.Fprintf(w, "func %v(ctx context.Context, c *client.Client%v) (*%v, error) {\n", name, params, responseName)
+	fmt.Fprintf(w, "\tdata := &%v{}\n", responseName)
+	fmt.Fprintf(w, "\terr := c.Do(ctx, &client.Request{Query: %v, OperationName: %q, Variables: %v}, data)\n", documentName, operation.Name.Value, variables)
+	fmt.Fprintf(w, "\treturn data, err\n}\n\n")
+	return nil
+}
+
+// build declares the struct of a selection set on parentType, and of its
+// fields, conditions and fragments. Field structs are named prefix followed by
+// the response key.
+func (c *clientGenerator) build(name string, prefix string, parentType graphql.Type, selections []ast.Selection, fragment bool) (*selectionStruct, error) {
+	if err := c.declare(name); err != nil {
+		return nil, err
+	}
+	s := &selectionStruct{name: name, parentType: parentType, fragment: fragment}
+	c.structs = append(c.structs, s)
+	s.typename = graphql.IsAbstractType(parentType)
+	if err := c.collect(s, selections); err != nil {
+		return nil, err
+	}
+
+	for _, field := range s.fields {
+		named := namedType(field.definition.Type)
+		structName := ""
+		if graphql.IsCompositeType(named) {
+			structName = prefix + goName(field.key)
+			if _, err := c.build(structName, structName, named, field.selections, false); err != nil {
+				return nil, err
+			}
+		}
+		field.goType = c.outputType(field.definition.Type, structName)
+	}
+	for _, cond := range s.conditions {
+		if cond.fragment != "" {
+			target, err := c.fragmentStruct(cond.fragment)
+			if err != nil {
+				return nil, err
+			}
+			cond.target = target
+			continue
+		}
+		structName := name + "On" + goName(cond.typeName)
+		target, err := c.build(structName, structName, c.schema.Type(cond.typeName), cond.selections, false)
+		if err != nil {
+			return nil, err
+		}
+		cond.target = target
+	}
+	return s, nil
+}
+
+// collect adds the fields, fragments and conditions of selections to s.
+func (c *clientGenerator) collect(s *selectionStruct, selections []ast.Selection) error {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			key := selection.Name.Value
+			if selection.Alias != nil {
+				key = selection.Alias.Value
+			}
+			if key == "__typename" {
+				s.typename = true
+				continue
+			}
+			var subSelections []ast.Selection
+			if selection.SelectionSet != nil {
+				subSelections = selection.SelectionSet.Selections
+			}
+			merged := false
+			for _, field := range s.fields {
+				if field.key == key {
+					field.selections = append(field.selections, subSelections...)
+					merged = true
+				}
+			}
+			if !merged {
+				s.fields = append(s.fields, &selectionField{
+					key:        key,
+					definition: c.fieldDefinition(s.parentType, selection.Name.Value),
+					selections: subSelections,
+				})
+			}
+		case *ast.InlineFragment:
+			if selection.TypeCondition == nil || c.appliesAlways(s.parentType, selection.TypeCondition.Name.Value) {
+				if err := c.collect(s, selection.SelectionSet.Selections); err != nil {
+					return err
+				}
+				continue
+			}
+			typeName := selection.TypeCondition.Name.Value
+			merged := false
+			for _, cond := range s.conditions {
+				if cond.fragment == "" && cond.typeName == typeName {
+					cond.selections = append(cond.selections, selection.SelectionSet.Selections...)
+					merged = true
+				}
+			}
+			if !merged {
+				s.conditions = append(s.conditions, &condition{
+					field:      "On" + goName(typeName),
+					typeName:   typeName,
+					selections: selection.SelectionSet.Selections,
+				})
+			}
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			definition := c.fragments[name]
+			if c.appliesAlways(s.parentType, definition.TypeCondition.Name.Value) {
+				target, err := c.fragmentStruct(name)
+				if err != nil {
+					return err
+				}
+				if !containsStruct(s.embedded, target) {
+					s.embedded = append(s.embedded, target)
+				}
+				continue
+			}
+			duplicate := false
+			for _, cond := range s.conditions {
+				duplicate = duplicate || cond.fragment == name
+			}
+			if !duplicate {
+				s.conditions = append(s.conditions, &condition{
+					field:    goName(name),
+					typeName: definition.TypeCondition.Name.Value,
+					fragment: name,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// fragmentStruct returns the struct of a fragment definition, declaring it
+// the first time.
+func (c *clientGenerator) fragmentStruct(name string) (*selectionStruct, error) {
+	if s, ok := c.fragmentStructs[name]; ok {
+		return s, nil
+	}
+	definition := c.fragments[name]
+	s, err := c.build(goName(name), goName(name), c.schema.Type(definition.TypeCondition.Name.Value), definition.SelectionSet.Selections, true)
+	if err != nil {
+		return nil, err
+	}
+	c.fragmentStructs[name] = s
+	return s, nil
+}
+
+// appliesAlways reports whether a type condition holds for every value of
+// parentType.
+func (c *clientGenerator) appliesAlways(parentType graphql.Type, typeCondition string) bool {
+	if parentType.Name() == typeCondition {
+		return true
+	}
+	// Valid spreads on an object are on the object or on its abstract types.
+	_, ok := parentType.(*graphql.Object)
+	return ok
+}
+
+// possibleTypes returns the names of the object types a type condition holds
+// for, sorted.
+func (c *clientGenerator) possibleTypes(typeName string) []string {
+	ttype := c.schema.Type(typeName)
+	if !graphql.IsAbstractType(ttype) {
+		return []string{typeName}
+	}
+	names := []string{}
+	for _, object := range c.schema.PossibleTypes(ttype.(graphql.Abstract)) {
+		names = append(names, object.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// needsDecoding reports whether the data of s must be decoded further than
+// encoding/json does, because it has conditions or fragments whose fields may
+// be shadowed by its own.
+func (s *selectionStruct) needsDecoding() bool {
+	return len(s.conditions) > 0 || len(s.embedded) > 0
+}
+
+// selectionStruct writes a struct and its decoding methods.
+func (c *clientGenerator) selectionStruct(s *selectionStruct) {
+	if s.fragment {
+		c.comment("", fmt.Sprintf("%v is the data of the fragment on %q.", s.name, s.parentType.Name()), "", "")
+	} else {
+		c.comment("", fmt.Sprintf("%v is the data selected on %q.", s.name, s.parentType.Name()), "", "")
+	}
+	fmt.Fprintf(&c.buf, "type %v struct {\n", s.name)
+	if s.typename {
+		fmt.Fprintf(&c.buf, "\tTypename string `json:\"__typename\"`\n")
+	}
+	for _, embedded := range s.embedded {
+		fmt.Fprintf(&c.buf, "\t%v\n", embedded.name)
+	}
+	for _, field := range s.fields {
+		c.comment("\t", "", field.definition.Description, field.definition.DeprecationReason)
+		fmt.Fprintf(&c.buf, "\t%v %v `json:%q`\n", goName(field.key), field.goType, field.key)
+	}
+	for _, cond := range s.conditions {
+		fmt.Fprintf(&c.buf, "\t// %v is set when the data is a %v.\n", cond.field, strings.Join(c.possibleTypes(cond.typeName), " or "))
+		fmt.Fprintf(&c.buf, "\t%v *%v `json:\"-\"`\n", cond.field, cond.target.name)
+	}
+	fmt.Fprintf(&c.buf, "}\n\n")
+
+	if !s.needsDecoding() {
+		return
+	}
+	if !s.fragment {
+		typename := "v.Typename"
+		if !s.typename {
+			typename = fmt.Sprintf("%q", s.parentType.Name())
+		}
+		fmt.Fprintf(&c.buf, "// UnmarshalJSON decodes the data of %v, of its fragments and of the\n// conditions matching its __typename.\n", s.name)
+		fmt.Fprintf(&c.buf, "func (v *%v) UnmarshalJSON(data []byte) error {\n", s.name)
+		fmt.Fprintf(&c.buf, "\ttype plain %v\n", s.name)
+		fmt.Fprintf(&c.buf, "\tif err := json.Unmarshal(data, (*plain)(v)); err != nil {\n\t\treturn err\n\t}\n")
+		fmt.Fprintf(&c.buf, "\treturn v.decodeSelections(data, %v)\n}\n\n", typename)
+	}
+	fmt.Fprintf(&c.buf, "func (v *%v) decodeSelections(data []byte, typename string) error {\n", s.name)
+	for _, embedded := range s.embedded {
+		c.decode(fmt.Sprintf("&v.%v", embedded.name), embedded)
+	}
+	for _, cond := range s.conditions {
+		quoted := []string{}
+		for _, name := range c.possibleTypes(cond.typeName) {
+			quoted = append(quoted, fmt.Sprintf("%q", name))
+		}
+		fmt.Fprintf(&c.buf, "\tswitch typename {\n\tcase %v:\n", strings.Join(quoted, ", "))
+		fmt.Fprintf(&c.buf, "\t\tv.%v = &%v{}\n", cond.field, cond.target.name)
+		c.decode("v."+cond.field, cond.target)
+		fmt.Fprintf(&c.buf, "\t}\n")
+	}
+	fmt.Fprintf(&c.buf, "\treturn nil\n}\n\n")
+}
+
+// decode writes the decoding of the data into the struct pointed to by target.
+func (c *clientGenerator) decode(target string, s *selectionStruct) {
+	fmt.Fprintf(&c.buf, "\tif err := json.Unmarshal(data, %v); err != nil {\n\t\treturn err\n\t}\n", target)
+	if s.fragment && s.needsDecoding() {
+		fmt.Fprintf(&c.buf, "\tif err := (%v).decodeSelections(data, typename); err != nil {\n\t\treturn err\n\t}\n", target)
+	}
+}
+
+// clientInputObject writes the struct of an input object, omitting unset
+// nullable fields.
+func (c *clientGenerator) clientInputObject(inputObject *graphql.InputObject) {
+	c.comment("", fmt.Sprintf("%v is the GraphQL input object type %q.", goName(inputObject.Name()), inputObject.Name()), inputObject.Description(), "")
+	fmt.Fprintf(&c.buf, "type %v struct {\n", goName(inputObject.Name()))
+	fields := inputObject.Fields()
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := fields[name]
+		tag := name
+		if _, ok := field.Type.(*graphql.NonNull); !ok {
+			tag += ",omitempty"
+		}
+		c.comment("\t", "", field.Description(), field.DeprecationReason)
+		fmt.Fprintf(&c.buf, "\t%v %v `json:%q`\n", goName(name), c.goType(field.Type), tag)
+	}
+	fmt.Fprintf(&c.buf, "}\n\n")
+}
+
+// outputType returns the Go type of the data of a field, where structName is
+// the struct of its selection set.
+func (c *clientGenerator) outputType(ttype graphql.Type, structName string) string {
+	nonNull, ok := ttype.(*graphql.NonNull)
+	if ok {
+		ttype = nonNull.OfType
+	}
+	pointer := "*"
+	if ok {
+		pointer = ""
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		return "[]" + c.outputType(ttype.OfType, structName)
+	case *graphql.Object, *graphql.Interface, *graphql.Union:
+		return pointer + structName
+	case *graphql.Enum:
+		c.enums[ttype.Name()] = true
+	}
+	if ok {
+		return c.goType(nonNull)
+	}
+	return c.goType(ttype)
+}
+
+// useInputType records the enums and input objects used by an input type.
+func (c *clientGenerator) useInputType(ttype graphql.Type) {
+	switch named := graphql.GetNamed(ttype).(type) {
+	case *graphql.Enum:
+		c.enums[named.Name()] = true
+	case *graphql.InputObject:
+		if c.inputs[named.Name()] {
+			return
+		}
+		c.inputs[named.Name()] = true
+		for _, field := range named.Fields() {
+			c.useInputType(field.Type)
+		}
+	}
+}
+
+// addTypenames adds __typename to the selection sets on abstract types, so
+// that the data of their conditions can be decoded.
+func (c *clientGenerator) addTypenames(selectionSet *ast.SelectionSet, parentType graphql.Type) {
+	if selectionSet == nil {
+		return
+	}
+	hasTypename := false
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name.Value == "__typename" && selection.Alias == nil {
+				hasTypename = true
+				continue
+			}
+			if definition := c.fieldDefinition(parentType, selection.Name.Value); definition != nil {
+				c.addTypenames(selection.SelectionSet, namedType(definition.Type))
+			}
+		case *ast.InlineFragment:
+			fragmentType := parentType
+			if selection.TypeCondition != nil {
+				fragmentType = c.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			c.addTypenames(selection.SelectionSet, fragmentType)
+		}
+	}
+	if graphql.IsAbstractType(parentType) && !hasTypename {
+		typename := ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "__typename"})})
+		selectionSet.Selections = append([]ast.Selection{typename}, selectionSet.Selections...)
+	}
+}
+
+// usedFragments returns the names of the fragments spread in a selection set,
+// directly or through other fragments, in the order they are first spread.
+func (c *clientGenerator) usedFragments(selectionSet *ast.SelectionSet, seen map[string]bool) []string {
+	names := []string{}
+	if selectionSet == nil {
+		return names
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			names = append(names, c.usedFragments(selection.SelectionSet, seen)...)
+		case *ast.InlineFragment:
+			names = append(names, c.usedFragments(selection.SelectionSet, seen)...)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+			names = append(names, c.usedFragments(c.fragments[name].SelectionSet, seen)...)
+		}
+	}
+	return names
+}
+
+// fieldDefinition returns the definition of a field of a composite type,
+// including the __typename meta field.
+func (c *clientGenerator) fieldDefinition(parentType graphql.Type, name string) *graphql.FieldDefinition {
+	if name == graphql.TypeNameMetaFieldDef.Name {
+		return graphql.TypeNameMetaFieldDef
+	}
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		return parentType.Fields()[name]
+	case *graphql.Interface:
+		return parentType.Fields()[name]
+	}
+	return nil
+}
+
+func (c *clientGenerator) typeFromAST(typeAST ast.Type) graphql.Type {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		return graphql.NewList(c.typeFromAST(typeAST.Type))
+	case *ast.NonNull:
+		return graphql.NewNonNull(c.typeFromAST(typeAST.Type))
+	case *ast.Named:
+		return c.schema.Type(typeAST.Name.Value)
+	}
+	return nil
+}
+
+// declare reserves a Go name, failing if it is already declared.
+func (c *clientGenerator) declare(name string) error {
+	if c.names[name] {
+		return fmt.Errorf("Cannot generate %v twice, rename an operation, fragment or alias.", name)
+	}
+	c.names[name] = true
+	return nil
+}
+
+// namedType returns the named type wrapped by a list or non-null type.
+func namedType(ttype graphql.Type) graphql.Type {
+	return graphql.GetNamed(ttype).(graphql.Type)
+}
+
+func containsStruct(structs []*selectionStruct, s *selectionStruct) bool {
+	for _, other := range structs {
+		if other == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := []string{}
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
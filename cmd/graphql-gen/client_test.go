@@ -0,0 +1,136 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/parser"
+)
+
+const clientTestSchema = `
+	enum Episode {
+		NEW_HOPE
+		JEDI
+	}
+
+	interface Character {
+		id: ID!
+		name: String
+		friends: [Character]
+	}
+
+	type Human implements Character {
+		id: ID!
+		name: String
+		friends: [Character]
+		homePlanet: String
+	}
+
+	type Droid implements Character {
+		id: ID!
+		name: String
+		friends: [Character]
+		primaryFunction: String
+	}
+
+	input ReviewInput {
+		stars: Int!
+		commentary: String
+	}
+
+	type Query {
+		hero(episode: Episode): Character
+		human(id: ID!): Human
+	}
+
+	type Mutation {
+		createReview(episode: Episode, review: ReviewInput!): Int
+	}
+`
+
+func generateTestClient(t *testing.T, operations string) (string, error) {
+	schema, err := graphql.BuildSchema(clientTestSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	document, err := parser.Parse(parser.ParseParams{Source: operations})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code, err := generateClient(schema, document, "starwars")
+	return string(code), err
+}
+
+func TestGenerateClient(t *testing.T) {
+	code, err := generateTestClient(t, `
+		query Hero($episode: Episode) {
+			hero(episode: $episode) {
+				...CharacterFields
+				friends { name }
+				... on Droid { primaryFunction }
+				...HumanFields
+			}
+		}
+
+		query Human($id: ID!) {
+			human(id: $id) { ...CharacterFields }
+		}
+
+		mutation CreateReview($episode: Episode, $review: ReviewInput!) {
+			createReview(episode: $episode, review: $review)
+		}
+
+		fragment CharacterFields on Character { id name }
+		fragment HumanFields on Human { homePlanet }
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"package starwars\n",
+		"const HeroDocument = `query Hero($episode: Episode) {\n  hero(episode: $episode) {\n    __typename\n    ...CharacterFields\n",
+		"fragment CharacterFields on Character {\n  __typename\n  id\n  name\n}\n\nfragment HumanFields on Human {\n  homePlanet\n}\n`\n",
+		"type HeroVariables struct {\n\tEpisode *Episode `json:\"episode,omitempty\"`\n}\n",
+		"type CreateReviewVariables struct {\n\tEpisode *Episode    `json:\"episode,omitempty\"`\n\tReview  ReviewInput `json:\"review\"`\n}\n",
+		"func Hero(ctx context.Context, c *client.Client, variables HeroVariables) (*HeroResponse, error) {\n",
+		"\terr := c.Do(ctx, &client.Request{Query: HeroDocument, OperationName: \"Hero\", Variables: variables}, data)\n",
+		"type Episode string\n",
+		"type ReviewInput struct {\n\tCommentary *string `json:\"commentary,omitempty\"`\n\tStars      int     `json:\"stars\"`\n}\n",
+		"type CreateReviewResponse struct {\n\tCreateReview *int `json:\"createReview\"`\n}\n",
+		"type HeroHero struct {\n" +
+			"\tTypename string `json:\"__typename\"`\n" +
+			"\tCharacterFields\n" +
+			"\tFriends []*HeroHeroFriends `json:\"friends\"`\n" +
+			"\t// OnDroid is set when the data is a Droid.\n" +
+			"\tOnDroid *HeroHeroOnDroid `json:\"-\"`\n" +
+			"\t// HumanFields is set when the data is a Human.\n" +
+			"\tHumanFields *HumanFields `json:\"-\"`\n}\n",
+		"\treturn v.decodeSelections(data, v.Typename)\n",
+		"\tswitch typename {\n\tcase \"Droid\":\n\t\tv.OnDroid = &HeroHeroOnDroid{}\n",
+		"type CharacterFields struct {\n\tTypename string  `json:\"__typename\"`\n\tID       string  `json:\"id\"`\n\tName     *string `json:\"name\"`\n}\n",
+		"type HumanHuman struct {\n\tCharacterFields\n}\n",
+		"\treturn v.decodeSelections(data, \"Human\")\n",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("expected generated code to contain:\n%v\ngot:\n%s", expected, code)
+		}
+	}
+	if strings.Contains(code, "func (v *CharacterFields) UnmarshalJSON") {
+		t.Errorf("expected fragments not to implement json.Unmarshaler, got:\n%s", code)
+	}
+}
+
+func TestGenerateClient_RejectsInvalidOperations(t *testing.T) {
+	for operations, expected := range map[string]string{
+		`{ hero { name } }`:              "Operations must be named to generate a client, found an anonymous query.",
+		`query Hero { hero { height } }`: `Invalid operations: Cannot query field "height" on type "Character".`,
+		`query Hero { hero { name } } query H { hero { ...on Human { name } } } fragment F on Human { id }`: `Invalid operations: Fragment "F" is never used.`,
+	} {
+		_, err := generateTestClient(t, operations)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Errorf("expected error %q for %v, got %v", expected, operations, err)
+		}
+	}
+}
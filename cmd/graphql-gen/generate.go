@@ -0,0 +1,423 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GannettDigital/graphql"
+)
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// generator writes the Go code for a schema.
+type generator struct {
+	schema graphql.Schema
+	buf    bytes.Buffer
+	// usesTime is set when generated types refer to time.Time.
+	usesTime bool
+}
+
+// generate returns the formatted Go code for a schema in package pkg.
+func generate(schema graphql.Schema, pkg string) ([]byte, error) {
+	g := &generator{schema: schema}
+	types := g.namedTypes()
+
+	for _, ttype := range types {
+		switch ttype := ttype.(type) {
+		case *graphql.Enum:
+			g.enum(ttype)
+		case *graphql.Interface:
+			g.abstract(ttype.Name(), ttype.Description(), "interface", ttype.Interfaces())
+		case *graphql.Union:
+			g.abstract(ttype.Name(), ttype.Description(), "union", nil)
+		case *graphql.InputObject:
+			g.inputObject(ttype)
+		}
+	}
+	resolvers := []*graphql.Object{}
+	for _, ttype := range types {
+		object, ok := ttype.(*graphql.Object)
+		if !ok {
+			continue
+		}
+		if !g.isRoot(object) {
+			g.object(object)
+		}
+		g.resolverInterface(object)
+		resolvers = append(resolvers, object)
+	}
+	g.newSchema(resolvers, types)
+
+	header := &bytes.Buffer{}
+	fmt.Fprintf(header, "// Code generated by graphql-gen. DO NOT EDIT.\n\npackage %v\n\nimport (\n", pkg)
+	if len(resolvers) > 0 {
+		fmt.Fprintf(header, "\t\"context\"\n\t\"fmt\"\n")
+	}
+	if g.usesTime {
+		fmt.Fprintf(header, "\t\"time\"\n")
+	}
+	fmt.Fprintf(header, "\n\t\"github.com/GannettDigital/graphql\"\n)\n")
+
+	code, err := format.Source(append(header.Bytes(), g.buf.Bytes()...))
+	if err != nil {
+		return nil, fmt.Errorf("Generated code is invalid: %v.", err)
+	}
+	return code, nil
+}
+
+// namedTypes returns the types defined by the schema, sorted by name.
+func (g *generator) namedTypes() []graphql.Type {
+	names := []string{}
+	for name, ttype := range g.schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		if _, ok := ttype.(*graphql.Scalar); ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	types := []graphql.Type{}
+	for _, name := range names {
+		types = append(types, g.schema.Type(name))
+	}
+	return types
+}
+
+func (g *generator) isRoot(object *graphql.Object) bool {
+	return object == g.schema.QueryType() || object == g.schema.MutationType() || object == g.schema.SubscriptionType()
+}
+
+func (g *generator) enum(enum *graphql.Enum) {
+	g.comment("", fmt.Sprintf("%v is the GraphQL enum type %q.", goName(enum.Name()), enum.Name()), enum.Description(), "")
+	fmt.Fprintf(&g.buf, "type %v string\n\nconst (\n", goName(enum.Name()))
+	values := enum.Values()
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+	for _, value := range values {
+		g.comment("\t", "", value.Description, value.DeprecationReason)
+		fmt.Fprintf(&g.buf, "\t%v %v = %q\n", goName(enum.Name())+goName(value.Name), goName(enum.Name()), value.Name)
+	}
+	fmt.Fprintf(&g.buf, ")\n\n")
+}
+
+// abstract writes the marker interface of an interface or union type.
+func (g *generator) abstract(name string, description string, kind string, interfaces []*graphql.Interface) {
+	g.comment("", fmt.Sprintf("%v is the GraphQL %v type %q, implemented by the structs of its possible types.", goName(name), kind, name), description, "")
+	fmt.Fprintf(&g.buf, "type %v interface {\n", goName(name))
+	for _, iface := range interfaces {
+		fmt.Fprintf(&g.buf, "\t%v\n", goName(iface.Name()))
+	}
+	fmt.Fprintf(&g.buf, "\tIs%v()\n}\n\n", goName(name))
+}
+
+func (g *generator) inputObject(inputObject *graphql.InputObject) {
+	g.comment("", fmt.Sprintf("%v is the GraphQL input object type %q.", goName(inputObject.Name()), inputObject.Name()), inputObject.Description(), "")
+	fmt.Fprintf(&g.buf, "type %v struct {\n", goName(inputObject.Name()))
+	fields := inputObject.Fields()
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field := fields[name]
+		g.comment("\t", "", field.Description(), field.DeprecationReason)
+		fmt.Fprintf(&g.buf, "\t%v %v `json:%q`\n", goName(name), g.goType(field.Type), name)
+	}
+	fmt.Fprintf(&g.buf, "}\n\n")
+}
+
+func (g *generator) object(object *graphql.Object) {
+	name := goName(object.Name())
+	g.comment("", fmt.Sprintf("%v is the GraphQL object type %q.", name, object.Name()), object.PrivateDescription, "")
+	fmt.Fprintf(&g.buf, "type %v struct {\n", name)
+	for _, field := range sortedFields(object) {
+		if len(field.Args) > 0 {
+			continue
+		}
+		g.comment("\t", "", field.Description, field.DeprecationReason)
+		fmt.Fprintf(&g.buf, "\t%v %v `json:%q`\n", goName(field.Name), g.goType(field.Type), field.Name)
+	}
+	fmt.Fprintf(&g.buf, "}\n\n")
+
+	for _, abstract := range g.abstractTypes(object) {
+		fmt.Fprintf(&g.buf, "// Is%v marks %v as a possible type of %v.\n", goName(abstract), name, goName(abstract))
+		fmt.Fprintf(&g.buf, "func (*%v) Is%v() {}\n\n", name, goName(abstract))
+	}
+}
+
+// abstractTypes returns the names of the interfaces and unions an object is a
+// possible type of.
+func (g *generator) abstractTypes(object *graphql.Object) []string {
+	names := []string{}
+	for _, ttype := range g.schema.TypeMap() {
+		switch ttype := ttype.(type) {
+		case *graphql.Interface:
+			if g.schema.IsPossibleType(ttype, object) {
+				names = append(names, ttype.Name())
+			}
+		case *graphql.Union:
+			if g.schema.IsPossibleType(ttype, object) {
+				names = append(names, ttype.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolverInterface writes the resolver interface of an object, with a method
+// for each of its fields, and the argument structs of its methods.
+//
+// The fields without arguments of object types other than the root operation
+// types are also written into the struct of the object, so their methods are
+// implemented by a default resolver reading them from the struct, which the
+// resolvers of computed fields embed.
+func (g *generator) resolverInterface(object *graphql.Object) {
+	root := g.isRoot(object)
+	fields := sortedFields(object)
+	name := goName(object.Name())
+	for _, field := range fields {
+		if len(field.Args) == 0 {
+			continue
+		}
+		fmt.Fprintf(&g.buf, "// %v are the arguments of %v.%v.\n", argsName(object, field), object.Name(), field.Name)
+		fmt.Fprintf(&g.buf, "type %v struct {\n", argsName(object, field))
+		args := append([]*graphql.Argument{}, field.Args...)
+		sort.Slice(args, func(i, j int) bool {
+			return args[i].Name() < args[j].Name()
+		})
+		for _, arg := range args {
+			g.comment("\t", "", arg.Description(), arg.DeprecationReason)
+			fmt.Fprintf(&g.buf, "\t%v %v `json:%q`\n", goName(arg.Name()), g.goType(arg.Type), arg.Name())
+		}
+		fmt.Fprintf(&g.buf, "}\n\n")
+	}
+
+	fmt.Fprintf(&g.buf, "// %vResolver resolves the fields of %v.\n", name, object.Name())
+	fmt.Fprintf(&g.buf, "type %vResolver interface {\n", name)
+	for _, field := range fields {
+		g.comment("\t", "", field.Description, field.DeprecationReason)
+		params := []string{"ctx context.Context"}
+		if !root {
+			params = append(params, "obj *"+name)
+		}
+		if len(field.Args) > 0 {
+			params = append(params, "args "+argsName(object, field))
+		}
+		fmt.Fprintf(&g.buf, "\t%v(%v) (%v, error)\n", goName(field.Name), strings.Join(params, ", "), g.goType(field.Type))
+	}
+	fmt.Fprintf(&g.buf, "}\n\n")
+
+	if root {
+		return
+	}
+	fmt.Fprintf(&g.buf, "// Default%vResolver implements the methods of %vResolver for the fields\n", name, name)
+	fmt.Fprintf(&g.buf, "// without arguments, reading them from the struct. Embed it into a\n")
+	fmt.Fprintf(&g.buf, "// %vResolver to implement only the methods of the other fields.\n", name)
+	fmt.Fprintf(&g.buf, "type Default%vResolver struct{}\n\n", name)
+	for _, field := range fields {
+		if len(field.Args) > 0 {
+			continue
+		}
+		fmt.Fprintf(&g.buf, "// %v returns obj.%v.\n", goName(field.Name), goName(field.Name))
+		fmt.Fprintf(&g.buf, "func (Default%vResolver) %v(ctx context.Context, obj *%v) (%v, error) {\n", name, goName(field.Name), name, g.goType(field.Type))
+		fmt.Fprintf(&g.buf, "\treturn obj.%v, nil\n}\n\n", goName(field.Name))
+	}
+}
+
+// newSchema writes the Resolvers struct and the NewSchema function.
+func (g *generator) newSchema(resolvers []*graphql.Object, types []graphql.Type) {
+	fmt.Fprintf(&g.buf, "// Resolvers holds the resolvers of the schema, all of which must be set.\n")
+	fmt.Fprintf(&g.buf, "type Resolvers struct {\n")
+	for _, object := range resolvers {
+		fmt.Fprintf(&g.buf, "\t%v %vResolver\n", goName(object.Name()), goName(object.Name()))
+	}
+	fmt.Fprintf(&g.buf, "}\n\n")
+
+	sdl := strings.Replace(graphql.PrintSchema(g.schema), "`", "` + \"`\" + `", -1)
+	fmt.Fprintf(&g.buf, "// SDL is the schema the code was generated from.\nconst SDL = `%v`\n\n", sdl)
+
+	fmt.Fprintf(&g.buf, "// NewSchema builds the schema with its fields resolved by resolvers.\n")
+	fmt.Fprintf(&g.buf, "func NewSchema(resolvers Resolvers) (graphql.Schema, error) {\n")
+	for _, object := range resolvers {
+		fmt.Fprintf(&g.buf, "\tif resolvers.%v == nil {\n", goName(object.Name()))
+		fmt.Fprintf(&g.buf, "\t\treturn graphql.Schema{}, fmt.Errorf(%q)\n\t}\n", "Resolvers."+goName(object.Name())+" is not set.")
+	}
+	fmt.Fprintf(&g.buf, "\tschema, err := graphql.BuildSchema(SDL)\n\tif err != nil {\n\t\treturn schema, err\n\t}\n")
+	if len(resolvers) > 0 {
+		fmt.Fprintf(&g.buf, "\tvar fields graphql.FieldDefinitionMap\n")
+	}
+	for _, object := range resolvers {
+		root := g.isRoot(object)
+		fmt.Fprintf(&g.buf, "\n\tfields = schema.Type(%q).(*graphql.Object).Fields()\n", object.Name())
+		for _, field := range sortedFields(object) {
+			fmt.Fprintf(&g.buf, "\tfields[%q].Resolve = func(p graphql.ResolveParams) (interface{}, error) {\n", field.Name)
+			call := []string{"p.Context"}
+			if !root {
+				fmt.Fprintf(&g.buf, "\t\tobj, _ := p.Source.(*%v)\n", goName(object.Name()))
+				call = append(call, "obj")
+			}
+			if len(field.Args) > 0 {
+				fmt.Fprintf(&g.buf, "\t\tvar args %v\n", argsName(object, field))
+				fmt.Fprintf(&g.buf, "\t\tif err := p.DecodeArgs(&args); err != nil {\n\t\t\treturn nil, err\n\t\t}\n")
+				call = append(call, "args")
+			}
+			fmt.Fprintf(&g.buf, "\t\treturn resolvers.%v.%v(%v)\n\t}\n",
+				goName(object.Name()), goName(field.Name), strings.Join(call, ", "))
+		}
+	}
+	for _, ttype := range types {
+		var kind string
+		switch ttype.(type) {
+		case *graphql.Interface:
+			kind = "Interface"
+		case *graphql.Union:
+			kind = "Union"
+		default:
+			continue
+		}
+		fmt.Fprintf(&g.buf, "\n\tschema.Type(%q).(*graphql.%v).ResolveType = func(p graphql.ResolveTypeParams) *graphql.Object {\n", ttype.Name(), kind)
+		fmt.Fprintf(&g.buf, "\t\tswitch p.Value.(type) {\n")
+		possibleTypes := append([]*graphql.Object{}, g.schema.PossibleTypes(ttype.(graphql.Abstract))...)
+		sort.Slice(possibleTypes, func(i, j int) bool {
+			return possibleTypes[i].Name() < possibleTypes[j].Name()
+		})
+		for _, object := range possibleTypes {
+			fmt.Fprintf(&g.buf, "\t\tcase *%v:\n\t\t\treturn schema.Type(%q).(*graphql.Object)\n", goName(object.Name()), object.Name())
+		}
+		fmt.Fprintf(&g.buf, "\t\t}\n\t\treturn nil\n\t}\n")
+	}
+	fmt.Fprintf(&g.buf, "\treturn schema, nil\n}\n")
+}
+
+// goType returns the Go type of values of a GraphQL type. Nullable scalars,
+// enums and input objects are pointers, and objects are always pointers.
+func (g *generator) goType(ttype graphql.Type) string {
+	nonNull, ok := ttype.(*graphql.NonNull)
+	if ok {
+		ttype = nonNull.OfType
+	}
+	pointer := "*"
+	if ok {
+		pointer = ""
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		return "[]" + g.goType(ttype.OfType)
+	case *graphql.Object:
+		return "*" + goName(ttype.Name())
+	case *graphql.Interface, *graphql.Union:
+		return goName(ttype.Name())
+	case *graphql.Enum, *graphql.InputObject:
+		return pointer + goName(ttype.Name())
+	case *graphql.Scalar:
+		switch ttype.Name() {
+		case "Int":
+			return pointer + "int"
+		case "Float":
+			return pointer + "float64"
+		case "String", "ID":
+			return pointer + "string"
+		case "Boolean":
+			return pointer + "bool"
+		case "DateTime":
+			g.usesTime = true
+			return pointer + "time.Time"
+		case "Map":
+			return "map[string]interface{}"
+		}
+	}
+	return "interface{}"
+}
+
+// comment writes a doc comment made of a summary, a description and a
+// deprecation, each of which may be empty.
+func (g *generator) comment(indentation string, summary string, description string, deprecationReason string) {
+	paragraphs := []string{}
+	for _, paragraph := range []string{summary, description} {
+		if paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	if deprecationReason != "" {
+		paragraphs = append(paragraphs, "Deprecated: "+deprecationReason)
+	}
+	for i, paragraph := range paragraphs {
+		if i > 0 {
+			fmt.Fprintf(&g.buf, "%v//\n", indentation)
+		}
+		for _, line := range strings.Split(paragraph, "\n") {
+			fmt.Fprintf(&g.buf, "%v// %v\n", indentation, line)
+		}
+	}
+}
+
+func sortedFields(object *graphql.Object) []*graphql.FieldDefinition {
+	fields := []*graphql.FieldDefinition{}
+	for _, field := range object.Fields() {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+func argsName(object *graphql.Object, field *graphql.FieldDefinition) string {
+	return goName(object.Name()) + goName(field.Name) + "Args"
+}
+
+// goName converts a GraphQL name to an exported Go name, e.g. "userId" becomes
+// "UserID" and "NEW_HOPE" becomes "NewHope".
+func goName(name string) string {
+	str := ""
+	for _, word := range words(name) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			str += upper
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		str += string(runes)
+	}
+	return str
+}
+
+// words splits a name at underscores and at the start of capitalised words.
+// Names in upper case, such as enum values, are split at underscores only.
+func words(name string) []string {
+	words := []string{}
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if strings.ToUpper(part) == part {
+			words = append(words, part)
+			continue
+		}
+		runes := []rune(part)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			startsWord := unicode.IsUpper(runes[i]) &&
+				(unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+			if startsWord {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
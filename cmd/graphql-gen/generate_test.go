@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+)
+
+func TestGenerate(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+		scalar DateTime
+
+		enum Episode {
+			NEW_HOPE
+			EMPIRE @deprecated(reason: "Use NEW_HOPE.")
+		}
+
+		interface Character {
+			id: ID!
+		}
+
+		type Human implements Character {
+			id: ID!
+			name: String
+			bornAt: DateTime!
+			height(inFeet: Boolean = false): Float
+			homePlanet: Planet
+		}
+
+		type Planet {
+			name: String!
+		}
+
+		union SearchResult = Human
+
+		input ReviewInput {
+			stars: Int!
+			commentary: String
+		}
+
+		type Query {
+			hero(episode: Episode): Character
+			search(text: String!): [SearchResult!]!
+			reviewCount: Int!
+		}
+
+		type Mutation {
+			createReview(episode: Episode!, review: ReviewInput!): Int
+		}
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code, err := generate(schema, "starwars")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"package starwars\n",
+		"type Episode string\n",
+		"\t// Deprecated: Use NEW_HOPE.\n\tEpisodeEmpire  Episode = \"EMPIRE\"\n",
+		"\tEpisodeNewHope Episode = \"NEW_HOPE\"\n",
+		"type Character interface {\n\tIsCharacter()\n}\n",
+		"type Human struct {\n\tBornAt     time.Time `json:\"bornAt\"`\n\tHomePlanet *Planet   `json:\"homePlanet\"`\n\tID         string    `json:\"id\"`\n\tName       *string   `json:\"name\"`\n}\n",
+		"type Planet struct {\n\tName string `json:\"name\"`\n}\n",
+		"func (*Human) IsCharacter() {}\n",
+		"func (*Human) IsSearchResult() {}\n",
+		"type ReviewInput struct {\n\tCommentary *string `json:\"commentary\"`\n\tStars      int     `json:\"stars\"`\n}\n",
+		"type HumanResolver interface {\n" +
+			"\tBornAt(ctx context.Context, obj *Human) (time.Time, error)\n" +
+			"\tHeight(ctx context.Context, obj *Human, args HumanHeightArgs) (*float64, error)\n" +
+			"\tHomePlanet(ctx context.Context, obj *Human) (*Planet, error)\n" +
+			"\tID(ctx context.Context, obj *Human) (string, error)\n" +
+			"\tName(ctx context.Context, obj *Human) (*string, error)\n}\n",
+		"type PlanetResolver interface {\n\tName(ctx context.Context, obj *Planet) (string, error)\n}\n",
+		"func (DefaultPlanetResolver) Name(ctx context.Context, obj *Planet) (string, error) {\n\treturn obj.Name, nil\n}\n",
+		"type QueryResolver interface {\n" +
+			"\tHero(ctx context.Context, args QueryHeroArgs) (Character, error)\n" +
+			"\tReviewCount(ctx context.Context) (int, error)\n" +
+			"\tSearch(ctx context.Context, args QuerySearchArgs) ([]SearchResult, error)\n}\n",
+		"type MutationCreateReviewArgs struct {\n\tEpisode Episode     `json:\"episode\"`\n\tReview  ReviewInput `json:\"review\"`\n}\n",
+		"type Resolvers struct {\n\tHuman    HumanResolver\n\tMutation MutationResolver\n\tPlanet   PlanetResolver\n\tQuery    QueryResolver\n}\n",
+		"\t\treturn resolvers.Human.Height(p.Context, obj, args)\n",
+		"\t\treturn resolvers.Planet.Name(p.Context, obj)\n",
+		"\t\treturn resolvers.Query.ReviewCount(p.Context)\n",
+		"\tschema.Type(\"SearchResult\").(*graphql.Union).ResolveType = ",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("expected generated code to contain:\n%v\ngot:\n%s", expected, code)
+		}
+	}
+	// Fields with arguments are not read from the structs.
+	if strings.Contains(string(code), "func (DefaultHumanResolver) Height(") {
+		t.Errorf("expected generated code not to implement Height by default, got:\n%s", code)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"id":          "ID",
+		"userId":      "UserID",
+		"homeURLPath": "HomeURLPath",
+		"NEW_HOPE":    "NewHope",
+		"JEDI":        "Jedi",
+		"__typename":  "Typename",
+	} {
+		if got := goName(name); got != expected {
+			t.Errorf("expected %q to become %q, got %q", name, expected, got)
+		}
+	}
+}
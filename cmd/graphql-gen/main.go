@@ -0,0 +1,144 @@
+// Command graphql-gen generates Go code from schemas written in the GraphQL
+// schema definition language.
+//
+//	graphql-gen -package starwars -o schema.go schema.graphql [more.graphql ...]
+//
+// The files are concatenated into one schema, from which it generates:
+//
+//   - a struct for each object and input object type;
+//   - a string type and constants for each enum type;
+//   - a marker interface for each interface and union type, implemented by
+//     the structs of their possible types;
+//   - a resolver interface for each object type, with a method for each
+//     field;
+//   - a default resolver for each object type other than the root operation
+//     types, reading its fields without arguments from its struct;
+//   - a NewSchema function which builds the graphql.Schema with its fields
+//     resolved by implementations of the resolver interfaces.
+//
+// With -operations, it instead generates a client for the operations found in
+// the .graphql files of a directory:
+//
+//	graphql-gen -package starwars -operations queries schema.graphql
+//
+// The schema may then also be the result of an introspection query, in a
+// single .json file. For each operation, it generates a function sending it
+// through a client.Client, a struct for its variables and structs for the
+// data it selects. Fragments become structs embedded into the structs of the
+// selection sets they are spread into. Selection sets on interfaces and unions
+// select __typename, and the parts of them which only apply to some possible
+// types are decoded into pointer fields, set when __typename matches.
+//
+// It exits with status 1 when the schema or operations cannot be read, built
+// or generated.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/language/source"
+)
+
+func main() {
+	pkg := flag.String("package", "schema", "name of the generated package")
+	output := flag.String("o", "", "file to write the generated code to instead of standard output")
+	operations := flag.String("operations", "", "directory of .graphql operations to generate a client for")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] schema.graphql [more.graphql ...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [flags] -operations dir schema.graphql|schema.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	schema, err := readSchema(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var code []byte
+	if *operations == "" {
+		code, err = generate(schema, *pkg)
+	} else {
+		var document *ast.Document
+		if document, err = readOperations(*operations); err == nil {
+			code, err = generateClient(schema, document, *pkg)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		os.Stdout.Write(code)
+		return
+	}
+	if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readSchema builds the schema from SDL files, or from a JSON file holding
+// the result of an introspection query.
+func readSchema(paths []string) (graphql.Schema, error) {
+	if len(paths) == 1 && filepath.Ext(paths[0]) == ".json" {
+		content, err := ioutil.ReadFile(paths[0])
+		if err != nil {
+			return graphql.Schema{}, err
+		}
+		introspection := map[string]interface{}{}
+		if err := json.Unmarshal(content, &introspection); err != nil {
+			return graphql.Schema{}, err
+		}
+		return graphql.BuildClientSchema(introspection)
+	}
+	sdl := []string{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return graphql.Schema{}, err
+		}
+		sdl = append(sdl, string(content))
+	}
+	return graphql.BuildSchema(strings.Join(sdl, "\n"))
+}
+
+// readOperations parses the .graphql files of a directory into one document,
+// so that fragments can be shared between files.
+func readOperations(dir string) (*ast.Document, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("No .graphql files found in %v.", dir)
+	}
+	document := ast.NewDocument(nil)
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(&source.Source{Name: path, Body: content}),
+		})
+		if err != nil {
+			return nil, err
+		}
+		document.Definitions = append(document.Definitions, parsed.Definitions...)
+	}
+	return document, nil
+}
@@ -75,11 +75,43 @@ func astFieldCost(field *ast.Field, fieldDef *FieldDefinition, exeContext *execu
 	if !ok {
 		return cost
 	}
-	cost += selectionSetCost(set, parent, exeContext, path, costDetail)
+	multiplier := costMultiplier(field, fieldDef, exeContext)
+	if multiplier == 1 {
+		return cost + selectionSetCost(set, parent, exeContext, path, costDetail)
+	}
+	// The selections are paid for once per multiplied item, and so are their details.
+	selectionCostDetail := make(map[string]int)
+	cost += multiplier * selectionSetCost(set, parent, exeContext, path, selectionCostDetail)
+	for key, value := range selectionCostDetail {
+		costDetail[key] = multiplier * value
+	}
 
 	return cost
 }
 
+// costMultiplier returns the sum of the values of the cost multipliers given
+// to a field, or 1 when none is given. Negative values count as 0, so that
+// they cannot offset the cost of the other multipliers.
+func costMultiplier(field *ast.Field, fieldDef *FieldDefinition, exeContext *executionContext) int {
+	if len(fieldDef.CostMultipliers) == 0 {
+		return 1
+	}
+	args, _ := getArgumentValues(fieldDef.Args, field.Arguments, exeContext.VariableValues)
+	multiplier, given := 0, false
+	for _, name := range fieldDef.CostMultipliers {
+		if value, ok := args[name].(int); ok {
+			if value > 0 {
+				multiplier += value
+			}
+			given = true
+		}
+	}
+	if !given {
+		return 1
+	}
+	return multiplier
+}
+
 // selectionSetCost will return the cost for a given selection set.
 func selectionSetCost(set *ast.SelectionSet, parent fieldDefiner, exeContext *executionContext, basePath string, costDetail map[string]int) int {
 	if set == nil {
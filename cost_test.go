@@ -641,3 +641,117 @@ func TestQueryComplexity(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryComplexity_CostMultipliers(t *testing.T) {
+	itemType := NewObject(ObjectConfig{
+		Name: "Item",
+		Fields: Fields{
+			"a": &Field{
+				Cost: 1,
+				Type: String,
+			},
+			"b": &Field{
+				Cost: 2,
+				Type: String,
+			},
+		},
+	})
+	schema, err := NewSchema(SchemaConfig{
+		Query: NewObject(ObjectConfig{
+			Name: "Query",
+			Fields: Fields{
+				"items": &Field{
+					Cost: 5,
+					Type: NewList(itemType),
+					Args: FieldConfigArgument{
+						"first": &ArgumentConfig{Type: Int},
+						"last":  &ArgumentConfig{Type: Int},
+					},
+					CostMultipliers: []string{"first", "last"},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	tests := []struct {
+		description string
+		query       string
+		args        map[string]interface{}
+		want        int
+		wantMap     map[string]int
+	}{
+		{
+			description: "Without multiplier arguments",
+			query:       `{ items { a b } }`,
+			want:        8,
+			wantMap: map[string]int{
+				"items":   5,
+				"items.a": 1,
+				"items.b": 2,
+			},
+		},
+		{
+			description: "With a literal multiplier argument",
+			query:       `{ items(first: 10) { a b } }`,
+			want:        35,
+			wantMap: map[string]int{
+				"items":   5,
+				"items.a": 10,
+				"items.b": 20,
+			},
+		},
+		{
+			description: "With multiplier arguments from variables",
+			query:       `query q($first: Int, $last: Int) { items(first: $first, last: $last) { a } }`,
+			args:        map[string]interface{}{"first": 3, "last": 2},
+			want:        10,
+			wantMap: map[string]int{
+				"items":   5,
+				"items.a": 5,
+			},
+		},
+		{
+			description: "With a negative multiplier argument",
+			query:       `{ items(first: -100) { a b } }`,
+			want:        5,
+			wantMap: map[string]int{
+				"items":   5,
+				"items.a": 0,
+				"items.b": 0,
+			},
+		},
+		{
+			description: "With a negative multiplier argument offsetting another",
+			query:       `{ items(first: -100, last: 10) { a b } }`,
+			want:        35,
+			wantMap: map[string]int{
+				"items":   5,
+				"items.a": 10,
+				"items.b": 20,
+			},
+		},
+	}
+	for _, test := range tests {
+		astDoc, err := parser.Parse(parser.ParseParams{Source: test.query})
+		if err != nil {
+			t.Fatalf("Test %q - Parse failed: %v", test.description, err)
+		}
+		got, gotMap, err := QueryComplexity(ExecuteParams{
+			Schema: schema,
+			AST:    astDoc,
+			Args:   test.args,
+		})
+		if err != nil {
+			t.Errorf("Test %q - failed running query complexity: %v", test.description, err)
+		}
+		if got != test.want {
+			t.Errorf("Test %q - got %d, want %d", test.description, got, test.want)
+		}
+		if !reflect.DeepEqual(gotMap, test.wantMap) {
+			t.Errorf("Test %q\nwant: %#v\ngot : %#v", test.description, test.wantMap, gotMap)
+		}
+	}
+}
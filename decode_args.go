@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// DecodeArgs decodes the arguments of the field into the struct pointed to by
+// target.
+//
+// Struct fields are matched by the names TypeRegistry gives them, read from
+// the graphql tag, then the json tag, then the Go field name. Input objects
+// decode into structs or maps, lists into slices and enums into types of
+// their values. Pointers are left nil for null or missing values, and strings
+// decode into types implementing encoding.TextUnmarshaler, such as time.Time.
+func (p ResolveParams) DecodeArgs(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if err := invariantf(
+		value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct,
+		`Arguments must be decoded into a pointer to a struct, got %T.`, target,
+	); err != nil {
+		return err
+	}
+	args := map[string]interface{}{}
+	for name, arg := range p.Args {
+		args[name] = arg
+	}
+	return decodeValue(args, value.Elem(), "")
+}
+
+// decodeValue decodes a coerced input value into target, where path names the
+// value in error messages.
+func decodeValue(value interface{}, target reflect.Value, path string) error {
+	if isNullish(value) {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	t := target.Type()
+	if t.Kind() == reflect.Ptr {
+		elem := reflect.New(t.Elem())
+		if err := decodeValue(value, elem.Elem(), path); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(t) {
+		target.Set(source)
+		return nil
+	}
+	if text, ok := value.(string); ok && reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return decodeError(path, t, value, err.Error())
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return decodeStruct(value, target, path)
+	case reflect.Map:
+		return decodeMap(value, target, path)
+	case reflect.Slice:
+		if source.Kind() != reflect.Slice {
+			// Like input coercion, a single value decodes as a list of one.
+			item := reflect.New(t.Elem()).Elem()
+			if err := decodeValue(value, item, path+"[0]"); err != nil {
+				return err
+			}
+			target.Set(reflect.Append(reflect.MakeSlice(t, 0, 1), item))
+			return nil
+		}
+		items := reflect.MakeSlice(t, source.Len(), source.Len())
+		for i := 0; i < source.Len(); i++ {
+			if err := decodeValue(source.Index(i).Interface(), items.Index(i), fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+		target.Set(items)
+		return nil
+	case reflect.String:
+		if source.Kind() == reflect.String {
+			target.SetString(source.String())
+			return nil
+		}
+	case reflect.Bool:
+		if source.Kind() == reflect.Bool {
+			target.SetBool(source.Bool())
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if number, ok := intValue(source); ok && !target.OverflowInt(number) {
+			target.SetInt(number)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if number, ok := uintValue(source); ok && !target.OverflowUint(number) {
+			target.SetUint(number)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			target.SetFloat(float64(source.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			target.SetFloat(float64(source.Uint()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(source.Float())
+			return nil
+		}
+	}
+	return decodeError(path, t, value, "")
+}
+
+func decodeStruct(value interface{}, target reflect.Value, path string) error {
+	fields, ok := value.(map[string]interface{})
+	if !ok || target.Type() == timeType {
+		return decodeError(path, target.Type(), value, "")
+	}
+	return eachStructField(target.Type(), func(field reflect.StructField, tag structTag) error {
+		fieldValue, ok := fields[tag.name]
+		if !ok {
+			return nil
+		}
+		fieldPath := tag.name
+		if path != "" {
+			fieldPath = path + "." + tag.name
+		}
+		return decodeValue(fieldValue, structField(target, field.Index), fieldPath)
+	})
+}
+
+func decodeMap(value interface{}, target reflect.Value, path string) error {
+	t := target.Type()
+	entries, ok := value.(map[string]interface{})
+	if !ok || t.Key().Kind() != reflect.String {
+		return decodeError(path, t, value, "")
+	}
+	decoded := reflect.MakeMapWithSize(t, len(entries))
+	for key, entry := range entries {
+		item := reflect.New(t.Elem()).Elem()
+		entryPath := key
+		if path != "" {
+			entryPath = path + "." + key
+		}
+		if err := decodeValue(entry, item, entryPath); err != nil {
+			return err
+		}
+		decoded.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), item)
+	}
+	target.Set(decoded)
+	return nil
+}
+
+// structField returns the field at index of a struct, allocating embedded
+// struct pointers along the way.
+func structField(value reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(fieldIndex)
+	}
+	return value
+}
+
+// intValue returns a number as an int64, if it is whole and in range.
+func intValue(source reflect.Value) (int64, bool) {
+	switch source.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return source.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(source.Uint()), source.Uint() <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		number := source.Float()
+		return int64(number), number == math.Trunc(number) && number >= math.MinInt64 && number < math.MaxInt64
+	}
+	return 0, false
+}
+
+// uintValue returns a number as a uint64, if it is whole and in range.
+func uintValue(source reflect.Value) (uint64, bool) {
+	switch source.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(source.Int()), source.Int() >= 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return source.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		number := source.Float()
+		return uint64(number), number == math.Trunc(number) && number >= 0 && number < math.MaxUint64
+	}
+	return 0, false
+}
+
+func decodeError(path string, t reflect.Type, value interface{}, reason string) error {
+	if path == "" {
+		path = "arguments"
+	} else {
+		path = fmt.Sprintf(`argument "%v"`, path)
+	}
+	if reason != "" {
+		reason = ": " + reason
+	}
+	return invariantf(false, `Cannot decode %v of type %T into Go type %v%v.`, path, value, t, reason)
+}
@@ -0,0 +1,141 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GannettDigital/graphql"
+)
+
+type decodeStatus string
+
+type decodeRange struct {
+	From time.Time  `json:"from"`
+	To   *time.Time `json:"to"`
+}
+
+type decodeSearchArgs struct {
+	Term     string            `json:"term"`
+	Limit    *int              `graphql:"limit"`
+	Page     uint8             `graphql:"page"`
+	Ratio    float32           `graphql:"ratio"`
+	Statuses []decodeStatus    `graphql:"statuses"`
+	Within   *decodeRange      `graphql:"within"`
+	Labels   map[string]string `graphql:"labels"`
+	Ignored  string            `graphql:"-"`
+}
+
+func executeDecodeArgsQuery(t *testing.T, query string, variables map[string]interface{}) (*decodeSearchArgs, *graphql.Result) {
+	status := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Status",
+		Values: graphql.EnumValueConfigMap{
+			"DRAFT":     &graphql.EnumValueConfig{Value: "draft"},
+			"PUBLISHED": &graphql.EnumValueConfig{Value: "published"},
+		},
+	})
+	within := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Range",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"from": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+			"to":   &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		},
+	})
+	var decoded *decodeSearchArgs
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"search": &graphql.Field{
+					Type: graphql.Boolean,
+					Args: graphql.FieldConfigArgument{
+						"term":     &graphql.ArgumentConfig{Type: graphql.String},
+						"limit":    &graphql.ArgumentConfig{Type: graphql.Int},
+						"page":     &graphql.ArgumentConfig{Type: graphql.Int},
+						"ratio":    &graphql.ArgumentConfig{Type: graphql.Float},
+						"statuses": &graphql.ArgumentConfig{Type: graphql.NewList(status)},
+						"within":   &graphql.ArgumentConfig{Type: within},
+						"labels":   &graphql.ArgumentConfig{Type: graphql.Map},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						decoded = &decodeSearchArgs{Ignored: "kept"}
+						if err := p.DecodeArgs(decoded); err != nil {
+							return nil, err
+						}
+						return true, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, VariableValues: variables})
+	return decoded, result
+}
+
+func TestDecodeArgs_DecodesIntoStructs(t *testing.T) {
+	decoded, result := executeDecodeArgsQuery(t, `query ($within: Range, $labels: Map) {
+		search(term: "go", page: 2, ratio: 0.5, statuses: [DRAFT, PUBLISHED], within: $within, labels: $labels)
+	}`, map[string]interface{}{
+		"within": map[string]interface{}{"from": "2020-01-02T03:04:05Z"},
+		"labels": map[string]interface{}{"team": "news"},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := &decodeSearchArgs{
+		Term:     "go",
+		Page:     2,
+		Ratio:    0.5,
+		Statuses: []decodeStatus{"draft", "published"},
+		Within:   &decodeRange{From: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Labels:   map[string]string{"team": "news"},
+		Ignored:  "kept",
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, decoded)
+	}
+}
+
+func TestDecodeArgs_DecodesDateTimeLiterals(t *testing.T) {
+	decoded, result := executeDecodeArgsQuery(t, `{
+		search(limit: 10, within: {from: "2020-01-02T03:04:05Z", to: "2020-02-03T04:05:06Z"})
+	}`, nil)
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	to := time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)
+	if decoded.Limit == nil || *decoded.Limit != 10 {
+		t.Fatalf("expected limit 10, got %v", decoded.Limit)
+	}
+	if decoded.Within == nil || !decoded.Within.To.Equal(to) {
+		t.Fatalf("expected range ending at %v, got %+v", to, decoded.Within)
+	}
+}
+
+func TestDecodeArgs_ReportsMismatchedTypes(t *testing.T) {
+	_, result := executeDecodeArgsQuery(t, `{ search(page: 300) }`, nil)
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot decode argument "page" of type int into Go type uint8.` {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	_, result = executeDecodeArgsQuery(t, `query ($labels: Map) { search(labels: $labels) }`, map[string]interface{}{
+		"labels": map[string]interface{}{"team": 1},
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot decode argument "labels.team" of type int into Go type string.` {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	_, result = executeDecodeArgsQuery(t, `{ search(within: {from: "yesterday"}) }`, nil)
+	if len(result.Errors) != 1 || result.Errors[0].Message != "Argument \"within\" has invalid value {from: \"yesterday\"}.\nIn field \"from\": Expected type \"DateTime\", found \"yesterday\"." {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	var target decodeSearchArgs
+	err := graphql.ResolveParams{}.DecodeArgs(target)
+	if err == nil || err.Error() != `Arguments must be decoded into a pointer to a struct, got graphql_test.decodeSearchArgs.` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"sync"
 
 	"github.com/GannettDigital/graphql/language/ast"
@@ -209,13 +210,19 @@ type Scalar struct {
 	err          error
 }
 
-// SerializeFn is a function type for serializing a GraphQLScalar type value
+// SerializeFn is a function type for serializing a GraphQLScalar type value.
+// It may return an error to reject the value, which is reported as the error
+// of the field.
 type SerializeFn func(value interface{}) interface{}
 
-// ParseValueFn is a function type for parsing the value of a GraphQLScalar type
+// ParseValueFn is a function type for parsing the value of a GraphQLScalar type.
+// It may return nil or an error to reject the value, the message of the error
+// being added to the validation error.
 type ParseValueFn func(value interface{}) interface{}
 
-// ParseLiteralFn is a function type for parsing the literal value of a GraphQLScalar type
+// ParseLiteralFn is a function type for parsing the literal value of a GraphQLScalar type.
+// It may return nil or an error to reject the literal, the message of the error
+// being added to the validation error.
 type ParseLiteralFn func(valueAST ast.Value) interface{}
 
 // ScalarConfig options for creating a new GraphQLScalar
@@ -225,6 +232,11 @@ type ScalarConfig struct {
 	Serialize    SerializeFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+	// SpecifiedByURL points to a specification of the scalar's data format,
+	// exposed through the @specifiedBy directive.
+	SpecifiedByURL string
+	// AppliedDirectives are the directives applied to this scalar in the schema.
+	AppliedDirectives []*AppliedDirective
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -301,6 +313,16 @@ func (st *Scalar) Error() error {
 	return st.err
 }
 
+// AppliedDirectives returns the directives applied to the scalar.
+func (st *Scalar) AppliedDirectives() []*AppliedDirective {
+	return st.scalarConfig.AppliedDirectives
+}
+
+// SpecifiedByURL returns the URL of the scalar's specification, if any.
+func (st *Scalar) SpecifiedByURL() string {
+	return st.scalarConfig.SpecifiedByURL
+}
+
 // Object Type Definition
 //
 // Almost all of the GraphQL types you define will be object  Object types
@@ -340,6 +362,9 @@ type Object struct {
 	PrivateName        string `json:"name"`
 	PrivateDescription string `json:"description"`
 	IsTypeOf           IsTypeOfFn
+	// ResolveReference resolves the entity referred to by a representation,
+	// for the "_entities" field of subgraph schemas.
+	ResolveReference ResolveReferenceFn
 
 	typeConfig            ObjectConfig
 	initialisedFields     bool
@@ -375,6 +400,12 @@ type ObjectConfig struct {
 	Fields      interface{} `json:"fields"`
 	IsTypeOf    IsTypeOfFn  `json:"isTypeOf"`
 	Description string      `json:"description"`
+	// AppliedDirectives are the directives applied to this object in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
+	// ResolveReference resolves the entity referred to by a representation,
+	// for the "_entities" field of subgraph schemas. Without it, the
+	// representation itself is the entity.
+	ResolveReference ResolveReferenceFn `json:"-"`
 }
 
 type FieldsThunk func() Fields
@@ -396,6 +427,7 @@ func NewObject(config ObjectConfig) *Object {
 	objectType.PrivateName = config.Name
 	objectType.PrivateDescription = config.Description
 	objectType.IsTypeOf = config.IsTypeOf
+	objectType.ResolveReference = config.ResolveReference
 	objectType.typeConfig = config
 
 	return objectType
@@ -404,9 +436,19 @@ func (gt *Object) AddFieldConfig(fieldName string, fieldConfig *Field) {
 	if fieldName == "" || fieldConfig == nil {
 		return
 	}
-	switch gt.typeConfig.Fields.(type) {
+	switch fields := gt.typeConfig.Fields.(type) {
 	case Fields:
-		gt.typeConfig.Fields.(Fields)[fieldName] = fieldConfig
+		fields[fieldName] = fieldConfig
+		gt.initialisedFields = false
+	case FieldsThunk:
+		gt.typeConfig.Fields = FieldsThunk(func() Fields {
+			configured := fields()
+			if configured == nil {
+				configured = Fields{}
+			}
+			configured[fieldName] = fieldConfig
+			return configured
+		})
 		gt.initialisedFields = false
 	}
 }
@@ -432,7 +474,11 @@ func (gt *Object) Fields() FieldDefinitionMap {
 		configureFields = gt.typeConfig.Fields.(FieldsThunk)()
 	}
 
-	gt.fields, gt.err = defineFieldMap(gt, configureFields)
+	// Keep an error found while defining the interfaces.
+	var err error
+	if gt.fields, err = defineFieldMap(gt, configureFields); err != nil {
+		gt.err = err
+	}
 	gt.initialisedFields = true
 	return gt.fields
 }
@@ -442,20 +488,10 @@ func (gt *Object) Interfaces() []*Interface {
 		return gt.interfaces
 	}
 
-	var configInterfaces []*Interface
-	switch gt.typeConfig.Interfaces.(type) {
-	case InterfacesThunk:
-		configInterfaces = gt.typeConfig.Interfaces.(InterfacesThunk)()
-	case []*Interface:
-		configInterfaces = gt.typeConfig.Interfaces.([]*Interface)
-	case nil:
-	default:
-		gt.err = fmt.Errorf("Unknown Object.Interfaces type: %T", gt.typeConfig.Interfaces)
-		gt.initialisedInterfaces = true
-		return nil
+	var err error
+	if gt.interfaces, err = defineInterfaces(gt, gt.typeConfig.Interfaces); err != nil {
+		gt.err = err
 	}
-
-	gt.interfaces, gt.err = defineInterfaces(gt, configInterfaces)
 	gt.initialisedInterfaces = true
 	return gt.interfaces
 }
@@ -464,9 +500,30 @@ func (gt *Object) Error() error {
 	return gt.err
 }
 
-func defineInterfaces(ttype *Object, interfaces []*Interface) ([]*Interface, error) {
+// AppliedDirectives returns the directives applied to the object.
+func (gt *Object) AppliedDirectives() []*AppliedDirective {
+	return gt.typeConfig.AppliedDirectives
+}
+
+// defineInterfaces returns the interfaces implemented by an Object or an
+// Interface, given as either a []*Interface or an InterfacesThunk.
+func defineInterfaces(ttype Type, configInterfaces interface{}) ([]*Interface, error) {
 	ifaces := []*Interface{}
 
+	var interfaces []*Interface
+	switch configInterfaces := configInterfaces.(type) {
+	case InterfacesThunk:
+		interfaces = configInterfaces()
+	case []*Interface:
+		interfaces = configInterfaces
+	case nil:
+	default:
+		if _, ok := ttype.(*Interface); ok {
+			return nil, fmt.Errorf("Unknown Interface.Interfaces type: %T", configInterfaces)
+		}
+		return nil, fmt.Errorf("Unknown Object.Interfaces type: %T", configInterfaces)
+	}
+
 	if len(interfaces) == 0 {
 		return ifaces, nil
 	}
@@ -507,61 +564,95 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 		return resultFieldMap, err
 	}
 
-	for fieldName, field := range fieldMap {
+	// Report every invalid field and argument, in a stable order.
+	fieldNames := make([]string, 0, len(fieldMap))
+	for fieldName := range fieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	errs := SchemaErrors{}
+	for _, fieldName := range fieldNames {
+		field := fieldMap[fieldName]
 		if field == nil {
 			continue
 		}
-		err = invariantf(
+		coordinate := fmt.Sprintf("%v.%v", ttype, fieldName)
+		if err = assertValidName(fieldName); err != nil {
+			errs.add(coordinate, err)
+			continue
+		}
+		if err = invariantf(
 			field.Type != nil,
 			`%v.%v field type must be Output Type but got: %v.`, ttype, fieldName, field.Type,
-		)
-		if err != nil {
-			return resultFieldMap, err
+		); err != nil {
+			errs.add(coordinate, err)
+			continue
 		}
 		if field.Type.Error() != nil {
-			return resultFieldMap, field.Type.Error()
-		}
-		if err = assertValidName(fieldName); err != nil {
-			return resultFieldMap, err
+			errs.add(coordinate, field.Type.Error())
+			continue
 		}
 		fieldDef := &FieldDefinition{
 			Name:              fieldName,
 			Cost:              field.Cost,
+			CostMultipliers:   field.CostMultipliers,
 			Description:       field.Description,
 			Type:              field.Type,
 			Resolve:           field.Resolve,
 			ResolveSerial:     field.ResolveSerial,
+			Authorize:         field.Authorize,
 			DeprecationReason: field.DeprecationReason,
+			AppliedDirectives: field.AppliedDirectives,
 		}
 
+		argNames := make([]string, 0, len(field.Args))
+		for argName := range field.Args {
+			argNames = append(argNames, argName)
+		}
+		sort.Strings(argNames)
+
 		fieldDef.Args = []*Argument{}
-		for argName, arg := range field.Args {
+		validArgs := true
+		for _, argName := range argNames {
+			arg := field.Args[argName]
+			argCoordinate := fmt.Sprintf("%v(%v:)", coordinate, argName)
 			if err = assertValidName(argName); err != nil {
-				return resultFieldMap, err
+				errs.add(argCoordinate, err)
+				validArgs = false
+				continue
 			}
 			if err = invariantf(
 				arg != nil,
 				`%v.%v args must be an object with argument names as keys.`, ttype, fieldName,
 			); err != nil {
-				return resultFieldMap, err
+				errs.add(argCoordinate, err)
+				validArgs = false
+				continue
 			}
 			if err = invariantf(
 				arg.Type != nil,
 				`%v.%v(%v:) argument type must be Input Type but got: %v.`, ttype, fieldName, argName, arg.Type,
 			); err != nil {
-				return resultFieldMap, err
+				errs.add(argCoordinate, err)
+				validArgs = false
+				continue
 			}
 			fieldArg := &Argument{
 				PrivateName:        argName,
 				PrivateDescription: arg.Description,
 				Type:               arg.Type,
 				DefaultValue:       arg.DefaultValue,
+				DeprecationReason:  arg.DeprecationReason,
+				AppliedDirectives:  arg.AppliedDirectives,
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
-		resultFieldMap[fieldName] = fieldDef
+		if validArgs {
+			resultFieldMap[fieldName] = fieldDef
+		}
 	}
-	return resultFieldMap, nil
+	return resultFieldMap, errs.err()
 }
 
 // ResolveParams Params for FieldResolveFn()
@@ -593,6 +684,22 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+	// FieldDefinition is the definition of the field being resolved.
+	FieldDefinition *FieldDefinition
+	// OriginalNames maps the names renamed by MergeSchemas to the names they
+	// have in the schema the field comes from: the names of types, and the
+	// coordinates of root fields, such as "Query.version_1", to their field
+	// names. It is nil for fields of schemas which were not renamed.
+	OriginalNames map[string]string
+}
+
+// AppliedDirective returns the directive with the given name applied to the
+// field being resolved in the schema, or nil.
+func (info ResolveInfo) AppliedDirective(name string) *AppliedDirective {
+	if info.FieldDefinition == nil {
+		return nil
+	}
+	return findAppliedDirective(info.FieldDefinition.AppliedDirectives, name)
 }
 
 type Fields map[string]*Field
@@ -606,26 +713,41 @@ type Field struct {
 	ResolveSerial     bool                `json:"-"` // If true this field will always be resolved serially
 	DeprecationReason string              `json:"deprecationReason"`
 	Description       string              `json:"description"`
+	// AppliedDirectives are the directives applied to this field in the schema,
+	// available to resolvers through ResolveInfo.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
+	// CostMultipliers are the arguments whose values multiply the cost of the
+	// selections of the field, such as the "first" argument of a list field.
+	CostMultipliers []string `json:"costMultipliers"`
+	// Authorize runs before the resolver of the field, and denies its
+	// resolution when it returns an error.
+	Authorize AuthorizeFn `json:"-"`
 }
 
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
+	// AppliedDirectives are the directives applied to this argument in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
 type FieldDefinition struct {
-	Name              string         `json:"name"`
-	Cost              int            `json:"cost"`
-	Description       string         `json:"description"`
-	Type              Output         `json:"type"`
-	Args              []*Argument    `json:"args"`
-	ResolveSerial     bool           `json:"-"` // If true this field will always be resolved serially
-	Resolve           FieldResolveFn `json:"-"`
-	DeprecationReason string         `json:"deprecationReason"`
+	Name              string              `json:"name"`
+	Cost              int                 `json:"cost"`
+	CostMultipliers   []string            `json:"costMultipliers"`
+	Description       string              `json:"description"`
+	Type              Output              `json:"type"`
+	Args              []*Argument         `json:"args"`
+	ResolveSerial     bool                `json:"-"` // If true this field will always be resolved serially
+	Resolve           FieldResolveFn      `json:"-"`
+	Authorize         AuthorizeFn         `json:"-"`
+	DeprecationReason string              `json:"deprecationReason"`
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 type FieldArgument struct {
@@ -636,10 +758,12 @@ type FieldArgument struct {
 }
 
 type Argument struct {
-	PrivateName        string      `json:"name"`
-	Type               Input       `json:"type"`
-	DefaultValue       interface{} `json:"defaultValue"`
-	PrivateDescription string      `json:"description"`
+	PrivateName        string              `json:"name"`
+	Type               Input               `json:"type"`
+	DefaultValue       interface{}         `json:"defaultValue"`
+	PrivateDescription string              `json:"description"`
+	DeprecationReason  string              `json:"deprecationReason"`
+	AppliedDirectives  []*AppliedDirective `json:"appliedDirectives"`
 }
 
 func (st *Argument) Name() string {
@@ -678,16 +802,24 @@ type Interface struct {
 	PrivateDescription string `json:"description"`
 	ResolveType        ResolveTypeFn
 
-	typeConfig        InterfaceConfig
-	initialisedFields bool
-	fields            FieldDefinitionMap
-	err               error
+	typeConfig            InterfaceConfig
+	initialisedFields     bool
+	fields                FieldDefinitionMap
+	initialisedInterfaces bool
+	interfaces            []*Interface
+	err                   error
 }
 type InterfaceConfig struct {
-	Name        string      `json:"name"`
+	Name string `json:"name"`
+	// Interfaces lists the interfaces this interface implements, as a
+	// []*Interface or an InterfacesThunk. Like objects, interfaces must list
+	// every interface implemented by the interfaces they implement.
+	Interfaces  interface{} `json:"interfaces"`
 	Fields      interface{} `json:"fields"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+	// AppliedDirectives are the directives applied to this interface in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -756,11 +888,28 @@ func (it *Interface) Fields() (fields FieldDefinitionMap) {
 		configureFields = it.typeConfig.Fields.(FieldsThunk)()
 	}
 
-	it.fields, it.err = defineFieldMap(it, configureFields)
+	// Keep an error found while defining the interfaces.
+	var err error
+	if it.fields, err = defineFieldMap(it, configureFields); err != nil {
+		it.err = err
+	}
 	it.initialisedFields = true
 	return it.fields
 }
 
+func (it *Interface) Interfaces() []*Interface {
+	if it.initialisedInterfaces {
+		return it.interfaces
+	}
+
+	var err error
+	if it.interfaces, err = defineInterfaces(it, it.typeConfig.Interfaces); err != nil {
+		it.err = err
+	}
+	it.initialisedInterfaces = true
+	return it.interfaces
+}
+
 func (it *Interface) String() string {
 	return it.PrivateName
 }
@@ -769,6 +918,11 @@ func (it *Interface) Error() error {
 	return it.err
 }
 
+// AppliedDirectives returns the directives applied to the interface.
+func (it *Interface) AppliedDirectives() []*AppliedDirective {
+	return it.typeConfig.AppliedDirectives
+}
+
 // Union Type Definition
 //
 // When a field can return one of a heterogeneous set of types, a Union type
@@ -805,6 +959,8 @@ type UnionConfig struct {
 	Types       []*Object `json:"types"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+	// AppliedDirectives are the directives applied to this union in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 func NewUnion(config UnionConfig) *Union {
@@ -853,6 +1009,11 @@ func NewUnion(config UnionConfig) *Union {
 func (ut *Union) Types() []*Object {
 	return ut.types
 }
+
+// AppliedDirectives returns the directives applied to the union.
+func (ut *Union) AppliedDirectives() []*AppliedDirective {
+	return ut.typeConfig.AppliedDirectives
+}
 func (ut *Union) String() string {
 	return ut.PrivateName
 }
@@ -903,17 +1064,22 @@ type EnumValueConfig struct {
 	Value             interface{} `json:"value"`
 	DeprecationReason string      `json:"deprecationReason"`
 	Description       string      `json:"description"`
+	// AppliedDirectives are the directives applied to this value in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 type EnumConfig struct {
 	Name        string             `json:"name"`
 	Values      EnumValueConfigMap `json:"values"`
 	Description string             `json:"description"`
+	// AppliedDirectives are the directives applied to this enum in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 type EnumValueDefinition struct {
-	Name              string      `json:"name"`
-	Value             interface{} `json:"value"`
-	DeprecationReason string      `json:"deprecationReason"`
-	Description       string      `json:"description"`
+	Name              string              `json:"name"`
+	Value             interface{}         `json:"value"`
+	DeprecationReason string              `json:"deprecationReason"`
+	Description       string              `json:"description"`
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 func NewEnum(config EnumConfig) *Enum {
@@ -959,6 +1125,7 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 			Value:             valueConfig.Value,
 			DeprecationReason: valueConfig.DeprecationReason,
 			Description:       valueConfig.Description,
+			AppliedDirectives: valueConfig.AppliedDirectives,
 		}
 		if value.Value == nil {
 			value.Value = valueName
@@ -967,6 +1134,12 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 	}
 	return values, nil
 }
+
+// AppliedDirectives returns the directives applied to the enum.
+func (gt *Enum) AppliedDirectives() []*AppliedDirective {
+	return gt.enumConfig.AppliedDirectives
+}
+
 func (gt *Enum) Values() []*EnumValueDefinition {
 	return gt.values
 }
@@ -978,6 +1151,12 @@ func (gt *Enum) Serialize(value interface{}) interface{} {
 	if enumValue, ok := gt.getValueLookup()[v]; ok {
 		return enumValue.Name
 	}
+	// Values of named string types, e.g. `type Episode string`, match string values.
+	if value := reflect.ValueOf(v); value.Kind() == reflect.String {
+		if enumValue, ok := gt.getValueLookup()[value.String()]; ok {
+			return enumValue.Name
+		}
+	}
 	return nil
 }
 func (gt *Enum) ParseValue(value interface{}) interface{} {
@@ -1068,15 +1247,20 @@ type InputObject struct {
 	err        error
 }
 type InputObjectFieldConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
+	// AppliedDirectives are the directives applied to this field in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 type InputObjectField struct {
-	PrivateName        string      `json:"name"`
-	Type               Input       `json:"type"`
-	DefaultValue       interface{} `json:"defaultValue"`
-	PrivateDescription string      `json:"description"`
+	PrivateName        string              `json:"name"`
+	Type               Input               `json:"type"`
+	DefaultValue       interface{}         `json:"defaultValue"`
+	PrivateDescription string              `json:"description"`
+	DeprecationReason  string              `json:"deprecationReason"`
+	AppliedDirectives  []*AppliedDirective `json:"appliedDirectives"`
 }
 
 func (st *InputObjectField) Name() string {
@@ -1099,6 +1283,11 @@ type InputObjectConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	Description string      `json:"description"`
+	// IsOneOf requires exactly one field to be given a non-null value, exposed
+	// through the @oneOf directive.
+	IsOneOf bool `json:"isOneOf"`
+	// AppliedDirectives are the directives applied to this input object in the schema.
+	AppliedDirectives []*AppliedDirective `json:"appliedDirectives"`
 }
 
 func NewInputObject(config InputObjectConfig) *InputObject {
@@ -1152,12 +1341,24 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 		field.Type = fieldConfig.Type
 		field.PrivateDescription = fieldConfig.Description
 		field.DefaultValue = fieldConfig.DefaultValue
+		field.DeprecationReason = fieldConfig.DeprecationReason
+		field.AppliedDirectives = fieldConfig.AppliedDirectives
 		resultFieldMap[fieldName] = field
 	}
 	gt.init = true
 	return resultFieldMap
 }
 
+// AppliedDirectives returns the directives applied to the input object.
+func (gt *InputObject) AppliedDirectives() []*AppliedDirective {
+	return gt.typeConfig.AppliedDirectives
+}
+
+// IsOneOf reports whether exactly one field of the input object must be given.
+func (gt *InputObject) IsOneOf() bool {
+	return gt.typeConfig.IsOneOf
+}
+
 func (gt *InputObject) Fields() InputObjectFieldMap {
 	if !gt.init {
 		gt.fields = gt.defineFieldMap()
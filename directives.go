@@ -32,25 +32,77 @@ var SpecifiedDirectives = []*Directive{
 	IncludeDirective,
 	SkipDirective,
 	DeprecatedDirective,
+	SpecifiedByDirective,
+	OneOfDirective,
 }
 
 // Directive structs are used by the GraphQL runtime as a way of modifying execution
 // behavior. Type system creators will usually not create these directly.
 type Directive struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Locations   []string    `json:"locations"`
-	Args        []*Argument `json:"args"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Locations    []string    `json:"locations"`
+	Args         []*Argument `json:"args"`
+	IsRepeatable bool        `json:"isRepeatable"`
+
+	// Resolve is the execution hook of the directive when it is used on a field.
+	Resolve DirectiveResolveFn `json:"-"`
 
 	err error
 }
 
+// DirectiveResolveParams are the parameters of a directive's execution hook.
+type DirectiveResolveParams struct {
+	// Args are the values of the directive arguments.
+	Args map[string]interface{}
+
+	// Field are the parameters the field is resolved with.
+	Field ResolveParams
+
+	// Next resolves the field, running the hooks of the directives used
+	// before this one on the field.
+	Next FieldResolveFn
+}
+
+// DirectiveResolveFn is the execution hook of a directive used on a field in a
+// query. It runs around the resolution of the field: it may call Next to
+// resolve the field and transform the value it returns, or return a value
+// without resolving the field at all.
+//
+// When several directives with hooks are used on a field, the first one runs
+// closest to the field's resolver, so that each directive transforms the value
+// produced by the directives before it, e.g. `name @truncate(length: 3) @uppercase`.
+type DirectiveResolveFn func(p DirectiveResolveParams) (interface{}, error)
+
+// AppliedDirective is a directive applied to an element of the schema, such as
+// a type or a field, with the values of its arguments. Applied directives carry
+// metadata which resolvers can read at runtime, e.g. `@auth(role: "ADMIN")`.
+type AppliedDirective struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+}
+
+// findAppliedDirective returns the first directive named name, or nil.
+func findAppliedDirective(directives []*AppliedDirective, name string) *AppliedDirective {
+	for _, directive := range directives {
+		if directive != nil && directive.Name == name {
+			return directive
+		}
+	}
+	return nil
+}
+
 // DirectiveConfig options for creating a new GraphQLDirective
 type DirectiveConfig struct {
 	Name        string              `json:"name"`
 	Description string              `json:"description"`
 	Locations   []string            `json:"locations"`
 	Args        FieldConfigArgument `json:"args"`
+	// IsRepeatable allows the directive to be used more than once at a location.
+	IsRepeatable bool `json:"isRepeatable"`
+	// Resolve is the execution hook run around the resolution of the fields the
+	// directive is used on. It requires the FIELD location.
+	Resolve DirectiveResolveFn `json:"-"`
 }
 
 func NewDirective(config DirectiveConfig) *Directive {
@@ -82,6 +134,8 @@ func NewDirective(config DirectiveConfig) *Directive {
 			PrivateDescription: argConfig.Description,
 			Type:               argConfig.Type,
 			DefaultValue:       argConfig.DefaultValue,
+			DeprecationReason:  argConfig.DeprecationReason,
+			AppliedDirectives:  argConfig.AppliedDirectives,
 		})
 	}
 
@@ -89,6 +143,8 @@ func NewDirective(config DirectiveConfig) *Directive {
 	dir.Description = config.Description
 	dir.Locations = config.Locations
 	dir.Args = args
+	dir.IsRepeatable = config.IsRepeatable
+	dir.Resolve = config.Resolve
 	return dir
 }
 
@@ -143,6 +199,33 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 	},
 	Locations: []string{
 		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
 		DirectiveLocationEnumValue,
 	},
 })
+
+// SpecifiedByDirective Used to provide a URL for specifying the behaviour of custom scalar definitions.
+var SpecifiedByDirective = NewDirective(DirectiveConfig{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Args: FieldConfigArgument{
+		"url": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The URL that specifies the behaviour of this scalar.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationScalar,
+	},
+})
+
+// OneOfDirective Used to declare that exactly one field of an input object must be given.
+var OneOfDirective = NewDirective(DirectiveConfig{
+	Name: "oneOf",
+	Description: "Indicates exactly one field must be supplied and this field must not be " +
+		"`null`.",
+	Locations: []string{
+		DirectiveLocationInputObject,
+	},
+})
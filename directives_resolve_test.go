@@ -0,0 +1,219 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+var uppercaseDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "uppercase",
+	Locations: []string{graphql.DirectiveLocationField},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		if s, ok := value.(string); ok {
+			return strings.ToUpper(s), err
+		}
+		return value, err
+	},
+})
+
+var truncateDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "truncate",
+	Locations: []string{graphql.DirectiveLocationField},
+	Args: graphql.FieldConfigArgument{
+		"length": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		length, _ := p.Args["length"].(int)
+		if s, ok := value.(string); ok && len(s) > length {
+			return s[:length], err
+		}
+		return value, err
+	},
+})
+
+var formatDateDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "formatDate",
+	Locations: []string{graphql.DirectiveLocationField},
+	Args: graphql.FieldConfigArgument{
+		"format": &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "2006-01-02"},
+	},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		format, _ := p.Args["format"].(string)
+		if t, ok := value.(time.Time); ok {
+			return t.Format(format), err
+		}
+		return value, err
+	},
+})
+
+var excerptDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "excerpt",
+	Locations: []string{graphql.DirectiveLocationField},
+	Args: graphql.FieldConfigArgument{
+		"length": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:    "ExcerptLength",
+				IsOneOf: true,
+				Fields: graphql.InputObjectConfigFieldMap{
+					"characters": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+					"words":      &graphql.InputObjectFieldConfig{Type: graphql.Int},
+				},
+			})),
+		},
+	},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		length, _ := p.Args["length"].(map[string]interface{})
+		if s, ok := value.(string); ok {
+			if words, ok := length["words"].(int); ok && len(strings.Fields(s)) > words {
+				return strings.Join(strings.Fields(s)[:words], " "), err
+			}
+			if characters, ok := length["characters"].(int); ok && len(s) > characters {
+				return s[:characters], err
+			}
+		}
+		return value, err
+	},
+})
+
+var directiveResolveTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "Luke Skywalker", nil
+				},
+			},
+			"born": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return time.Date(1977, time.May, 25, 0, 0, 0, 0, time.UTC), nil
+				},
+			},
+		},
+	}),
+	Directives: append([]*graphql.Directive{uppercaseDirective, truncateDirective, formatDateDirective, excerptDirective},
+		graphql.SpecifiedDirectives...),
+})
+
+func executeDirectiveResolveTestQuery(doc string, variables map[string]interface{}) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         directiveResolveTestSchema,
+		RequestString:  doc,
+		VariableValues: variables,
+	})
+}
+
+func TestDirectivesResolve_TransformsResolvedValue(t *testing.T) {
+	result := executeDirectiveResolveTestQuery(`{
+		upper: name @uppercase
+		short: name @truncate(length: 4)
+		born @formatDate(format: "Jan 2, 2006")
+		isoBorn: born @formatDate
+		plain: name
+	}`, nil)
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"upper":   "LUKE SKYWALKER",
+			"short":   "Luke",
+			"born":    "May 25, 1977",
+			"isoBorn": "1977-05-25",
+			"plain":   "Luke Skywalker",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesResolve_AppliesHooksInOrder(t *testing.T) {
+	result := executeDirectiveResolveTestQuery(`query ($length: Int!) {
+		truncatedFirst: name @truncate(length: $length) @uppercase
+		uppercasedFirst: name @uppercase @truncate(length: 2)
+		skipped: name @uppercase @skip(if: true)
+	}`, map[string]interface{}{"length": 3})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"truncatedFirst":  "LUK",
+			"uppercasedFirst": "LU",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesResolve_AppliesHooksOfMergedFields(t *testing.T) {
+	result := executeDirectiveResolveTestQuery(`{
+		name @uppercase
+		... on Query { name @truncate(length: 4) @uppercase }
+		first: name @excerpt(length: {words: 1})
+	}`, nil)
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"name":  "LUKE",
+			"first": "Luke",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesResolve_InvalidArgumentsFailTheField(t *testing.T) {
+	// Without validation, the variable can leave the OneOf argument empty.
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: directiveResolveTestSchema,
+		AST: testutil.TestParse(t, `query ($characters: Int) {
+			name @excerpt(length: {characters: $characters})
+		}`),
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{"name": nil},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Argument \"length\" has invalid value {characters: $characters}.\nExactly one key must be specified for OneOf type \"ExcerptLength\".",
+				Locations: []location.SourceLocation{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesResolve_RequiresFieldLocation(t *testing.T) {
+	hooked := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "hooked",
+		Locations: []string{graphql.DirectiveLocationFragmentSpread},
+		Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+			return p.Next(p.Field)
+		},
+	})
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+		}),
+		Directives: []*graphql.Directive{hooked},
+	})
+	expected := graphql.SchemaErrors{{
+		Coordinate: "@hooked",
+		Message:    `Directive "@hooked" has an execution hook and must include the FIELD location.`,
+	}}
+	if !reflect.DeepEqual(expected, err) {
+		t.Fatalf("Expected error: %v, got %v", expected, err)
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"testing"
 
 	"github.com/GannettDigital/graphql"
-	"github.com/GannettDigital/graphql/gqlerrors"
-	"github.com/GannettDigital/graphql/language/location"
 	"github.com/GannettDigital/graphql/testutil"
 )
 
@@ -56,10 +54,9 @@ func TestDirectives_DirectivesMustBeNamed(t *testing.T) {
 		}),
 		Directives: []*graphql.Directive{invalidDirective},
 	})
-	expectedErr := gqlerrors.FormattedError{
-		Message:   "Directive must be named.",
-		Locations: []location.SourceLocation{},
-	}
+	expectedErr := graphql.SchemaErrors{{
+		Message: "Directive must be named.",
+	}}
 	if !reflect.DeepEqual(expectedErr, err) {
 		t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
 	}
@@ -83,10 +80,9 @@ func TestDirectives_DirectiveNameMustBeValid(t *testing.T) {
 		}),
 		Directives: []*graphql.Directive{invalidDirective},
 	})
-	expectedErr := gqlerrors.FormattedError{
-		Message:   `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "123invalid name" does not.`,
-		Locations: []location.SourceLocation{},
-	}
+	expectedErr := graphql.SchemaErrors{{
+		Message: `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "123invalid name" does not.`,
+	}}
 	if !reflect.DeepEqual(expectedErr, err) {
 		t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
 	}
@@ -107,10 +103,9 @@ func TestDirectives_DirectiveNameMustProvideLocations(t *testing.T) {
 		}),
 		Directives: []*graphql.Directive{invalidDirective},
 	})
-	expectedErr := gqlerrors.FormattedError{
-		Message:   `Must provide locations for directive.`,
-		Locations: []location.SourceLocation{},
-	}
+	expectedErr := graphql.SchemaErrors{{
+		Message: `Must provide locations for directive.`,
+	}}
 	if !reflect.DeepEqual(expectedErr, err) {
 		t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
 	}
@@ -144,10 +139,9 @@ func TestDirectives_DirectiveArgNamesMustBeValid(t *testing.T) {
 		}),
 		Directives: []*graphql.Directive{invalidDirective},
 	})
-	expectedErr := gqlerrors.FormattedError{
-		Message:   `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "123if" does not.`,
-		Locations: []location.SourceLocation{},
-	}
+	expectedErr := graphql.SchemaErrors{{
+		Message: `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "123if" does not.`,
+	}}
 	if !reflect.DeepEqual(expectedErr, err) {
 		t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
 	}
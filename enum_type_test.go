@@ -421,3 +421,19 @@ func TestTypeSystem_EnumValues_EnumValueMayBePointer(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestTypeSystem_EnumValues_SerializesNamedStringTypes(t *testing.T) {
+	type episode string
+	enum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Episode",
+		Values: graphql.EnumValueConfigMap{
+			"NEW_HOPE": &graphql.EnumValueConfig{Value: "NEW_HOPE"},
+		},
+	})
+	if got := enum.Serialize(episode("NEW_HOPE")); got != "NEW_HOPE" {
+		t.Fatalf("expected NEW_HOPE, got %v", got)
+	}
+	if got := enum.Serialize(episode("JEDI")); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+}
@@ -17,7 +17,7 @@ import (
 /* Shared data variables to allow dynamic reloads
 /*****************************************************************************/
 
-var schema graphql.Schema
+var schema *graphql.SchemaHolder
 
 const jsonDataFile = "data.json"
 
@@ -25,10 +25,13 @@ func handleSIGUSR1(c chan os.Signal) {
 	for {
 		<-c
 		fmt.Printf("Caught SIGUSR1. Reloading %s\n", jsonDataFile)
-		err := importJSONDataFromFile(jsonDataFile)
+		newSchema, err := importJSONDataFromFile(jsonDataFile)
+		if err == nil {
+			// In-flight requests finish on the previous schema.
+			err = schema.Swap(newSchema)
+		}
 		if err != nil {
 			fmt.Printf("Error: %s\n", err.Error())
-			return
 		}
 	}
 }
@@ -47,9 +50,8 @@ func filterUser(data []map[string]interface{}, args map[string]interface{}) map[
 	return nil
 }
 
-func executeQuery(query string, schema graphql.Schema) *graphql.Result {
-	result := graphql.Do(graphql.Params{
-		Schema:        schema,
+func executeQuery(query string, schema *graphql.SchemaHolder) *graphql.Result {
+	result := schema.Do(graphql.Params{
 		RequestString: query,
 	})
 	if len(result.Errors) > 0 {
@@ -58,17 +60,17 @@ func executeQuery(query string, schema graphql.Schema) *graphql.Result {
 	return result
 }
 
-func importJSONDataFromFile(fileName string) error {
+func importJSONDataFromFile(fileName string) (graphql.Schema, error) {
 	content, err := ioutil.ReadFile(fileName)
 	if err != nil {
-		return err
+		return graphql.Schema{}, err
 	}
 
 	var data []map[string]interface{}
 
 	err = json.Unmarshal(content, &data)
 	if err != nil {
-		return err
+		return graphql.Schema{}, err
 	}
 
 	fields := make(graphql.Fields)
@@ -105,26 +107,25 @@ func importJSONDataFromFile(fileName string) error {
 			},
 		})
 
-	schema, _ = graphql.NewSchema(
+	return graphql.NewSchema(
 		graphql.SchemaConfig{
 			Query: queryType,
 		},
 	)
-
-	return nil
 }
 
 func main() {
-	// Catch SIGUSR1 and reload the data file
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, syscall.SIGUSR1)
-	go handleSIGUSR1(c)
-
-	err := importJSONDataFromFile(jsonDataFile)
+	initialSchema, err := importJSONDataFromFile(jsonDataFile)
 	if err != nil {
 		fmt.Printf("Error: %s\n", err.Error())
 		return
 	}
+	schema = graphql.NewSchemaHolder(initialSchema, graphql.SchemaHolderConfig{DocumentCacheSize: 100})
+
+	// Catch SIGUSR1 and reload the data file
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGUSR1)
+	go handleSIGUSR1(c)
 
 	http.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
 		result := executeQuery(r.URL.Query().Get("query"), schema)
@@ -23,6 +23,9 @@ type ExecuteParams struct {
 	// information to resolve functions.
 	Context context.Context
 
+	// Authorize authorizes the fields which have no Authorize function.
+	Authorize AuthorizeFn
+
 	manager *resolveManager
 }
 
@@ -50,6 +53,7 @@ func Execute(p ExecuteParams) (result *Result) {
 			Errors:        nil,
 			Result:        result,
 			Context:       p.Context,
+			Authorize:     p.Authorize,
 			manager:       p.manager,
 		})
 
@@ -108,6 +112,7 @@ type buildExecutionCtxParams struct {
 	Errors        []gqlerrors.FormattedError
 	Result        *Result
 	Context       context.Context
+	Authorize     AuthorizeFn
 	manager       *resolveManager
 }
 
@@ -118,6 +123,7 @@ type executionContext struct {
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
 	Context        context.Context
+	Authorize      AuthorizeFn
 
 	errors      []gqlerrors.FormattedError
 	errorsMutex sync.Mutex
@@ -180,6 +186,7 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 	eCtx.VariableValues = variableValues
 	eCtx.addError(p.Errors...)
 	eCtx.Context = p.Context
+	eCtx.Authorize = p.Authorize
 	eCtx.manager = p.manager
 	return eCtx, nil
 }
@@ -288,7 +295,7 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 
 		result, err := resolveSerially(fn, params)
 		if err != nil {
-			p.ExecutionContext.addError(gqlerrors.FormatError(err))
+			p.ExecutionContext.addError(resolverErrors(err)...)
 		}
 
 		finalResults[responseName] = completeValueCatchingError(p.ExecutionContext, params.Info.ReturnType, params.Info.FieldASTs, params.Info, result)
@@ -373,7 +380,7 @@ func executeFields(p executeFieldsParams) *Result {
 	}
 	for responseName, resp := range completed {
 		if resp.err != nil {
-			p.ExecutionContext.addError(gqlerrors.FormatError(resp.err))
+			p.ExecutionContext.addError(resolverErrors(resp.err)...)
 			if resp.result == nil {
 				finalResults[responseName] = nil
 				continue
@@ -389,6 +396,15 @@ func executeFields(p executeFieldsParams) *Result {
 	}
 }
 
+// resolverErrors returns the errors returned by a resolver, which may return
+// several at once as gqlerrors.FormattedErrors.
+func resolverErrors(err error) []gqlerrors.FormattedError {
+	if errs, ok := err.(gqlerrors.FormattedErrors); ok {
+		return errs
+	}
+	return []gqlerrors.FormattedError{gqlerrors.FormatError(err)}
+}
+
 type collectFieldsParams struct {
 	ExeContext           *executionContext
 	RuntimeType          *Object // previously known as OperationType
@@ -614,22 +630,34 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	if resolveFn == nil {
 		resolveFn = DefaultResolveFn
 	}
+	resolveFn = withDirectiveResolvers(eCtx, fieldASTs, resolveFn)
+	authorize := fieldDef.Authorize
+	if authorize == nil {
+		authorize = eCtx.Authorize
+	}
+	resolveFn = withAuthorization(authorize, resolveFn)
 
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args, err := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	if err != nil {
+		resolveFn = func(p ResolveParams) (interface{}, error) {
+			return nil, err
+		}
+	}
 
 	info := ResolveInfo{
-		FieldName:      fieldName,
-		FieldASTs:      fieldASTs,
-		ReturnType:     returnType,
-		ParentType:     parentType,
-		Schema:         eCtx.Schema,
-		Fragments:      eCtx.Fragments,
-		RootValue:      eCtx.Root,
-		Operation:      eCtx.Operation,
-		VariableValues: eCtx.VariableValues,
+		FieldName:       fieldName,
+		FieldASTs:       fieldASTs,
+		ReturnType:      returnType,
+		ParentType:      parentType,
+		Schema:          eCtx.Schema,
+		Fragments:       eCtx.Fragments,
+		RootValue:       eCtx.Root,
+		Operation:       eCtx.Operation,
+		VariableValues:  eCtx.VariableValues,
+		FieldDefinition: fieldDef,
 	}
 
 	return resolveFn, ResolveParams{
@@ -640,6 +668,47 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	}
 }
 
+// withDirectiveResolvers wraps the resolver of a field with the execution hooks
+// of the directives used on the field, the first directive being the innermost.
+// The directives of every selection of the field are used, a directive which is
+// not repeatable only once.
+func withDirectiveResolvers(eCtx *executionContext, fieldASTs []*ast.Field, resolveFn FieldResolveFn) FieldResolveFn {
+	used := map[string]bool{}
+	for _, fieldAST := range fieldASTs {
+		for _, directiveAST := range fieldAST.Directives {
+			resolveFn = withDirectiveResolver(eCtx, directiveAST, used, resolveFn)
+		}
+	}
+	return resolveFn
+}
+
+// withDirectiveResolver wraps resolveFn with the execution hook of a directive,
+// if it has one. A directive whose arguments are invalid fails the field.
+func withDirectiveResolver(eCtx *executionContext, directiveAST *ast.Directive, used map[string]bool, resolveFn FieldResolveFn) FieldResolveFn {
+	if directiveAST == nil || directiveAST.Name == nil {
+		return resolveFn
+	}
+	directive := eCtx.Schema.Directive(directiveAST.Name.Value)
+	if directive == nil || directive.Resolve == nil || (used[directive.Name] && !directive.IsRepeatable) {
+		return resolveFn
+	}
+	used[directive.Name] = true
+	args, err := getArgumentValues(directive.Args, directiveAST.Arguments, eCtx.VariableValues)
+	if err != nil {
+		return func(p ResolveParams) (interface{}, error) {
+			return nil, err
+		}
+	}
+	hook, next := directive.Resolve, resolveFn
+	return func(p ResolveParams) (interface{}, error) {
+		return hook(DirectiveResolveParams{
+			Args:  args,
+			Field: p,
+			Next:  next,
+		})
+	}
+}
+
 // TODO do I need returnType, fieldASTs here? It seems they are always matching the same named values in the ResolveInfo
 func completeValueCatchingError(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) (completed interface{}) {
 	// catch panic
@@ -694,10 +763,10 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
 	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
+		return completeLeafValue(returnType, fieldASTs, result)
 	}
 	if returnType, ok := returnType.(*Enum); ok {
-		return completeLeafValue(returnType, result)
+		return completeLeafValue(returnType, fieldASTs, result)
 	}
 
 	// If field type is an abstract type, Interface or Union, determine the
@@ -728,6 +797,15 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 // of that value, then completing based on that type.
 func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) interface{} {
 
+	// Entities resolved by the "_entities" field of subgraphs know their type,
+	// or the error resolving them.
+	if entity, ok := result.(*entityResult); ok {
+		if entity.err != nil {
+			panic(gqlerrors.FormatError(NewLocatedError(entity.err, FieldASTsToNodeASTs(fieldASTs))))
+		}
+		return completeObjectValue(eCtx, entity.object, fieldASTs, info, entity.value)
+	}
+
 	var runtimeType *Object
 
 	resolveTypeParams := ResolveTypeParams{
@@ -813,8 +891,12 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
-func completeLeafValue(returnType Leaf, result interface{}) interface{} {
+// A serialization error is raised as the error of the field.
+func completeLeafValue(returnType Leaf, fieldASTs []*ast.Field, result interface{}) interface{} {
 	serializedResult := returnType.Serialize(result)
+	if err, ok := serializedResult.(error); ok {
+		panic(gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))))
+	}
 	if isNullish(serializedResult) {
 		return nil
 	}
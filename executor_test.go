@@ -1797,3 +1797,53 @@ func TestContextDeadline(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
 	}
 }
+
+func TestExecutesNilPointersAsNull(t *testing.T) {
+	type node struct {
+		Name *string `json:"name"`
+		Next *node   `json:"next"`
+	}
+	var nodeType *graphql.Object
+	nodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Node",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+				"next": &graphql.Field{Type: nodeType},
+			}
+		}),
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"first": &graphql.Field{
+					Type: nodeType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return &node{}, nil
+					},
+				},
+				"missing": &graphql.Field{
+					Type: nodeType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return (*node)(nil), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ first { name next { name } } missing { name } }`})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"first":   map[string]interface{}{"name": nil, "next": nil},
+		"missing": nil,
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
@@ -0,0 +1,336 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/GannettDigital/graphql/language/ast"
+)
+
+// ResolveReferenceParams are the parameters of a reference resolver.
+type ResolveReferenceParams struct {
+	// Representation is the representation of the entity sent by the gateway:
+	// its "__typename" and the fields of one of its keys, along with the
+	// fields required by the fields being resolved.
+	Representation map[string]interface{}
+
+	// Info is the information about the "_entities" field being resolved.
+	Info ResolveInfo
+
+	// Context argument is a context value that is provided to every resolve function within an execution.
+	Context context.Context
+}
+
+// ResolveReferenceFn resolves the entity referred to by a representation. A
+// nil entity is returned as null.
+type ResolveReferenceFn func(p ResolveReferenceParams) (interface{}, error)
+
+// FieldSetScalar is the type of the field sets used by the federation
+// directives, such as "id" or "id organization { id }".
+var FieldSetScalar = NewScalar(ScalarConfig{
+	Name: "_FieldSet",
+	Serialize: func(value interface{}) interface{} {
+		return coerceString(value)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return coerceString(value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return valueAST.Value
+		}
+		return nil
+	},
+})
+
+// AnyScalar is the type of entity representations, which are objects of any
+// shape.
+var AnyScalar = NewScalar(ScalarConfig{
+	Name: "_Any",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: anyValueFromAST,
+})
+
+// ServiceType describes the subgraph to the gateway.
+var ServiceType = NewObject(ObjectConfig{
+	Name: "_Service",
+	Fields: Fields{
+		"sdl": &Field{
+			Type:        String,
+			Description: "The schema of the subgraph, with the federation directives applied to it.",
+		},
+	},
+})
+
+// KeyDirective Used to declare an entity, which other subgraphs can refer to
+// and extend, and the fields by which it is referred to.
+var KeyDirective = NewDirective(DirectiveConfig{
+	Name:         "key",
+	Description:  "Declares an entity and the fields by which it is referred to.",
+	IsRepeatable: true,
+	Args: FieldConfigArgument{
+		"fields": &ArgumentConfig{Type: NewNonNull(FieldSetScalar)},
+	},
+	Locations: []string{
+		DirectiveLocationObject,
+		DirectiveLocationInterface,
+	},
+})
+
+// ExternalDirective Used to mark a field of an entity extension as defined by
+// another subgraph.
+var ExternalDirective = NewDirective(DirectiveConfig{
+	Name:        "external",
+	Description: "Marks a field as defined by another subgraph.",
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// RequiresDirective Used to declare the external fields a field needs to be
+// resolved, which the gateway adds to the representations it sends.
+var RequiresDirective = NewDirective(DirectiveConfig{
+	Name:        "requires",
+	Description: "Declares the external fields needed to resolve a field.",
+	Args: FieldConfigArgument{
+		"fields": &ArgumentConfig{Type: NewNonNull(FieldSetScalar)},
+	},
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// ProvidesDirective Used to declare the fields of an entity another subgraph
+// owns that a field resolves along with it.
+var ProvidesDirective = NewDirective(DirectiveConfig{
+	Name:        "provides",
+	Description: "Declares the external fields of the returned entity which this field resolves.",
+	Args: FieldConfigArgument{
+		"fields": &ArgumentConfig{Type: NewNonNull(FieldSetScalar)},
+	},
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+	},
+})
+
+// ExtendsDirective Used to mark a type as an extension of a type owned by
+// another subgraph.
+var ExtendsDirective = NewDirective(DirectiveConfig{
+	Name:        "extends",
+	Description: "Marks a type as an extension of a type defined by another subgraph.",
+	Locations: []string{
+		DirectiveLocationObject,
+		DirectiveLocationInterface,
+	},
+})
+
+// FederationDirectives are the directives a subgraph schema may apply.
+var FederationDirectives = []*Directive{
+	KeyDirective,
+	ExternalDirective,
+	RequiresDirective,
+	ProvidesDirective,
+	ExtendsDirective,
+}
+
+// entityResult is an entity resolved from a representation, or the error
+// resolving it, completed as its object type by completeAbstractValue.
+type entityResult struct {
+	object *Object
+	value  interface{}
+	err    error
+}
+
+// NewSubgraphSchema creates a schema which can be served as a subgraph of an
+// Apollo Federation gateway.
+//
+// Objects declare themselves entities by applying the @key directive, and
+// resolve the representations of their entities with ResolveReference. On top
+// of config, the schema has the federation directives, and its query type has:
+//
+//   - a "_service" field, whose "sdl" is the schema printed without the
+//     additions made for federation;
+//   - an "_entities(representations: [_Any!]!): [_Entity]!" field, where the
+//     "_Entity" union is made of the entities, when there are any.
+//
+// The fields are added to a copy of the query type, so that config.Query is
+// left unchanged.
+func NewSubgraphSchema(config SchemaConfig) (Schema, error) {
+	if config.Query == nil {
+		return Schema{}, invariant(false, "Schema query must be Object Type but got: nil.")
+	}
+	config.Query = copyObject(config.Query)
+	if len(config.Directives) == 0 {
+		config.Directives = SpecifiedDirectives
+	}
+	directives := append([]*Directive{}, config.Directives...)
+	for _, directive := range FederationDirectives {
+		if !containsDirective(directives, directive) {
+			directives = append(directives, directive)
+		}
+	}
+	config.Directives = directives
+	config.Types = append(append([]Type{}, config.Types...), FieldSetScalar, AnyScalar, ServiceType)
+
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	sdl := PrintFilteredSchema(schema, func(directive *Directive) bool {
+		return !containsDirective(FederationDirectives, directive)
+	}, func(ttype Type) bool {
+		return ttype != FieldSetScalar && ttype != AnyScalar && ttype != ServiceType
+	})
+
+	config.Query.AddFieldConfig("_service", &Field{
+		Type: NewNonNull(ServiceType),
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			return map[string]interface{}{"sdl": sdl}, nil
+		},
+	})
+
+	entities := map[string]*Object{}
+	entityTypes := []*Object{}
+	typeMap := schema.TypeMap()
+	for _, name := range sortedTypeNames(typeMap) {
+		if object, ok := typeMap[name].(*Object); ok && findAppliedDirective(object.AppliedDirectives(), KeyDirective.Name) != nil {
+			entities[name] = object
+			entityTypes = append(entityTypes, object)
+		}
+	}
+	if len(entityTypes) > 0 {
+		entityType := NewUnion(UnionConfig{
+			Name:  "_Entity",
+			Types: entityTypes,
+			ResolveType: func(p ResolveTypeParams) *Object {
+				if representation, ok := p.Value.(map[string]interface{}); ok {
+					typename, _ := representation["__typename"].(string)
+					return entities[typename]
+				}
+				return nil
+			},
+		})
+		config.Types = append(config.Types, entityType)
+		config.Query.AddFieldConfig("_entities", &Field{
+			Type: NewNonNull(NewList(entityType)),
+			Args: FieldConfigArgument{
+				"representations": &ArgumentConfig{
+					Type: NewNonNull(NewList(NewNonNull(AnyScalar))),
+				},
+			},
+			Resolve: resolveEntities(entities),
+		})
+	}
+	return NewSchema(config)
+}
+
+// copyObject returns an object with the config of object, whose fields can be
+// added without adding them to object.
+func copyObject(object *Object) *Object {
+	config := object.typeConfig
+	configured := config.Fields
+	config.Fields = FieldsThunk(func() Fields {
+		fields := Fields{}
+		var original Fields
+		switch configured := configured.(type) {
+		case Fields:
+			original = configured
+		case FieldsThunk:
+			original = configured()
+		}
+		for name, field := range original {
+			fields[name] = field
+		}
+		return fields
+	})
+	return NewObject(config)
+}
+
+// resolveEntities resolves the representations of entities with the reference
+// resolvers of their types.
+func resolveEntities(entities map[string]*Object) FieldResolveFn {
+	return func(p ResolveParams) (interface{}, error) {
+		representations, _ := p.Args["representations"].([]interface{})
+		results := make([]interface{}, len(representations))
+		for i, value := range representations {
+			representation, ok := value.(map[string]interface{})
+			if !ok {
+				results[i] = &entityResult{err: fmt.Errorf(`Representation must be an object with a "__typename", got %v.`, value)}
+				continue
+			}
+			typename, _ := representation["__typename"].(string)
+			object, ok := entities[typename]
+			if !ok {
+				results[i] = &entityResult{err: fmt.Errorf(`Type "%v" is not an entity type.`, typename)}
+				continue
+			}
+			if object.ResolveReference == nil {
+				results[i] = &entityResult{object: object, value: representation}
+				continue
+			}
+			entity, err := object.ResolveReference(ResolveReferenceParams{
+				Representation: representation,
+				Info:           p.Info,
+				Context:        p.Context,
+			})
+			if err != nil {
+				results[i] = &entityResult{err: err}
+			} else if !isNullish(entity) {
+				results[i] = &entityResult{object: object, value: entity}
+			}
+		}
+		return results, nil
+	}
+}
+
+// anyValueFromAST returns the Go value of a literal of any shape: objects
+// become maps, lists slices and enum values strings.
+func anyValueFromAST(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		fields := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			fields[field.Name.Value] = anyValueFromAST(field.Value)
+		}
+		return fields
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, value := range valueAST.Values {
+			values = append(values, anyValueFromAST(value))
+		}
+		return values
+	case *ast.IntValue:
+		if value, err := strconv.Atoi(valueAST.Value); err == nil {
+			return value
+		}
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.FloatValue:
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	}
+	return nil
+}
+
+func containsDirective(directives []*Directive, directive *Directive) bool {
+	for _, other := range directives {
+		if other == directive || (other != nil && other.Name == directive.Name) {
+			return true
+		}
+	}
+	return false
+}
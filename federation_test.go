@@ -0,0 +1,182 @@
+package graphql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+type federationProduct struct {
+	UPC   string `json:"upc"`
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
+
+var federationProducts = map[string]*federationProduct{
+	"1": {UPC: "1", Name: "Table", Price: 899},
+	"2": {UPC: "2", Name: "Couch", Price: 1299},
+}
+
+var federationUserObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID), AppliedDirectives: []*graphql.AppliedDirective{{Name: "external"}}},
+		"username": &graphql.Field{Type: graphql.String, AppliedDirectives: []*graphql.AppliedDirective{{Name: "external"}}},
+		"greeting": &graphql.Field{
+			Type: graphql.String,
+			AppliedDirectives: []*graphql.AppliedDirective{
+				{Name: "requires", Args: map[string]interface{}{"fields": "username"}},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return "Hello, " + p.Source.(map[string]interface{})["username"].(string), nil
+			},
+		},
+	},
+	AppliedDirectives: []*graphql.AppliedDirective{
+		{Name: "extends"},
+		{Name: "key", Args: map[string]interface{}{"fields": "id"}},
+	},
+})
+
+var federationProductObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Product",
+	Fields: graphql.FieldsThunk(func() graphql.Fields {
+		return graphql.Fields{
+			"upc":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name":  &graphql.Field{Type: graphql.String},
+			"price": &graphql.Field{Type: graphql.Int},
+		}
+	}),
+	AppliedDirectives: []*graphql.AppliedDirective{
+		{Name: "key", Args: map[string]interface{}{"fields": "upc"}},
+	},
+	ResolveReference: func(p graphql.ResolveReferenceParams) (interface{}, error) {
+		upc, _ := p.Representation["upc"].(string)
+		if upc == "0" {
+			return nil, errors.New("Product 0 is discontinued.")
+		}
+		return federationProducts[upc], nil
+	},
+})
+
+var federationTestSchema, _ = graphql.NewSubgraphSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"topProducts": &graphql.Field{
+					Type: graphql.NewList(federationProductObject),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []*federationProduct{federationProducts["1"], federationProducts["2"]}, nil
+					},
+				},
+			}
+		}),
+	}),
+	Types: []graphql.Type{federationUserObject},
+})
+
+func TestSubgraphSchema_PrintsServiceSDL(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        federationTestSchema,
+		RequestString: `{ _service { sdl } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := `type Product @key(fields: "upc") {
+  name: String
+  price: Int
+  upc: String!
+}
+
+type Query {
+  topProducts: [Product]
+}
+
+type User @extends @key(fields: "id") {
+  greeting: String @requires(fields: "username")
+  id: ID! @external
+  username: String @external
+}
+`
+	sdl := result.Data.(map[string]interface{})["_service"].(map[string]interface{})["sdl"]
+	if sdl != expected {
+		t.Fatalf("unexpected sdl:\n%v\nexpected:\n%v", sdl, expected)
+	}
+}
+
+func TestSubgraphSchema_ResolvesEntities(t *testing.T) {
+	query := `query ($representations: [_Any!]!) {
+		_entities(representations: $representations) {
+			__typename
+			... on Product { upc name price }
+			... on User { id greeting }
+		}
+	}`
+	result := graphql.Do(graphql.Params{
+		Schema:        federationTestSchema,
+		RequestString: query,
+		VariableValues: map[string]interface{}{
+			"representations": []interface{}{
+				map[string]interface{}{"__typename": "Product", "upc": "2"},
+				map[string]interface{}{"__typename": "User", "id": "7", "username": "ada"},
+				map[string]interface{}{"__typename": "Product", "upc": "0"},
+				map[string]interface{}{"__typename": "Product", "upc": "3"},
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"_entities": []interface{}{
+				map[string]interface{}{"__typename": "Product", "upc": "2", "name": "Couch", "price": 1299},
+				map[string]interface{}{"__typename": "User", "id": "7", "greeting": "Hello, ada"},
+				nil,
+				nil,
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Product 0 is discontinued.",
+				Locations: []location.SourceLocation{{Line: 2, Column: 3}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        federationTestSchema,
+		RequestString: `{ _entities(representations: [{__typename: "Query"}]) { __typename } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Type "Query" is not an entity type.` {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestSubgraphSchema_LeavesQueryTypeUnchanged(t *testing.T) {
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{Type: graphql.String},
+		},
+	})
+	for i := 0; i < 2; i++ {
+		schema, err := graphql.NewSubgraphSchema(graphql.SchemaConfig{Query: query})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := schema.QueryType().Fields()["_service"]; !ok {
+			t.Fatalf("expected the subgraph query type to have _service")
+		}
+	}
+	if _, ok := query.Fields()["_service"]; ok {
+		t.Fatalf("expected the query type not to be given _service")
+	}
+}
@@ -0,0 +1,451 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/GannettDigital/graphql/language/printer"
+)
+
+// Kinds of BreakingChange.
+const (
+	BreakingChangeTypeRemoved                 = "TYPE_REMOVED"
+	BreakingChangeTypeChangedKind             = "TYPE_CHANGED_KIND"
+	BreakingChangeTypeRemovedFromUnion        = "TYPE_REMOVED_FROM_UNION"
+	BreakingChangeValueRemovedFromEnum        = "VALUE_REMOVED_FROM_ENUM"
+	BreakingChangeRequiredInputFieldAdded     = "REQUIRED_INPUT_FIELD_ADDED"
+	BreakingChangeImplementedInterfaceRemoved = "IMPLEMENTED_INTERFACE_REMOVED"
+	BreakingChangeFieldRemoved                = "FIELD_REMOVED"
+	BreakingChangeFieldChangedKind            = "FIELD_CHANGED_KIND"
+	BreakingChangeRequiredArgAdded            = "REQUIRED_ARG_ADDED"
+	BreakingChangeArgRemoved                  = "ARG_REMOVED"
+	BreakingChangeArgChangedKind              = "ARG_CHANGED_KIND"
+	BreakingChangeDirectiveRemoved            = "DIRECTIVE_REMOVED"
+	BreakingChangeDirectiveArgRemoved         = "DIRECTIVE_ARG_REMOVED"
+	BreakingChangeRequiredDirectiveArgAdded   = "REQUIRED_DIRECTIVE_ARG_ADDED"
+	BreakingChangeDirectiveLocationRemoved    = "DIRECTIVE_LOCATION_REMOVED"
+	BreakingChangeDirectiveRepeatableRemoved  = "DIRECTIVE_REPEATABLE_REMOVED"
+)
+
+// Kinds of DangerousChange.
+const (
+	DangerousChangeValueAddedToEnum        = "VALUE_ADDED_TO_ENUM"
+	DangerousChangeInterfaceAddedToObject  = "INTERFACE_ADDED_TO_OBJECT"
+	DangerousChangeTypeAddedToUnion        = "TYPE_ADDED_TO_UNION"
+	DangerousChangeOptionalInputFieldAdded = "OPTIONAL_INPUT_FIELD_ADDED"
+	DangerousChangeOptionalArgAdded        = "OPTIONAL_ARG_ADDED"
+	DangerousChangeArgDefaultValueChange   = "ARG_DEFAULT_VALUE_CHANGE"
+)
+
+// BreakingChange is a change between two schemas which breaks existing clients.
+type BreakingChange struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// DangerousChange is a change between two schemas which does not break existing
+// clients by itself, but may change how they behave.
+type DangerousChange struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// FindBreakingChanges returns the changes from oldSchema to newSchema which
+// break queries that are valid against oldSchema.
+func FindBreakingChanges(oldSchema, newSchema Schema) []BreakingChange {
+	breakingChanges, _ := findSchemaChanges(&oldSchema, &newSchema)
+	return breakingChanges
+}
+
+// FindDangerousChanges returns the changes from oldSchema to newSchema which
+// do not break queries that are valid against oldSchema, but may change their
+// results, such as an added enum value or a changed argument default value.
+func FindDangerousChanges(oldSchema, newSchema Schema) []DangerousChange {
+	_, dangerousChanges := findSchemaChanges(&oldSchema, &newSchema)
+	return dangerousChanges
+}
+
+// schemaChanges collects the changes found while comparing two schemas.
+type schemaChanges struct {
+	breaking  []BreakingChange
+	dangerous []DangerousChange
+}
+
+func (c *schemaChanges) addBreaking(changeType string, format string, a ...interface{}) {
+	c.breaking = append(c.breaking, BreakingChange{Type: changeType, Description: fmt.Sprintf(format, a...)})
+}
+
+func (c *schemaChanges) addDangerous(changeType string, format string, a ...interface{}) {
+	c.dangerous = append(c.dangerous, DangerousChange{Type: changeType, Description: fmt.Sprintf(format, a...)})
+}
+
+func findSchemaChanges(oldSchema, newSchema *Schema) ([]BreakingChange, []DangerousChange) {
+	changes := &schemaChanges{}
+	findTypeChanges(changes, oldSchema.TypeMap(), newSchema.TypeMap())
+	findDirectiveChanges(changes, oldSchema, newSchema)
+	return changes.breaking, changes.dangerous
+}
+
+func findTypeChanges(changes *schemaChanges, oldTypeMap, newTypeMap TypeMap) {
+	for _, name := range sortedTypeNames(oldTypeMap) {
+		oldType := oldTypeMap[name]
+		newType, ok := newTypeMap[name]
+		if !ok {
+			// Specified scalars are only in the type map while referenced, but
+			// they can't be removed from a schema.
+			if isSpecifiedScalar(oldType) {
+				continue
+			}
+			changes.addBreaking(BreakingChangeTypeRemoved, "%v was removed.", name)
+			continue
+		}
+
+		switch oldType := oldType.(type) {
+		case *Enum:
+			if newType, ok := newType.(*Enum); ok {
+				findEnumValueChanges(changes, oldType, newType)
+				continue
+			}
+		case *Union:
+			if newType, ok := newType.(*Union); ok {
+				findUnionTypeChanges(changes, oldType, newType)
+				continue
+			}
+		case *InputObject:
+			if newType, ok := newType.(*InputObject); ok {
+				findInputFieldChanges(changes, oldType, newType)
+				continue
+			}
+		case *Object:
+			if newType, ok := newType.(*Object); ok {
+				findImplementedInterfaceChanges(changes, oldType, newType)
+				findFieldChanges(changes, name, oldType.Fields(), newType.Fields())
+				continue
+			}
+		case *Interface:
+			if newType, ok := newType.(*Interface); ok {
+				findImplementedInterfaceChanges(changes, oldType, newType)
+				findFieldChanges(changes, name, oldType.Fields(), newType.Fields())
+				continue
+			}
+		case *Scalar:
+			if _, ok := newType.(*Scalar); ok {
+				continue
+			}
+		}
+		changes.addBreaking(BreakingChangeTypeChangedKind, "%v changed from %v to %v.",
+			name, typeKindDescription(oldType), typeKindDescription(newType))
+	}
+}
+
+func findEnumValueChanges(changes *schemaChanges, oldType, newType *Enum) {
+	oldValues := sortedEnumValueNames(oldType)
+	newValues := sortedEnumValueNames(newType)
+	for _, name := range newValues {
+		if !containsString(oldValues, name) {
+			changes.addDangerous(DangerousChangeValueAddedToEnum, "%v was added to enum type %v.", name, oldType.Name())
+		}
+	}
+	for _, name := range oldValues {
+		if !containsString(newValues, name) {
+			changes.addBreaking(BreakingChangeValueRemovedFromEnum, "%v was removed from enum type %v.", name, oldType.Name())
+		}
+	}
+}
+
+func findUnionTypeChanges(changes *schemaChanges, oldType, newType *Union) {
+	oldTypes := map[string]bool{}
+	for _, ttype := range oldType.Types() {
+		oldTypes[ttype.Name()] = true
+	}
+	newTypes := map[string]bool{}
+	for _, ttype := range newType.Types() {
+		newTypes[ttype.Name()] = true
+		if !oldTypes[ttype.Name()] {
+			changes.addDangerous(DangerousChangeTypeAddedToUnion, "%v was added to union type %v.", ttype.Name(), oldType.Name())
+		}
+	}
+	for _, ttype := range oldType.Types() {
+		if !newTypes[ttype.Name()] {
+			changes.addBreaking(BreakingChangeTypeRemovedFromUnion, "%v was removed from union type %v.", ttype.Name(), oldType.Name())
+		}
+	}
+}
+
+func findImplementedInterfaceChanges(changes *schemaChanges, oldType, newType implementingType) {
+	oldInterfaces := map[string]bool{}
+	for _, iface := range oldType.Interfaces() {
+		oldInterfaces[iface.Name()] = true
+	}
+	newInterfaces := map[string]bool{}
+	for _, iface := range newType.Interfaces() {
+		newInterfaces[iface.Name()] = true
+		if !oldInterfaces[iface.Name()] {
+			changes.addDangerous(DangerousChangeInterfaceAddedToObject, "%v added to interfaces implemented by %v.", iface.Name(), oldType.Name())
+		}
+	}
+	for _, iface := range oldType.Interfaces() {
+		if !newInterfaces[iface.Name()] {
+			changes.addBreaking(BreakingChangeImplementedInterfaceRemoved, "%v no longer implements interface %v.", oldType.Name(), iface.Name())
+		}
+	}
+}
+
+func findFieldChanges(changes *schemaChanges, typeName string, oldFields, newFields FieldDefinitionMap) {
+	for _, fieldName := range sortedFieldNames(oldFields) {
+		oldField := oldFields[fieldName]
+		newField, ok := newFields[fieldName]
+		if !ok {
+			changes.addBreaking(BreakingChangeFieldRemoved, "%v.%v was removed.", typeName, fieldName)
+			continue
+		}
+		findArgChanges(changes, typeName+"."+fieldName, oldField.Args, newField.Args)
+		if !isChangeSafeForObjectOrInterfaceField(oldField.Type, newField.Type) {
+			changes.addBreaking(BreakingChangeFieldChangedKind, "%v.%v changed type from %v to %v.",
+				typeName, fieldName, oldField.Type, newField.Type)
+		}
+	}
+}
+
+func findArgChanges(changes *schemaChanges, fieldName string, oldArgs, newArgs []*Argument) {
+	newArgsByName := map[string]*Argument{}
+	for _, arg := range newArgs {
+		newArgsByName[arg.Name()] = arg
+	}
+	oldArgsByName := map[string]*Argument{}
+	for _, oldArg := range oldArgs {
+		oldArgsByName[oldArg.Name()] = oldArg
+		newArg, ok := newArgsByName[oldArg.Name()]
+		if !ok {
+			changes.addBreaking(BreakingChangeArgRemoved, "%v arg %v was removed.", fieldName, oldArg.Name())
+			continue
+		}
+		if !isChangeSafeForInputObjectFieldOrFieldArg(oldArg.Type, newArg.Type) {
+			changes.addBreaking(BreakingChangeArgChangedKind, "%v arg %v has changed type from %v to %v.",
+				fieldName, oldArg.Name(), oldArg.Type, newArg.Type)
+			continue
+		}
+		if oldArg.DefaultValue != nil {
+			oldDefault := printDefaultValue(oldArg.DefaultValue, oldArg.Type)
+			newDefault := printDefaultValue(newArg.DefaultValue, newArg.Type)
+			if oldDefault != newDefault {
+				changes.addDangerous(DangerousChangeArgDefaultValueChange, "%v arg %v has changed defaultValue from %v to %v.",
+					fieldName, oldArg.Name(), oldDefault, newDefault)
+			}
+		}
+	}
+	for _, newArg := range newArgs {
+		if _, ok := oldArgsByName[newArg.Name()]; ok {
+			continue
+		}
+		if isRequiredInput(newArg.Type, newArg.DefaultValue) {
+			changes.addBreaking(BreakingChangeRequiredArgAdded, "A required arg %v on %v was added.", newArg.Name(), fieldName)
+		} else {
+			changes.addDangerous(DangerousChangeOptionalArgAdded, "An optional arg %v on %v was added.", newArg.Name(), fieldName)
+		}
+	}
+}
+
+func findInputFieldChanges(changes *schemaChanges, oldType, newType *InputObject) {
+	oldFields := oldType.Fields()
+	newFields := newType.Fields()
+	for _, fieldName := range sortedInputFieldNames(oldFields) {
+		oldField := oldFields[fieldName]
+		newField, ok := newFields[fieldName]
+		if !ok {
+			changes.addBreaking(BreakingChangeFieldRemoved, "%v.%v was removed.", oldType.Name(), fieldName)
+			continue
+		}
+		if !isChangeSafeForInputObjectFieldOrFieldArg(oldField.Type, newField.Type) {
+			changes.addBreaking(BreakingChangeFieldChangedKind, "%v.%v changed type from %v to %v.",
+				oldType.Name(), fieldName, oldField.Type, newField.Type)
+		}
+	}
+	for _, fieldName := range sortedInputFieldNames(newFields) {
+		if _, ok := oldFields[fieldName]; ok {
+			continue
+		}
+		newField := newFields[fieldName]
+		if isRequiredInput(newField.Type, newField.DefaultValue) {
+			changes.addBreaking(BreakingChangeRequiredInputFieldAdded, "A required field %v on input type %v was added.", fieldName, oldType.Name())
+		} else {
+			changes.addDangerous(DangerousChangeOptionalInputFieldAdded, "An optional field %v on input type %v was added.", fieldName, oldType.Name())
+		}
+	}
+}
+
+func findDirectiveChanges(changes *schemaChanges, oldSchema, newSchema *Schema) {
+	for _, oldDirective := range oldSchema.Directives() {
+		newDirective := newSchema.Directive(oldDirective.Name)
+		if newDirective == nil {
+			changes.addBreaking(BreakingChangeDirectiveRemoved, "%v was removed.", oldDirective.Name)
+			continue
+		}
+		if oldDirective.IsRepeatable && !newDirective.IsRepeatable {
+			changes.addBreaking(BreakingChangeDirectiveRepeatableRemoved, "Repeatable flag was removed from %v.", oldDirective.Name)
+		}
+
+		oldArgs := map[string]bool{}
+		for _, arg := range oldDirective.Args {
+			oldArgs[arg.Name()] = true
+		}
+		newArgs := map[string]bool{}
+		for _, arg := range newDirective.Args {
+			newArgs[arg.Name()] = true
+			if !oldArgs[arg.Name()] && isRequiredInput(arg.Type, arg.DefaultValue) {
+				changes.addBreaking(BreakingChangeRequiredDirectiveArgAdded, "A required arg %v on directive %v was added.", arg.Name(), oldDirective.Name)
+			}
+		}
+		for _, arg := range oldDirective.Args {
+			if !newArgs[arg.Name()] {
+				changes.addBreaking(BreakingChangeDirectiveArgRemoved, "%v was removed from %v.", arg.Name(), oldDirective.Name)
+			}
+		}
+
+		newLocations := map[string]bool{}
+		for _, location := range newDirective.Locations {
+			newLocations[location] = true
+		}
+		for _, location := range oldDirective.Locations {
+			if !newLocations[location] {
+				changes.addBreaking(BreakingChangeDirectiveLocationRemoved, "%v was removed from %v.", location, oldDirective.Name)
+			}
+		}
+	}
+}
+
+// isChangeSafeForObjectOrInterfaceField reports whether clients selecting a field of
+// oldType can still read a field of newType. Output types may become stricter.
+func isChangeSafeForObjectOrInterfaceField(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case *List:
+		switch newType := newType.(type) {
+		case *List:
+			return isChangeSafeForObjectOrInterfaceField(oldType.OfType, newType.OfType)
+		case *NonNull:
+			return isChangeSafeForObjectOrInterfaceField(oldType, newType.OfType)
+		}
+		return false
+	case *NonNull:
+		if newType, ok := newType.(*NonNull); ok {
+			return isChangeSafeForObjectOrInterfaceField(oldType.OfType, newType.OfType)
+		}
+		return false
+	}
+	if newType, ok := newType.(*NonNull); ok {
+		return isChangeSafeForObjectOrInterfaceField(oldType, newType.OfType)
+	}
+	return isNamedTypeUnchanged(oldType, newType)
+}
+
+// isChangeSafeForInputObjectFieldOrFieldArg reports whether values clients provide
+// for oldType are still accepted by newType. Input types may only become looser.
+func isChangeSafeForInputObjectFieldOrFieldArg(oldType, newType Type) bool {
+	switch oldType := oldType.(type) {
+	case *List:
+		if newType, ok := newType.(*List); ok {
+			return isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType.OfType)
+		}
+		return false
+	case *NonNull:
+		switch newType := newType.(type) {
+		case *NonNull:
+			return isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType.OfType)
+		default:
+			return isChangeSafeForInputObjectFieldOrFieldArg(oldType.OfType, newType)
+		}
+	}
+	return isNamedTypeUnchanged(oldType, newType)
+}
+
+func isNamedTypeUnchanged(oldType, newType Type) bool {
+	switch newType.(type) {
+	case *List, *NonNull:
+		return false
+	}
+	return oldType.Name() == newType.Name()
+}
+
+func isSpecifiedScalar(ttype Type) bool {
+	switch ttype {
+	case Int, Float, String, Boolean, ID:
+		return true
+	}
+	return false
+}
+
+func isRequiredInput(ttype Type, defaultValue interface{}) bool {
+	_, ok := ttype.(*NonNull)
+	return ok && defaultValue == nil
+}
+
+func printDefaultValue(value interface{}, ttype Type) string {
+	valueAST := astFromValue(value, ttype)
+	if valueAST == nil {
+		return "null"
+	}
+	return fmt.Sprint(printer.Print(valueAST))
+}
+
+func typeKindDescription(ttype Type) string {
+	switch ttype.(type) {
+	case *Scalar:
+		return "a Scalar type"
+	case *Object:
+		return "an Object type"
+	case *Interface:
+		return "an Interface type"
+	case *Union:
+		return "a Union type"
+	case *Enum:
+		return "an Enum type"
+	case *InputObject:
+		return "an Input type"
+	}
+	return fmt.Sprintf("%T", ttype)
+}
+
+func sortedTypeNames(typeMap TypeMap) []string {
+	names := make([]string, 0, len(typeMap))
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFieldNames(fields FieldDefinitionMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedInputFieldNames(fields InputObjectFieldMap) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedEnumValueNames(enum *Enum) []string {
+	names := make([]string, 0, len(enum.Values()))
+	for _, value := range enum.Values() {
+		names = append(names, value.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+)
+
+func mustBuildSchema(t *testing.T, sdl string) graphql.Schema {
+	schema, err := graphql.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("unexpected error building schema: %v", err)
+	}
+	return schema
+}
+
+func TestFindBreakingChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected []graphql.BreakingChange
+	}{
+		{
+			name: "removed type",
+			old:  `type Query { a: String } type Foo { a: String }`,
+			new:  `type Query { a: String }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeTypeRemoved, Description: "Foo was removed."},
+			},
+		},
+		{
+			name: "changed type kind",
+			old:  `type Query { a: String } type Foo { a: String }`,
+			new:  `type Query { a: String } input Foo { a: String }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeTypeChangedKind, Description: "Foo changed from an Object type to an Input type."},
+			},
+		},
+		{
+			name: "removed field",
+			old:  `type Query { a: String b: Int }`,
+			new:  `type Query { a: String }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeFieldRemoved, Description: "Query.b was removed."},
+			},
+		},
+		{
+			name: "loosened output nullability",
+			old:  `type Query { a: String! b: [Int] c: Int }`,
+			new:  `type Query { a: String b: [Int!]! c: [Int] }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeFieldChangedKind, Description: "Query.a changed type from String! to String."},
+				{Type: graphql.BreakingChangeFieldChangedKind, Description: "Query.c changed type from Int to [Int]."},
+			},
+		},
+		{
+			name: "tightened argument nullability",
+			old:  `type Query { a(x: Int, y: Int!): String }`,
+			new:  `type Query { a(x: Int!, y: Int): String }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeArgChangedKind, Description: "Query.a arg x has changed type from Int to Int!."},
+			},
+		},
+		{
+			name: "removed and added arguments",
+			old:  `type Query { a(x: Int): String }`,
+			new:  `type Query { a(y: Int!, z: Int! = 1): String }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeArgRemoved, Description: "Query.a arg x was removed."},
+				{Type: graphql.BreakingChangeRequiredArgAdded, Description: "A required arg y on Query.a was added."},
+			},
+		},
+		{
+			name: "removed enum value",
+			old:  `type Query { a: E } enum E { A B }`,
+			new:  `type Query { a: E } enum E { A }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeValueRemovedFromEnum, Description: "B was removed from enum type E."},
+			},
+		},
+		{
+			name: "removed union member",
+			old:  `type Query { a: U } type A { a: Int } type B { b: Int } union U = A | B`,
+			new:  `type Query { a: U } type A { a: Int } type B { b: Int } union U = A`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeTypeRemovedFromUnion, Description: "B was removed from union type U."},
+			},
+		},
+		{
+			name: "required input field added",
+			old:  `type Query { a(i: I): Int } input I { a: Int }`,
+			new:  `type Query { a(i: I): Int } input I { a: Int b: Int! }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeRequiredInputFieldAdded, Description: "A required field b on input type I was added."},
+			},
+		},
+		{
+			name: "removed interface",
+			old:  `type Query { a: A } interface I { a: Int } type A implements I { a: Int }`,
+			new:  `type Query { a: A } interface I { a: Int } type A { a: Int }`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeImplementedInterfaceRemoved, Description: "A no longer implements interface I."},
+			},
+		},
+		{
+			name: "directive changes",
+			old:  `type Query { a: Int } directive @a(x: Int) on FIELD | QUERY directive @b on FIELD`,
+			new:  `type Query { a: Int } directive @a(y: Int!) on FIELD`,
+			expected: []graphql.BreakingChange{
+				{Type: graphql.BreakingChangeRequiredDirectiveArgAdded, Description: "A required arg y on directive a was added."},
+				{Type: graphql.BreakingChangeDirectiveArgRemoved, Description: "x was removed from a."},
+				{Type: graphql.BreakingChangeDirectiveLocationRemoved, Description: "QUERY was removed from a."},
+				{Type: graphql.BreakingChangeDirectiveRemoved, Description: "b was removed."},
+			},
+		},
+		{
+			name: "no changes",
+			old:  `type Query { a(x: Int): String! }`,
+			new:  `type Query { a(x: Int): String! }`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := graphql.FindBreakingChanges(mustBuildSchema(t, test.old), mustBuildSchema(t, test.new))
+			if !reflect.DeepEqual(changes, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, changes)
+			}
+		})
+	}
+}
+
+func TestFindDangerousChanges(t *testing.T) {
+	oldSchema := mustBuildSchema(t, `
+		type Query { a(x: Int = 1): U e: E }
+		type A { a: Int }
+		type B implements I { b: Int }
+		interface I { b: Int }
+		union U = A
+		enum E { ONE }
+		input In { a: Int }
+		type M { m(i: In): Int }
+	`)
+	newSchema := mustBuildSchema(t, `
+		type Query { a(x: Int = 2, y: Int): U e: E }
+		type A implements I { a: Int b: Int }
+		type B implements I { b: Int }
+		interface I { b: Int }
+		union U = A | B
+		enum E { ONE TWO }
+		input In { a: Int b: Int }
+		type M { m(i: In): Int }
+	`)
+
+	expected := []graphql.DangerousChange{
+		{Type: graphql.DangerousChangeInterfaceAddedToObject, Description: "I added to interfaces implemented by A."},
+		{Type: graphql.DangerousChangeValueAddedToEnum, Description: "TWO was added to enum type E."},
+		{Type: graphql.DangerousChangeOptionalInputFieldAdded, Description: "An optional field b on input type In was added."},
+		{Type: graphql.DangerousChangeArgDefaultValueChange, Description: "Query.a arg x has changed defaultValue from 1 to 2."},
+		{Type: graphql.DangerousChangeOptionalArgAdded, Description: "An optional arg y on Query.a was added."},
+		{Type: graphql.DangerousChangeTypeAddedToUnion, Description: "B was added to union type U."},
+	}
+	changes := graphql.FindDangerousChanges(oldSchema, newSchema)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+	if breaking := graphql.FindBreakingChanges(oldSchema, newSchema); len(breaking) != 0 {
+		t.Fatalf("expected no breaking changes, got %v", breaking)
+	}
+}
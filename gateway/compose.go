@@ -0,0 +1,494 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/language/printer"
+	"github.com/GannettDigital/graphql/language/source"
+)
+
+// federationNames are the directives and types added to subgraph schemas for
+// federation, which are left out of the supergraph.
+var federationNames = map[string]bool{
+	"key": true, "external": true, "requires": true, "provides": true, "extends": true,
+	"_Any": true, "_FieldSet": true, "_Service": true, "_Entity": true,
+}
+
+// fieldDirectives are the federation directives of a field in a subgraph.
+type fieldDirectives struct {
+	external bool
+	requires string
+	provides string
+}
+
+// supergraph is the schema composed from subgraphs, with the federation
+// metadata the planner needs.
+type supergraph struct {
+	schema    graphql.Schema
+	subgraphs []Subgraph
+	// keys[typeName][subgraph] are the key field sets of an entity type in
+	// the subgraphs which declare it.
+	keys map[string]map[string][]string
+	// fields[typeName][fieldName][subgraph] are the directives of a field in
+	// the subgraphs which define it.
+	fields map[string]map[string]map[string]*fieldDirectives
+}
+
+// compose builds the supergraph of subgraphs from their SDL. Types defined by
+// several subgraphs are merged: objects and interfaces get the fields and the
+// directives of all their definitions, enums all their values and unions all
+// their members. A type defined with different kinds, or a field defined with
+// different types or arguments, by two subgraphs is reported as a
+// graphql.SchemaErrors.
+func compose(subgraphs []Subgraph, sdls []string) (*supergraph, error) {
+	s := &supergraph{
+		subgraphs: subgraphs,
+		keys:      map[string]map[string][]string{},
+		fields:    map[string]map[string]map[string]*fieldDirectives{},
+	}
+	documents := []*ast.Document{}
+	repeatable := map[string]bool{}
+	for i, subgraph := range subgraphs {
+		document, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(&source.Source{Name: subgraph.Name, Body: []byte(sdls[i])}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Cannot parse the schema of subgraph %q: %v", subgraph.Name, err)
+		}
+		documents = append(documents, document)
+		for _, def := range document.Definitions {
+			if def, ok := def.(*ast.DirectiveDefinition); ok && def.Repeatable {
+				repeatable[def.Name.Value] = true
+			}
+		}
+	}
+
+	m := &merger{supergraph: s, repeatable: repeatable, merged: map[string]ast.Node{}, definedBy: map[string]string{}}
+	for i, subgraph := range subgraphs {
+		m.subgraph = subgraph.Name
+		for _, def := range documents[i].Definitions {
+			switch def := def.(type) {
+			case *ast.TypeExtensionDefinition:
+				s.readObject(subgraph.Name, def.Definition)
+				m.add(def.Definition.Name.Value, def.Definition, m.mergeObject)
+			case *ast.ObjectDefinition:
+				if federationNames[def.Name.Value] {
+					continue
+				}
+				s.readObject(subgraph.Name, def)
+				m.add(def.Name.Value, def, m.mergeObject)
+			case *ast.InterfaceDefinition:
+				s.readFields(subgraph.Name, def.Name.Value, def.Fields)
+				m.add(def.Name.Value, def, m.mergeInterface)
+			case *ast.UnionDefinition:
+				if federationNames[def.Name.Value] {
+					continue
+				}
+				m.add(def.Name.Value, def, mergeUnion)
+			case *ast.EnumDefinition:
+				m.add(def.Name.Value, def, mergeEnum)
+			case *ast.InputObjectDefinition:
+				m.add(def.Name.Value, def, mergeInputObject)
+			case *ast.ScalarDefinition:
+				if federationNames[def.Name.Value] {
+					continue
+				}
+				m.add(def.Name.Value, def, mergeScalar)
+			case *ast.DirectiveDefinition:
+				name := "@" + def.Name.Value
+				if _, ok := m.merged[name]; !ok && !federationNames[def.Name.Value] {
+					m.merged[name] = def
+					m.names = append(m.names, name)
+				}
+			}
+		}
+	}
+	if len(m.errs) > 0 {
+		return nil, m.errs
+	}
+
+	document := ast.NewDocument(nil)
+	for _, name := range m.names {
+		document.Definitions = append(document.Definitions, m.merged[name])
+	}
+	schema, err := graphql.BuildASTSchema(document)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot compose the supergraph: %v", err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// merger merges the definitions of the subgraphs into the definitions of the
+// supergraph.
+type merger struct {
+	supergraph *supergraph
+	// subgraph is the subgraph whose definitions are being merged.
+	subgraph string
+	// repeatable holds the names of the repeatable directives.
+	repeatable map[string]bool
+	// merged holds the definitions of the supergraph, in the order of names.
+	merged map[string]ast.Node
+	names  []string
+	// definedBy[name] is the first subgraph defining a type.
+	definedBy map[string]string
+	errs      graphql.SchemaErrors
+}
+
+// add merges the definition of a type with merge, unless the type is already
+// defined with another kind.
+func (m *merger) add(name string, def ast.Node, merge func(existing ast.Node, def ast.Node) ast.Node) {
+	existing, ok := m.merged[name]
+	if !ok {
+		m.names = append(m.names, name)
+		m.definedBy[name] = m.subgraph
+	} else if kindOf(existing) != kindOf(def) {
+		m.conflict(name, `Type "%v" is %v in subgraph %q but %v in subgraph %q.`,
+			name, kindOf(existing), m.definedBy[name], kindOf(def), m.subgraph)
+		return
+	}
+	m.merged[name] = merge(existing, def)
+}
+
+func (m *merger) conflict(coordinate string, format string, a ...interface{}) {
+	m.errs = append(m.errs, &graphql.SchemaError{Coordinate: coordinate, Message: fmt.Sprintf(format, a...)})
+}
+
+// kindOf describes the kind of a type definition.
+func kindOf(def ast.Node) string {
+	switch def.(type) {
+	case *ast.ObjectDefinition:
+		return "an object"
+	case *ast.InterfaceDefinition:
+		return "an interface"
+	case *ast.UnionDefinition:
+		return "a union"
+	case *ast.EnumDefinition:
+		return "an enum"
+	case *ast.InputObjectDefinition:
+		return "an input object"
+	case *ast.ScalarDefinition:
+		return "a scalar"
+	}
+	return "a definition"
+}
+
+// readObject records the keys and field directives of an object in a
+// subgraph.
+func (s *supergraph) readObject(subgraph string, def *ast.ObjectDefinition) {
+	for _, directive := range def.Directives {
+		if directive.Name.Value != "key" {
+			continue
+		}
+		if fields := stringArgument(directive, "fields"); fields != "" {
+			if s.keys[def.Name.Value] == nil {
+				s.keys[def.Name.Value] = map[string][]string{}
+			}
+			s.keys[def.Name.Value][subgraph] = append(s.keys[def.Name.Value][subgraph], fields)
+		}
+	}
+	s.readFields(subgraph, def.Name.Value, def.Fields)
+}
+
+func (s *supergraph) readFields(subgraph string, typeName string, fields []*ast.FieldDefinition) {
+	if s.fields[typeName] == nil {
+		s.fields[typeName] = map[string]map[string]*fieldDirectives{}
+	}
+	for _, field := range fields {
+		directives := &fieldDirectives{}
+		for _, directive := range field.Directives {
+			switch directive.Name.Value {
+			case "external":
+				directives.external = true
+			case "requires":
+				directives.requires = stringArgument(directive, "fields")
+			case "provides":
+				directives.provides = stringArgument(directive, "fields")
+			}
+		}
+		if s.fields[typeName][field.Name.Value] == nil {
+			s.fields[typeName][field.Name.Value] = map[string]*fieldDirectives{}
+		}
+		s.fields[typeName][field.Name.Value][subgraph] = directives
+	}
+}
+
+// isEntity reports whether a type is an entity in any subgraph.
+func (s *supergraph) isEntity(typeName string) bool {
+	return len(s.keys[typeName]) > 0
+}
+
+// owner returns the first subgraph resolving a field, rather than marking it
+// as external.
+func (s *supergraph) owner(typeName string, fieldName string) string {
+	definitions := s.fields[typeName][fieldName]
+	for _, subgraph := range s.subgraphs {
+		if directives, ok := definitions[subgraph.Name]; ok && !directives.external {
+			return subgraph.Name
+		}
+	}
+	return ""
+}
+
+// resolvable reports whether a subgraph can resolve a field of a type: it
+// defines the field without marking it as external, or the field is part of
+// one of the keys the subgraph declares for the type.
+func (s *supergraph) resolvable(typeName string, fieldName string, subgraph string) bool {
+	if directives, ok := s.fields[typeName][fieldName][subgraph]; ok && !directives.external {
+		return true
+	}
+	for _, key := range s.keys[typeName][subgraph] {
+		for _, selection := range parseFieldSet(key) {
+			if field, ok := selection.(*ast.Field); ok && field.Name.Value == fieldName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// key returns the key by which a subgraph resolves the entities of a type.
+func (s *supergraph) key(typeName string, subgraph string) string {
+	if keys := s.keys[typeName][subgraph]; len(keys) > 0 {
+		return keys[0]
+	}
+	for _, other := range s.subgraphs {
+		if keys := s.keys[typeName][other.Name]; len(keys) > 0 {
+			return keys[0]
+		}
+	}
+	return ""
+}
+
+func (s *supergraph) subgraph(name string) Subgraph {
+	for _, subgraph := range s.subgraphs {
+		if subgraph.Name == name {
+			return subgraph
+		}
+	}
+	return Subgraph{Name: name}
+}
+
+// parseFieldSet parses a field set, such as "id organization { id }".
+func parseFieldSet(fieldSet string) []ast.Selection {
+	document, err := parser.Parse(parser.ParseParams{Source: "{" + fieldSet + "}"})
+	if err != nil || len(document.Definitions) != 1 {
+		return nil
+	}
+	operation, ok := document.Definitions[0].(*ast.OperationDefinition)
+	if !ok {
+		return nil
+	}
+	return operation.SelectionSet.Selections
+}
+
+func stringArgument(directive *ast.Directive, name string) string {
+	for _, arg := range directive.Arguments {
+		if value, ok := arg.Value.(*ast.StringValue); ok && arg.Name.Value == name {
+			return value.Value
+		}
+	}
+	return ""
+}
+
+// withoutFederationDirectives returns directives without the federation ones.
+func withoutFederationDirectives(directives []*ast.Directive) []*ast.Directive {
+	kept := []*ast.Directive{}
+	for _, directive := range directives {
+		if !federationNames[directive.Name.Value] {
+			kept = append(kept, directive)
+		}
+	}
+	return kept
+}
+
+// mergeFields adds the fields of definitions to the fields of a type,
+// preferring the definitions which are not external. A field must have the
+// same type and arguments in every subgraph.
+func (m *merger) mergeFields(typeName string, fields []*ast.FieldDefinition, definitions []*ast.FieldDefinition) []*ast.FieldDefinition {
+	merged := append([]*ast.FieldDefinition{}, fields...)
+	for _, definition := range definitions {
+		if definition.Name.Value == "_service" || definition.Name.Value == "_entities" {
+			continue
+		}
+		field := *definition
+		field.Directives = withoutFederationDirectives(definition.Directives)
+		found := false
+		for i, existing := range merged {
+			if existing.Name.Value != field.Name.Value {
+				continue
+			}
+			found = true
+			if fieldSignature(existing) != fieldSignature(&field) {
+				m.conflict(typeName+"."+field.Name.Value, `Field "%v.%v" is "%v" in subgraph %q but "%v" in subgraph %q.`,
+					typeName, field.Name.Value, fieldSignature(existing), m.fieldDefinedBy(typeName, field.Name.Value),
+					fieldSignature(&field), m.subgraph)
+			} else if !hasDirective(definition.Directives, "external") {
+				merged[i] = &field
+			}
+		}
+		if !found {
+			merged = append(merged, &field)
+		}
+	}
+	return merged
+}
+
+// fieldDefinedBy returns the first subgraph other than the one being merged
+// which defines a field, or the one being merged when it defines it twice.
+func (m *merger) fieldDefinedBy(typeName string, fieldName string) string {
+	for _, subgraph := range m.supergraph.subgraphs {
+		if _, ok := m.supergraph.fields[typeName][fieldName][subgraph.Name]; ok && subgraph.Name != m.subgraph {
+			return subgraph.Name
+		}
+	}
+	return m.subgraph
+}
+
+// fieldSignature prints the arguments and the type of a field, such as
+// "reviews(first: Int): [Review]", its arguments sorted by name.
+func fieldSignature(field *ast.FieldDefinition) string {
+	args := []string{}
+	for _, arg := range field.Arguments {
+		args = append(args, fmt.Sprintf("%v: %v", arg.Name.Value, printer.Print(arg.Type)))
+	}
+	sort.Strings(args)
+	signature := field.Name.Value
+	if len(args) > 0 {
+		signature += "(" + strings.Join(args, ", ") + ")"
+	}
+	return fmt.Sprintf("%v: %v", signature, printer.Print(field.Type))
+}
+
+// mergeDirectives adds the directives of definitions other than the federation
+// ones to directives, once for directives which are not repeatable.
+func (m *merger) mergeDirectives(directives []*ast.Directive, definitions []*ast.Directive) []*ast.Directive {
+	merged := append([]*ast.Directive{}, directives...)
+	for _, directive := range withoutFederationDirectives(definitions) {
+		if m.repeatable[directive.Name.Value] || !hasDirective(merged, directive.Name.Value) {
+			merged = append(merged, directive)
+		}
+	}
+	return merged
+}
+
+func mergeInterfaces(interfaces []*ast.Named, others []*ast.Named) []*ast.Named {
+	merged := append([]*ast.Named{}, interfaces...)
+	for _, other := range others {
+		if !containsNamed(merged, other) {
+			merged = append(merged, other)
+		}
+	}
+	return merged
+}
+
+func (m *merger) mergeObject(existing ast.Node, node ast.Node) ast.Node {
+	def := node.(*ast.ObjectDefinition)
+	object, ok := existing.(*ast.ObjectDefinition)
+	if !ok {
+		object = ast.NewObjectDefinition(&ast.ObjectDefinition{Name: def.Name, Description: def.Description})
+	}
+	merged := ast.NewObjectDefinition(object)
+	if merged.Description == nil {
+		merged.Description = def.Description
+	}
+	merged.Interfaces = mergeInterfaces(object.Interfaces, def.Interfaces)
+	merged.Directives = m.mergeDirectives(object.Directives, def.Directives)
+	merged.Fields = m.mergeFields(def.Name.Value, object.Fields, def.Fields)
+	return merged
+}
+
+func (m *merger) mergeInterface(existing ast.Node, node ast.Node) ast.Node {
+	def := node.(*ast.InterfaceDefinition)
+	iface, ok := existing.(*ast.InterfaceDefinition)
+	if !ok {
+		iface = ast.NewInterfaceDefinition(&ast.InterfaceDefinition{Name: def.Name, Description: def.Description})
+	}
+	merged := ast.NewInterfaceDefinition(iface)
+	merged.Interfaces = mergeInterfaces(iface.Interfaces, def.Interfaces)
+	merged.Directives = m.mergeDirectives(iface.Directives, def.Directives)
+	merged.Fields = m.mergeFields(def.Name.Value, iface.Fields, def.Fields)
+	return merged
+}
+
+func mergeUnion(existing ast.Node, node ast.Node) ast.Node {
+	def := node.(*ast.UnionDefinition)
+	union, ok := existing.(*ast.UnionDefinition)
+	if !ok {
+		return def
+	}
+	merged := ast.NewUnionDefinition(union)
+	merged.Types = mergeInterfaces(union.Types, def.Types)
+	return merged
+}
+
+func mergeEnum(existing ast.Node, node ast.Node) ast.Node {
+	def := node.(*ast.EnumDefinition)
+	enum, ok := existing.(*ast.EnumDefinition)
+	if !ok {
+		return def
+	}
+	merged := ast.NewEnumDefinition(enum)
+	merged.Values = append([]*ast.EnumValueDefinition{}, enum.Values...)
+	for _, value := range def.Values {
+		found := false
+		for _, existing := range merged.Values {
+			found = found || existing.Name.Value == value.Name.Value
+		}
+		if !found {
+			merged.Values = append(merged.Values, value)
+		}
+	}
+	return merged
+}
+
+func mergeInputObject(existing ast.Node, node ast.Node) ast.Node {
+	def := node.(*ast.InputObjectDefinition)
+	input, ok := existing.(*ast.InputObjectDefinition)
+	if !ok {
+		return def
+	}
+	merged := ast.NewInputObjectDefinition(input)
+	merged.Fields = append([]*ast.InputValueDefinition{}, input.Fields...)
+	for _, field := range def.Fields {
+		found := false
+		for _, existing := range merged.Fields {
+			found = found || existing.Name.Value == field.Name.Value
+		}
+		if !found {
+			merged.Fields = append(merged.Fields, field)
+		}
+	}
+	return merged
+}
+
+// mergeScalar keeps the first definition of a scalar.
+func mergeScalar(existing ast.Node, def ast.Node) ast.Node {
+	if existing != nil {
+		return existing
+	}
+	return def
+}
+
+func containsNamed(named []*ast.Named, other *ast.Named) bool {
+	for _, n := range named {
+		if n.Name.Value == other.Name.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDirective(directives []*ast.Directive, name string) bool {
+	for _, directive := range directives {
+		if directive.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/client"
+)
+
+// Subgraph is a subgraph served at a URL.
+type Subgraph struct {
+	Name string
+	URL  string
+}
+
+// Fetcher sends the requests of a query plan to subgraphs.
+type Fetcher interface {
+	Fetch(ctx context.Context, subgraph Subgraph, request *client.Request) (*client.Response, error)
+}
+
+// FetcherFunc adapts a function to a Fetcher.
+type FetcherFunc func(ctx context.Context, subgraph Subgraph, request *client.Request) (*client.Response, error)
+
+// Fetch calls f(ctx, subgraph, request).
+func (f FetcherFunc) Fetch(ctx context.Context, subgraph Subgraph, request *client.Request) (*client.Response, error) {
+	return f(ctx, subgraph, request)
+}
+
+// HTTPFetcher posts requests to the URLs of subgraphs.
+type HTTPFetcher struct {
+	// Client sends the requests. http.DefaultClient is used when it is nil.
+	Client *http.Client
+	// Header is added to every request.
+	Header http.Header
+}
+
+// Fetch posts the request to the URL of the subgraph.
+func (f *HTTPFetcher) Fetch(ctx context.Context, subgraph Subgraph, request *client.Request) (*client.Response, error) {
+	transport := &client.HTTPTransport{URL: subgraph.URL, Client: f.Client, Header: f.Header}
+	return transport.Do(ctx, request)
+}
+
+// LocalFetcher executes requests against subgraph schemas in the same
+// process, by subgraph name. Responses are encoded as JSON, as they would be
+// over HTTP.
+type LocalFetcher map[string]graphql.Schema
+
+// Fetch executes the request against the schema of the subgraph.
+func (f LocalFetcher) Fetch(ctx context.Context, subgraph Subgraph, request *client.Request) (*client.Response, error) {
+	schema, ok := f[subgraph.Name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown subgraph %q.", subgraph.Name)
+	}
+	variables, _ := request.Variables.(map[string]interface{})
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: variables,
+		Context:        ctx,
+	})
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	response := &client.Response{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,517 @@
+// Package gateway serves a supergraph composed from Apollo Federation
+// subgraphs, such as the ones built with graphql.NewSubgraphSchema.
+//
+// The gateway fetches the SDL of each subgraph, composes the supergraph
+// schema from them, and resolves each operation with a query plan: the
+// subgraph fetches resolving its root fields, followed by the fetches
+// resolving the fields of the entities they return which other subgraphs own.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/client"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/language/source"
+)
+
+// Config is the configuration of a gateway.
+type Config struct {
+	Subgraphs []Subgraph
+	// Fetcher sends the requests to the subgraphs. An HTTPFetcher is used
+	// when it is nil.
+	Fetcher Fetcher
+}
+
+// Params are the parameters of an operation executed by a gateway.
+type Params struct {
+	// Context is passed to the fetcher.
+	Context context.Context
+
+	// The GraphQL operation(s) to execute.
+	RequestString string
+
+	// The name of the operation to use if the request contains multiple
+	// operations.
+	OperationName string
+
+	// The variables of the operation, which are sent to the subgraphs.
+	VariableValues map[string]interface{}
+}
+
+// Gateway executes operations against a supergraph.
+type Gateway struct {
+	supergraph *supergraph
+	fetcher    Fetcher
+}
+
+// New fetches the SDL of the subgraphs and composes their supergraph.
+func New(ctx context.Context, config Config) (*Gateway, error) {
+	fetcher := config.Fetcher
+	if fetcher == nil {
+		fetcher = &HTTPFetcher{}
+	}
+	sdls := []string{}
+	for _, subgraph := range config.Subgraphs {
+		response, err := fetcher.Fetch(ctx, subgraph, &client.Request{Query: "{ _service { sdl } }"})
+		if err == nil && len(response.Errors) > 0 {
+			err = response.Errors
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Cannot fetch the schema of subgraph %q: %v", subgraph.Name, err)
+		}
+		var data struct {
+			Service struct {
+				SDL string `json:"sdl"`
+			} `json:"_service"`
+		}
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			return nil, fmt.Errorf("Cannot fetch the schema of subgraph %q: %v", subgraph.Name, err)
+		}
+		sdls = append(sdls, data.Service.SDL)
+	}
+	supergraph, err := compose(config.Subgraphs, sdls)
+	if err != nil {
+		return nil, err
+	}
+	return &Gateway{supergraph: supergraph, fetcher: fetcher}, nil
+}
+
+// Schema returns the supergraph schema. Its fields have no resolvers: the
+// gateway resolves them with the subgraphs.
+func (g *Gateway) Schema() graphql.Schema {
+	return g.supergraph.schema
+}
+
+// Plan returns the query plan of an operation, without executing it.
+func (g *Gateway) Plan(p Params) (*QueryPlan, error) {
+	_, planner, err := g.prepare(p)
+	if err != nil {
+		return nil, err
+	}
+	return planner.plan()
+}
+
+// Do executes an operation by following its query plan.
+func (g *Gateway) Do(p Params) *graphql.Result {
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+	document, planner, err := g.prepare(p)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if errs := graphql.ValidateDocument(&g.supergraph.schema, document, nil); !errs.IsValid {
+		return &graphql.Result{Errors: errs.Errors}
+	}
+	plan, err := planner.plan()
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	e := &execution{
+		fetcher:    g.fetcher,
+		supergraph: g.supergraph,
+		ctx:        p.Context,
+		variables:  p.VariableValues,
+		data:       map[string]interface{}{},
+	}
+	if plan.Serial {
+		for _, fetch := range plan.Fetches {
+			e.run(fetch)
+		}
+	} else {
+		e.runAll(plan.Fetches)
+	}
+	if usesIntrospection(planner.operation.SelectionSet.Selections, planner.fragments) {
+		result := graphql.Do(graphql.Params{
+			Schema:         g.supergraph.schema,
+			RequestString:  p.RequestString,
+			OperationName:  p.OperationName,
+			VariableValues: p.VariableValues,
+			Context:        p.Context,
+		})
+		if data, ok := result.Data.(map[string]interface{}); ok {
+			e.introspection = data
+		}
+		e.errors = append(e.errors, result.Errors...)
+	}
+
+	rootType := g.supergraph.schema.QueryType()
+	if planner.operation.Operation == ast.OperationTypeMutation {
+		rootType = g.supergraph.schema.MutationType()
+	}
+	s := &shaper{execution: e, fragments: planner.fragments}
+	return &graphql.Result{
+		Data:   s.object(rootType, planner.operation.SelectionSet.Selections, e.data),
+		Errors: e.errors,
+	}
+}
+
+// prepare parses the request and finds the operation to execute.
+func (g *Gateway) prepare(p Params) (*ast.Document, *planner, error) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(p.RequestString), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	planner := &planner{supergraph: g.supergraph, fragments: map[string]*ast.FragmentDefinition{}}
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if p.OperationName == "" && planner.operation != nil {
+				return nil, nil, fmt.Errorf("Must provide operation name if query contains multiple operations.")
+			}
+			if p.OperationName == "" || definition.GetName() != nil && definition.GetName().Value == p.OperationName {
+				planner.operation = definition
+			}
+		case *ast.FragmentDefinition:
+			planner.fragments[definition.Name.Value] = definition
+		}
+	}
+	if planner.operation == nil {
+		if p.OperationName != "" {
+			return nil, nil, fmt.Errorf(`Unknown operation named "%v".`, p.OperationName)
+		}
+		return nil, nil, fmt.Errorf("Must provide an operation.")
+	}
+	return document, planner, nil
+}
+
+// execution is the state of the execution of a query plan.
+type execution struct {
+	fetcher    Fetcher
+	supergraph *supergraph
+	ctx        context.Context
+	variables  map[string]interface{}
+
+	mu            sync.Mutex
+	data          map[string]interface{}
+	errors        []gqlerrors.FormattedError
+	introspection map[string]interface{}
+}
+
+// runAll runs fetches in parallel.
+func (e *execution) runAll(fetches []*Fetch) {
+	var wg sync.WaitGroup
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func(fetch *Fetch) {
+			defer wg.Done()
+			e.run(fetch)
+		}(fetch)
+	}
+	wg.Wait()
+}
+
+// run runs a fetch, merges its data, and runs the fetches depending on it.
+func (e *execution) run(fetch *Fetch) {
+	variables := map[string]interface{}{}
+	for name, value := range e.variables {
+		variables[name] = value
+	}
+
+	var entities []map[string]interface{}
+	var paths [][]interface{}
+	if len(fetch.Path) > 0 {
+		e.mu.Lock()
+		collectEntities(e.data, fetch.Path, nil, fetch.representations, &entities, &paths)
+		representations := []interface{}{}
+		for _, entity := range entities {
+			representations = append(representations, project(entity, fetch.representations[entity["__typename"].(string)]))
+		}
+		e.mu.Unlock()
+		if len(entities) == 0 {
+			return
+		}
+		variables["representations"] = representations
+	}
+
+	response, err := e.fetcher.Fetch(e.ctx, e.supergraph.subgraph(fetch.Subgraph), &client.Request{
+		Query:     fetch.Query,
+		Variables: variables,
+	})
+	var data map[string]interface{}
+	if err == nil && len(response.Data) > 0 {
+		err = json.Unmarshal(response.Data, &data)
+	}
+
+	e.mu.Lock()
+	if err != nil {
+		e.errors = append(e.errors, gqlerrors.FormattedError{
+			Message:   fmt.Sprintf("Cannot fetch from subgraph %q: %v", fetch.Subgraph, err),
+			Locations: []location.SourceLocation{},
+		})
+		e.mu.Unlock()
+		return
+	}
+	for _, responseErr := range response.Errors {
+		e.errors = append(e.errors, gqlerrors.FormattedError{
+			Message:   responseErr.Message,
+			Locations: []location.SourceLocation{},
+			Path:      entityPath(responseErr.Path, paths),
+		})
+	}
+	if len(fetch.Path) > 0 {
+		results, _ := data["_entities"].([]interface{})
+		for i, result := range results {
+			if result, ok := result.(map[string]interface{}); ok && i < len(entities) {
+				merge(entities[i], result)
+			}
+		}
+	} else {
+		merge(e.data, data)
+	}
+	e.mu.Unlock()
+
+	e.runAll(fetch.Then)
+}
+
+// collectEntities collects the objects at path in value whose types have a
+// representation, and their paths in the data.
+func collectEntities(value interface{}, path []string, current []interface{}, types map[string][]ast.Selection, entities *[]map[string]interface{}, paths *[][]interface{}) {
+	if len(path) == 0 {
+		if object, ok := value.(map[string]interface{}); ok {
+			typename, _ := object["__typename"].(string)
+			if _, ok := types[typename]; ok {
+				*entities = append(*entities, object)
+				*paths = append(*paths, append([]interface{}{}, current...))
+			}
+		}
+		return
+	}
+	if path[0] == "@" {
+		list, _ := value.([]interface{})
+		for i, item := range list {
+			collectEntities(item, path[1:], append(current, i), types, entities, paths)
+		}
+		return
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		collectEntities(object[path[0]], path[1:], append(current, path[0]), types, entities, paths)
+	}
+}
+
+// project returns the fields of value in selections.
+func project(value interface{}, selections []ast.Selection) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		projected := []interface{}{}
+		for _, item := range value {
+			projected = append(projected, project(item, selections))
+		}
+		return projected
+	case map[string]interface{}:
+		projected := map[string]interface{}{}
+		for _, selection := range selections {
+			if field, ok := selection.(*ast.Field); ok {
+				key := responseKey(field)
+				if field.SelectionSet != nil {
+					projected[key] = project(value[key], field.SelectionSet.Selections)
+				} else {
+					projected[key] = value[key]
+				}
+			}
+		}
+		return projected
+	}
+	return value
+}
+
+// merge deeply merges the fields of source into target.
+func merge(target map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if existing, ok := target[key].(map[string]interface{}); ok {
+				merge(existing, value)
+				continue
+			}
+		case []interface{}:
+			if existing, ok := target[key].([]interface{}); ok && len(existing) == len(value) {
+				for i, item := range value {
+					existingItem, ok1 := existing[i].(map[string]interface{})
+					item, ok2 := item.(map[string]interface{})
+					if ok1 && ok2 {
+						merge(existingItem, item)
+					} else {
+						existing[i] = value[i]
+					}
+				}
+				continue
+			}
+		}
+		target[key] = value
+	}
+}
+
+// entityPath rewrites the path of an error of an entity fetch, which starts
+// with the index of the entity in "_entities", into its path in the data.
+func entityPath(path []interface{}, paths [][]interface{}) []interface{} {
+	if paths == nil || len(path) < 2 || path[0] != "_entities" {
+		return path
+	}
+	index, ok := path[1].(float64)
+	if !ok || int(index) < 0 || int(index) >= len(paths) {
+		return path
+	}
+	return append(append([]interface{}{}, paths[int(index)]...), path[2:]...)
+}
+
+// usesIntrospection reports whether root selections query the schema.
+func usesIntrospection(selections []ast.Selection, fragments map[string]*ast.FragmentDefinition) bool {
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if selection.Name.Value == "__schema" || selection.Name.Value == "__type" {
+				return true
+			}
+		case *ast.InlineFragment:
+			if usesIntrospection(selection.SelectionSet.Selections, fragments) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if fragment, ok := fragments[selection.Name.Value]; ok && usesIntrospection(fragment.SelectionSet.Selections, fragments) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shaper shapes the data fetched from the subgraphs as the operation
+// selects it, leaving out the fields added by the query plan.
+type shaper struct {
+	*execution
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func (s *shaper) object(parentType graphql.Type, selections []ast.Selection, value map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	s.collect(parentType, selections, value, result)
+	return result
+}
+
+func (s *shaper) collect(parentType graphql.Type, selections []ast.Selection, value map[string]interface{}, result map[string]interface{}) {
+	typename, _ := value["__typename"].(string)
+	if typename == "" {
+		typename = parentType.Name()
+	}
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if !s.included(selection.Directives) {
+				continue
+			}
+			key, name := responseKey(selection), selection.Name.Value
+			switch {
+			case name == "__typename":
+				result[key] = typename
+			case name == "__schema" || name == "__type":
+				result[key] = s.introspection[key]
+			case selection.SelectionSet == nil:
+				result[key] = value[key]
+			default:
+				definition := fieldDefinition(parentType, name)
+				if definition == nil {
+					continue
+				}
+				childType := graphql.GetNamed(definition.Type).(graphql.Type)
+				result[key] = s.complete(childType, selection.SelectionSet.Selections, value[key], result[key])
+			}
+		case *ast.InlineFragment:
+			if !s.included(selection.Directives) {
+				continue
+			}
+			conditionType := parentType
+			if selection.TypeCondition != nil {
+				conditionType = s.supergraph.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			if s.applies(conditionType, typename) {
+				s.collect(conditionType, selection.SelectionSet.Selections, value, result)
+			}
+		case *ast.FragmentSpread:
+			fragment, ok := s.fragments[selection.Name.Value]
+			if !ok || !s.included(selection.Directives) {
+				continue
+			}
+			conditionType := s.supergraph.schema.Type(fragment.TypeCondition.Name.Value)
+			if s.applies(conditionType, typename) {
+				s.collect(conditionType, fragment.SelectionSet.Selections, value, result)
+			}
+		}
+	}
+}
+
+func (s *shaper) complete(ttype graphql.Type, selections []ast.Selection, value interface{}, existing interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		existingList, _ := existing.([]interface{})
+		completed := make([]interface{}, len(value))
+		for i, item := range value {
+			var existingItem interface{}
+			if i < len(existingList) {
+				existingItem = existingList[i]
+			}
+			completed[i] = s.complete(ttype, selections, item, existingItem)
+		}
+		return completed
+	case map[string]interface{}:
+		result, ok := existing.(map[string]interface{})
+		if !ok {
+			result = map[string]interface{}{}
+		}
+		s.collect(ttype, selections, value, result)
+		return result
+	}
+	return nil
+}
+
+// applies reports whether a fragment on conditionType applies to an object
+// of the type named typename.
+func (s *shaper) applies(conditionType graphql.Type, typename string) bool {
+	if conditionType == nil {
+		return false
+	}
+	if conditionType.Name() == typename {
+		return true
+	}
+	object, ok := s.supergraph.schema.Type(typename).(*graphql.Object)
+	if !ok || !graphql.IsAbstractType(conditionType) {
+		return false
+	}
+	return s.supergraph.schema.IsPossibleType(conditionType.(graphql.Abstract), object)
+}
+
+// included evaluates the @skip and @include directives of a selection.
+func (s *shaper) included(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive.Name.Value != graphql.SkipDirective.Name && directive.Name.Value != graphql.IncludeDirective.Name {
+			continue
+		}
+		condition := false
+		for _, arg := range directive.Arguments {
+			if arg.Name.Value != "if" {
+				continue
+			}
+			switch value := arg.Value.(type) {
+			case *ast.BooleanValue:
+				condition = value.Value
+			case *ast.Variable:
+				condition, _ = s.variables[value.Name.Value].(bool)
+			}
+		}
+		if condition == (directive.Name.Value == graphql.SkipDirective.Name) {
+			return false
+		}
+	}
+	return true
+}
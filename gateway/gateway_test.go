@@ -0,0 +1,517 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/client"
+	"github.com/GannettDigital/graphql/gateway"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+var users = map[string]map[string]interface{}{
+	"1": {"id": "1", "name": "Ada Lovelace", "username": "@ada"},
+	"2": {"id": "2", "name": "Alan Turing", "username": "@alan"},
+}
+
+var products = []map[string]interface{}{
+	{"upc": "1", "name": "Table", "price": 899},
+	{"upc": "2", "name": "Couch", "price": 1299},
+	{"upc": "3", "name": "Chair", "price": 54},
+}
+
+var reviews = []map[string]interface{}{
+	{"id": "1", "authorID": "1", "upc": "1", "body": "Love it!"},
+	{"id": "2", "authorID": "1", "upc": "2", "body": "Too expensive."},
+	{"id": "3", "authorID": "2", "upc": "3", "body": "Could be better."},
+}
+
+func key(name string) []*graphql.AppliedDirective {
+	return []*graphql.AppliedDirective{{Name: "key", Args: map[string]interface{}{"fields": name}}}
+}
+
+func external() []*graphql.AppliedDirective {
+	return []*graphql.AppliedDirective{{Name: "external"}}
+}
+
+var accountsTestSchema, _ = graphql.NewSubgraphSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type: graphql.NewObject(graphql.ObjectConfig{
+					Name: "User",
+					Fields: graphql.Fields{
+						"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+						"name":     &graphql.Field{Type: graphql.String},
+						"username": &graphql.Field{Type: graphql.String},
+					},
+					AppliedDirectives: key("id"),
+					ResolveReference: func(p graphql.ResolveReferenceParams) (interface{}, error) {
+						return users[p.Representation["id"].(string)], nil
+					},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return users["1"], nil
+				},
+			},
+		},
+	}),
+})
+
+var productsTestSchema, _ = graphql.NewSubgraphSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"topProducts": &graphql.Field{
+				Type: graphql.NewList(graphql.NewObject(graphql.ObjectConfig{
+					Name: "Product",
+					Fields: graphql.Fields{
+						"upc":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+						"name":  &graphql.Field{Type: graphql.String},
+						"price": &graphql.Field{Type: graphql.Int},
+					},
+					AppliedDirectives: key("upc"),
+					ResolveReference: func(p graphql.ResolveReferenceParams) (interface{}, error) {
+						for _, product := range products {
+							if product["upc"] == p.Representation["upc"] {
+								return product, nil
+							}
+						}
+						return nil, nil
+					},
+				})),
+				Args: graphql.FieldConfigArgument{
+					"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 5},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					first := p.Args["first"].(int)
+					if first > len(products) {
+						first = len(products)
+					}
+					return products[:first], nil
+				},
+			},
+		},
+	}),
+})
+
+var reviewsTestSchema graphql.Schema
+
+// localFetcher executes the requests of the gateway against the test
+// subgraphs.
+var localFetcher gateway.LocalFetcher
+
+func init() {
+	var review *graphql.Object
+	user := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID), AppliedDirectives: external()},
+				"username": &graphql.Field{Type: graphql.String, AppliedDirectives: external()},
+				"reviews": &graphql.Field{
+					Type: graphql.NewList(review),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return filterReviews("authorID", p.Source.(map[string]interface{})["id"]), nil
+					},
+				},
+			}
+		}),
+		AppliedDirectives: append([]*graphql.AppliedDirective{{Name: "extends"}}, key("id")...),
+	})
+	product := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"upc": &graphql.Field{Type: graphql.NewNonNull(graphql.String), AppliedDirectives: external()},
+				"reviews": &graphql.Field{
+					Type: graphql.NewList(review),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return filterReviews("upc", p.Source.(map[string]interface{})["upc"]), nil
+					},
+				},
+			}
+		}),
+		AppliedDirectives: append([]*graphql.AppliedDirective{{Name: "extends"}}, key("upc")...),
+	})
+	review = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Review",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"body": &graphql.Field{Type: graphql.String},
+			"author": &graphql.Field{
+				Type:              user,
+				AppliedDirectives: []*graphql.AppliedDirective{{Name: "provides", Args: map[string]interface{}{"fields": "username"}}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					user := users[p.Source.(map[string]interface{})["authorID"].(string)]
+					return map[string]interface{}{"id": user["id"], "username": user["username"]}, nil
+				},
+			},
+			"product": &graphql.Field{
+				Type: product,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"upc": p.Source.(map[string]interface{})["upc"]}, nil
+				},
+			},
+		},
+	})
+	reviewsTestSchema, _ = graphql.NewSubgraphSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"latestReviews": &graphql.Field{
+					Type: graphql.NewList(review),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return reviews[len(reviews)-1:], nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{user, product},
+	})
+	localFetcher = gateway.LocalFetcher{
+		"accounts":  accountsTestSchema,
+		"products":  productsTestSchema,
+		"reviews":   reviewsTestSchema,
+		"inventory": inventoryTestSchema,
+	}
+}
+
+func filterReviews(field string, value interface{}) []map[string]interface{} {
+	filtered := []map[string]interface{}{}
+	for _, review := range reviews {
+		if review[field] == value {
+			filtered = append(filtered, review)
+		}
+	}
+	return filtered
+}
+
+var inventoryTestSchema, _ = graphql.NewSubgraphSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"inStockCount": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return len(products) - 1, nil
+				},
+			},
+		},
+	}),
+	Types: []graphql.Type{
+		graphql.NewObject(graphql.ObjectConfig{
+			Name: "Product",
+			Fields: graphql.Fields{
+				"upc":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), AppliedDirectives: external()},
+				"price": &graphql.Field{Type: graphql.Int, AppliedDirectives: external()},
+				"inStock": &graphql.Field{
+					Type: graphql.Boolean,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(map[string]interface{})["upc"] != "2", nil
+					},
+				},
+				"shippingEstimate": &graphql.Field{
+					Type:              graphql.Int,
+					AppliedDirectives: []*graphql.AppliedDirective{{Name: "requires", Args: map[string]interface{}{"fields": "price"}}},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return int(p.Source.(map[string]interface{})["price"].(float64)) / 100, nil
+					},
+				},
+			},
+			AppliedDirectives: append([]*graphql.AppliedDirective{{Name: "extends"}}, key("upc")...),
+		}),
+	},
+})
+
+func newGateway(t *testing.T, fetcher gateway.Fetcher) *gateway.Gateway {
+	g, err := gateway.New(context.Background(), gateway.Config{
+		Subgraphs: []gateway.Subgraph{
+			{Name: "accounts"},
+			{Name: "products"},
+			{Name: "reviews"},
+			{Name: "inventory"},
+		},
+		Fetcher: fetcher,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return g
+}
+
+const gatewayQuery = `query ($first: Int) {
+	me {
+		name
+		reviews {
+			body
+			product { name shippingEstimate }
+		}
+	}
+	topProducts(first: $first) {
+		...ProductDetails
+		reviews { author { username } }
+	}
+}
+
+fragment ProductDetails on Product {
+	name
+	inStock
+}`
+
+func TestGateway_PlansOperations(t *testing.T) {
+	plan, err := newGateway(t, localFetcher).Plan(gateway.Params{RequestString: gatewayQuery})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `QueryPlan {
+  Parallel {
+    Fetch(subgraph: "accounts") {
+      {
+        me {
+          __typename
+          name
+          id
+        }
+      }
+    }
+      Fetch(subgraph: "reviews", path: "me") {
+        User representation: __typename id
+        query ($representations: [_Any!]!) {
+          _entities(representations: $representations) {
+            ... on User {
+              reviews {
+                body
+                product {
+                  __typename
+                  upc
+                }
+              }
+            }
+          }
+        }
+      }
+        Fetch(subgraph: "products", path: "me.reviews.@.product") {
+          Product representation: __typename upc
+          query ($representations: [_Any!]!) {
+            _entities(representations: $representations) {
+              ... on Product {
+                name
+                price
+              }
+            }
+          }
+        }
+          Fetch(subgraph: "inventory", path: "me.reviews.@.product") {
+            Product representation: __typename upc price
+            query ($representations: [_Any!]!) {
+              _entities(representations: $representations) {
+                ... on Product {
+                  shippingEstimate
+                }
+              }
+            }
+          }
+    Fetch(subgraph: "products") {
+      query ($first: Int) {
+        topProducts(first: $first) {
+          __typename
+          ... on Product {
+            name
+            __typename
+            upc
+          }
+          upc
+        }
+      }
+    }
+      Fetch(subgraph: "inventory", path: "topProducts.@") {
+        Product representation: __typename upc
+        query ($representations: [_Any!]!) {
+          _entities(representations: $representations) {
+            ... on Product {
+              inStock
+            }
+          }
+        }
+      }
+      Fetch(subgraph: "reviews", path: "topProducts.@") {
+        Product representation: __typename upc
+        query ($representations: [_Any!]!) {
+          _entities(representations: $representations) {
+            ... on Product {
+              reviews {
+                author {
+                  __typename
+                  username
+                }
+              }
+            }
+          }
+        }
+      }
+  }
+}
+`
+	if plan.String() != expected {
+		t.Fatalf("Unexpected plan, Diff: %v", testutil.Diff(expected, plan.String()))
+	}
+}
+
+func TestGateway_ExecutesPlans(t *testing.T) {
+	result := newGateway(t, localFetcher).Do(gateway.Params{
+		RequestString:  gatewayQuery,
+		VariableValues: map[string]interface{}{"first": 2},
+	})
+	expected := `{
+		"data": {
+			"me": {
+				"name": "Ada Lovelace",
+				"reviews": [
+					{"body": "Love it!", "product": {"name": "Table", "shippingEstimate": 8}},
+					{"body": "Too expensive.", "product": {"name": "Couch", "shippingEstimate": 12}}
+				]
+			},
+			"topProducts": [
+				{"name": "Table", "inStock": true, "reviews": [{"author": {"username": "@ada"}}]},
+				{"name": "Couch", "inStock": false, "reviews": [{"author": {"username": "@ada"}}]}
+			]
+		}
+	}`
+	assertJSON(t, result, expected)
+}
+
+func TestGateway_RewritesEntityErrorPaths(t *testing.T) {
+	fetcher := gateway.FetcherFunc(func(ctx context.Context, subgraph gateway.Subgraph, request *client.Request) (*client.Response, error) {
+		response, err := localFetcher.Fetch(ctx, subgraph, request)
+		if variables, ok := request.Variables.(map[string]interface{}); ok && err == nil && subgraph.Name == "inventory" && variables["representations"] != nil {
+			response.Data = json.RawMessage(`{"_entities": [{"inStock": true}, null]}`)
+			response.Errors = client.Errors{{Message: "Inventory is down.", Path: []interface{}{"_entities", 1.0, "inStock"}}}
+		}
+		return response, err
+	})
+	result := newGateway(t, fetcher).Do(gateway.Params{
+		RequestString: `{ topProducts(first: 2) { name inStock } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"topProducts": []interface{}{
+				map[string]interface{}{"name": "Table", "inStock": true},
+				map[string]interface{}{"name": "Couch", "inStock": nil},
+			},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Inventory is down.",
+				Locations: []location.SourceLocation{},
+				Path:      []interface{}{"topProducts", 1, "inStock"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func assertJSON(t *testing.T, result *graphql.Result, expected string) {
+	actual, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actualValue, expectedValue interface{}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedValue, actualValue))
+	}
+}
+
+// sdlFetcher serves the SDL of subgraphs.
+func sdlFetcher(sdls map[string]string) gateway.Fetcher {
+	return gateway.FetcherFunc(func(ctx context.Context, subgraph gateway.Subgraph, request *client.Request) (*client.Response, error) {
+		data, err := json.Marshal(map[string]interface{}{"_service": map[string]interface{}{"sdl": sdls[subgraph.Name]}})
+		return &client.Response{Data: data}, err
+	})
+}
+
+func TestGateway_ReportsCompositionConflicts(t *testing.T) {
+	tests := []struct {
+		description string
+		products    string
+		inventory   string
+		expected    error
+	}{
+		{
+			description: "type kinds",
+			products:    `type Query { product: Product } type Product { upc: String }`,
+			inventory:   `type Query { stock: Product } interface Product { upc: String }`,
+			expected: graphql.SchemaErrors{{
+				Coordinate: "Product",
+				Message:    `Type "Product" is an object in subgraph "products" but an interface in subgraph "inventory".`,
+			}},
+		},
+		{
+			description: "field types",
+			products:    `type Query { price: Int }`,
+			inventory:   `type Query { price: Float }`,
+			expected: graphql.SchemaErrors{{
+				Coordinate: "Query.price",
+				Message:    `Field "Query.price" is "price: Int" in subgraph "products" but "price: Float" in subgraph "inventory".`,
+			}},
+		},
+		{
+			description: "field arguments",
+			products:    `type Query { upcs(first: Int): [String] }`,
+			inventory:   `type Query { upcs: [String] }`,
+			expected: graphql.SchemaErrors{{
+				Coordinate: "Query.upcs",
+				Message:    `Field "Query.upcs" is "upcs(first: Int): [String]" in subgraph "products" but "upcs: [String]" in subgraph "inventory".`,
+			}},
+		},
+	}
+	for _, test := range tests {
+		_, err := gateway.New(context.Background(), gateway.Config{
+			Subgraphs: []gateway.Subgraph{{Name: "products"}, {Name: "inventory"}},
+			Fetcher:   sdlFetcher(map[string]string{"products": test.products, "inventory": test.inventory}),
+		})
+		if !reflect.DeepEqual(err, test.expected) {
+			t.Errorf("Test %q - Diff: %v", test.description, testutil.Diff(test.expected, err))
+		}
+	}
+}
+
+func TestGateway_MergesDirectivesOfTypes(t *testing.T) {
+	g, err := gateway.New(context.Background(), gateway.Config{
+		Subgraphs: []gateway.Subgraph{{Name: "products"}, {Name: "inventory"}},
+		Fetcher: sdlFetcher(map[string]string{
+			"products": `directive @cached on OBJECT
+				directive @tag(name: String) repeatable on OBJECT
+				type Query @cached @tag(name: "products") { price: Int }`,
+			"inventory": `directive @cached on OBJECT
+				directive @tag(name: String) repeatable on OBJECT
+				type Query @cached @tag(name: "inventory") { stock: Int }`,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := g.Schema()
+	names := []string{}
+	for _, directive := range schema.QueryType().AppliedDirectives() {
+		names = append(names, directive.Name)
+	}
+	expected := []string{"cached", "tag", "tag"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Unexpected directives, Diff: %v", testutil.Diff(expected, names))
+	}
+}
@@ -0,0 +1,507 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/language/printer"
+)
+
+// QueryPlan is the plan of the subgraph fetches resolving an operation.
+type QueryPlan struct {
+	// Fetches resolve the root fields of the operation. They run in
+	// parallel, or one after the other for mutations.
+	Fetches []*Fetch
+	Serial  bool
+}
+
+// Fetch is a request to a subgraph in a query plan.
+type Fetch struct {
+	Subgraph string
+	// Path is the path in the data of the entities whose fields the fetch
+	// resolves, made of response keys and "@" for the items of lists. It is
+	// empty for fetches of root fields.
+	Path []string
+	// Representations are the selection sets the representations of entities
+	// are made of, by type name. They are empty for fetches of root fields.
+	Representations map[string]string
+	// Query is the document sent to the subgraph. Fetches of entities send
+	// their representations as the $representations variable.
+	Query string
+	// Then are the fetches depending on the data of this one, which run in
+	// parallel once it has completed.
+	Then []*Fetch
+
+	operation string
+	// fields are the selections of a root fetch, or of an entity fetch by
+	// type name, before they are planned.
+	fields          []ast.Selection
+	entityFields    map[string][]ast.Selection
+	entityTypes     []string
+	representations map[string][]ast.Selection
+}
+
+// String prints the plan for debugging, with each fetch followed by the
+// fetches depending on it.
+func (p *QueryPlan) String() string {
+	kind := "Parallel"
+	if p.Serial {
+		kind = "Sequence"
+	}
+	lines := []string{"QueryPlan {", "  " + kind + " {"}
+	for _, fetch := range p.Fetches {
+		lines = append(lines, fetch.lines("    ")...)
+	}
+	return strings.Join(append(lines, "  }", "}"), "\n") + "\n"
+}
+
+func (f *Fetch) lines(indentation string) []string {
+	header := fmt.Sprintf("Fetch(subgraph: %q", f.Subgraph)
+	if len(f.Path) > 0 {
+		header += fmt.Sprintf(", path: %q", strings.Join(f.Path, "."))
+	}
+	lines := []string{indentation + header + ") {"}
+	for _, typeName := range sortedKeys(f.Representations) {
+		lines = append(lines, fmt.Sprintf("%v  %v representation: %v", indentation, typeName, f.Representations[typeName]))
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(f.Query, "\n"), "\n") {
+		lines = append(lines, indentation+"  "+line)
+	}
+	lines = append(lines, indentation+"}")
+	for _, then := range f.Then {
+		lines = append(lines, then.lines(indentation+"  ")...)
+	}
+	return lines
+}
+
+// planner builds the query plan of an operation.
+type planner struct {
+	supergraph *supergraph
+	operation  *ast.OperationDefinition
+	fragments  map[string]*ast.FragmentDefinition
+}
+
+func (p *planner) plan() (*QueryPlan, error) {
+	schema := p.supergraph.schema
+	rootType := schema.QueryType()
+	plan := &QueryPlan{}
+	switch p.operation.Operation {
+	case ast.OperationTypeMutation:
+		rootType = schema.MutationType()
+		plan.Serial = true
+	case ast.OperationTypeSubscription:
+		return nil, fmt.Errorf("Subscriptions are not supported by the gateway.")
+	}
+	if rootType == nil {
+		return nil, fmt.Errorf("Schema is not configured for %vs.", p.operation.Operation)
+	}
+
+	for _, field := range p.rootFields(p.operation.SelectionSet.Selections, nil) {
+		name := field.Name.Value
+		if name == "__typename" || name == "__schema" || name == "__type" {
+			continue
+		}
+		owner := p.supergraph.owner(rootType.Name(), name)
+		if owner == "" {
+			return nil, fmt.Errorf(`Cannot plan field "%v.%v": no subgraph resolves it.`, rootType.Name(), name)
+		}
+		var fetch *Fetch
+		if plan.Serial {
+			if last := len(plan.Fetches) - 1; last >= 0 && plan.Fetches[last].Subgraph == owner {
+				fetch = plan.Fetches[last]
+			}
+		} else {
+			for _, existing := range plan.Fetches {
+				if existing.Subgraph == owner {
+					fetch = existing
+				}
+			}
+		}
+		if fetch == nil {
+			fetch = &Fetch{Subgraph: owner, operation: p.operation.Operation}
+			plan.Fetches = append(plan.Fetches, fetch)
+		}
+		fetch.fields = append(fetch.fields, field)
+	}
+
+	for _, fetch := range plan.Fetches {
+		selections, err := p.selections(fetch, fetch.Subgraph, rootType, fetch.fields, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		fetch.Query = p.printOperation(fetch.operation, nil, selections)
+		if err := p.planDependents(fetch); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// rootFields returns the root fields of the operation, from fragments too,
+// which take the directives of the fragments including them.
+func (p *planner) rootFields(selections []ast.Selection, directives []*ast.Directive) []*ast.Field {
+	fields := []*ast.Field{}
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if len(directives) > 0 {
+				selection = copyField(selection)
+				selection.Directives = append(append([]*ast.Directive{}, selection.Directives...), directives...)
+			}
+			fields = append(fields, selection)
+		case *ast.InlineFragment:
+			fields = append(fields, p.rootFields(selection.SelectionSet.Selections, append(directives, selection.Directives...))...)
+		case *ast.FragmentSpread:
+			if fragment, ok := p.fragments[selection.Name.Value]; ok {
+				fields = append(fields, p.rootFields(fragment.SelectionSet.Selections, append(directives, selection.Directives...))...)
+			}
+		}
+	}
+	return fields
+}
+
+// selections plans selections on parentType, found at path in the data, for
+// the subgraph of fetch. The fields the subgraph cannot resolve are deferred
+// to the entity fetches depending on fetch, and replaced by the fields their
+// representations are made of. Provided are the fields of parentType the
+// subgraph resolves because of an @provides directive.
+func (p *planner) selections(fetch *Fetch, subgraph string, parentType graphql.Type, selections []ast.Selection, path []string, provided []ast.Selection) ([]ast.Selection, error) {
+	planned := []ast.Selection{}
+	object, isObject := parentType.(*graphql.Object)
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			name := selection.Name.Value
+			if name == "__typename" {
+				planned = appendSelection(planned, selection)
+				continue
+			}
+			definition := fieldDefinition(parentType, name)
+			if definition == nil {
+				return nil, fmt.Errorf(`Cannot plan field "%v.%v": it is not defined.`, parentType.Name(), name)
+			}
+			if isObject && !p.supergraph.resolvable(object.Name(), name, subgraph) && findField(provided, name) == nil {
+				requirements, err := p.deferField(fetch, subgraph, object, selection, path)
+				if err != nil {
+					return nil, err
+				}
+				for _, requirement := range requirements {
+					planned = appendSelection(planned, requirement)
+				}
+				continue
+			}
+			field := copyField(selection)
+			if selection.SelectionSet != nil {
+				childType := graphql.GetNamed(definition.Type).(graphql.Type)
+				childProvided := []ast.Selection{}
+				if providedField := findField(provided, name); providedField != nil && providedField.SelectionSet != nil {
+					childProvided = providedField.SelectionSet.Selections
+				}
+				if isObject {
+					if directives, ok := p.supergraph.fields[object.Name()][name][subgraph]; ok && directives.provides != "" {
+						childProvided = append(childProvided, parseFieldSet(directives.provides)...)
+					}
+				}
+				childSelections, err := p.selections(fetch, subgraph, childType, selection.SelectionSet.Selections, childPath(path, selection, definition.Type), childProvided)
+				if err != nil {
+					return nil, err
+				}
+				if graphql.IsAbstractType(childType) || p.supergraph.isEntity(childType.Name()) {
+					prefixed := []ast.Selection{typenameField()}
+					for _, childSelection := range childSelections {
+						prefixed = appendSelection(prefixed, childSelection)
+					}
+					childSelections = prefixed
+				}
+				field.SelectionSet = ast.NewSelectionSet(&ast.SelectionSet{Selections: childSelections})
+			}
+			planned = appendSelection(planned, field)
+		case *ast.InlineFragment:
+			conditionType := parentType
+			if selection.TypeCondition != nil {
+				conditionType = p.supergraph.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			inner, err := p.selections(fetch, subgraph, conditionType, selection.SelectionSet.Selections, path, provided)
+			if err != nil {
+				return nil, err
+			}
+			planned = append(planned, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: selection.TypeCondition,
+				Directives:    selection.Directives,
+				SelectionSet:  ast.NewSelectionSet(&ast.SelectionSet{Selections: inner}),
+			}))
+		case *ast.FragmentSpread:
+			fragment, ok := p.fragments[selection.Name.Value]
+			if !ok {
+				return nil, fmt.Errorf(`Unknown fragment "%v".`, selection.Name.Value)
+			}
+			inner, err := p.selections(fetch, subgraph, p.supergraph.schema.Type(fragment.TypeCondition.Name.Value), fragment.SelectionSet.Selections, path, provided)
+			if err != nil {
+				return nil, err
+			}
+			planned = append(planned, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: fragment.TypeCondition,
+				Directives:    selection.Directives,
+				SelectionSet:  ast.NewSelectionSet(&ast.SelectionSet{Selections: inner}),
+			}))
+		}
+	}
+	return planned, nil
+}
+
+// deferField adds a field of an entity to the fetch of its owner depending on
+// fetch, and returns the fields of the representation of the entity. When
+// the subgraph of fetch cannot resolve the fields the owner requires either,
+// the fetch of the owner depends on the fetch resolving them instead.
+func (p *planner) deferField(fetch *Fetch, subgraph string, object *graphql.Object, field *ast.Field, path []string) ([]ast.Selection, error) {
+	typeName, name := object.Name(), field.Name.Value
+	owner := p.supergraph.owner(typeName, name)
+	if owner == "" || !p.supergraph.isEntity(typeName) {
+		return nil, fmt.Errorf(`Cannot plan field "%v.%v": subgraph %q cannot resolve it and it is not a field of an entity.`, typeName, name, subgraph)
+	}
+	fieldSet := "__typename " + p.supergraph.key(typeName, owner)
+	if directives := p.supergraph.fields[typeName][name][owner]; directives != nil && directives.requires != "" {
+		fieldSet += " " + directives.requires
+	}
+	requirements := parseFieldSet(fieldSet)
+
+	deferred := map[*Fetch]int{}
+	for _, then := range fetch.Then {
+		deferred[then] = len(then.entityFields[typeName])
+	}
+	planned, err := p.selections(fetch, subgraph, object, requirements, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	parent := fetch
+	for _, then := range fetch.Then {
+		if count, ok := deferred[then]; !ok || len(then.entityFields[typeName]) > count {
+			parent = then
+		}
+	}
+
+	var dependent *Fetch
+	for _, then := range parent.Then {
+		if then.Subgraph == owner && strings.Join(then.Path, ".") == strings.Join(path, ".") {
+			dependent = then
+		}
+	}
+	if dependent == nil {
+		dependent = &Fetch{
+			Subgraph:        owner,
+			Path:            path,
+			Representations: map[string]string{},
+			operation:       ast.OperationTypeQuery,
+			entityFields:    map[string][]ast.Selection{},
+			representations: map[string][]ast.Selection{},
+		}
+		parent.Then = append(parent.Then, dependent)
+	}
+	if _, ok := dependent.entityFields[typeName]; !ok {
+		dependent.entityTypes = append(dependent.entityTypes, typeName)
+	}
+	dependent.entityFields[typeName] = append(dependent.entityFields[typeName], field)
+	for _, requirement := range requirements {
+		if findField(dependent.representations[typeName], requirement.(*ast.Field).Name.Value) == nil {
+			dependent.representations[typeName] = append(dependent.representations[typeName], requirement)
+		}
+	}
+	printed := strings.TrimSuffix(strings.TrimPrefix(printSelections(dependent.representations[typeName]), "{"), "}")
+	dependent.Representations[typeName] = strings.Join(strings.Fields(printed), " ")
+	return planned, nil
+}
+
+// planDependents plans the entity fetches depending on fetch, and the ones
+// depending on them in turn.
+func (p *planner) planDependents(fetch *Fetch) error {
+	for i := 0; i < len(fetch.Then); i++ {
+		dependent := fetch.Then[i]
+		fragments := []ast.Selection{}
+		for _, typeName := range dependent.entityTypes {
+			object := p.supergraph.schema.Type(typeName)
+			inner, err := p.selections(dependent, dependent.Subgraph, object, dependent.entityFields[typeName], dependent.Path, nil)
+			if err != nil {
+				return err
+			}
+			fragments = append(fragments, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: ast.NewNamed(&ast.Named{Name: ast.NewName(&ast.Name{Value: typeName})}),
+				SelectionSet:  ast.NewSelectionSet(&ast.SelectionSet{Selections: inner}),
+			}))
+		}
+		entities := ast.NewField(&ast.Field{
+			Name: ast.NewName(&ast.Name{Value: "_entities"}),
+			Arguments: []*ast.Argument{ast.NewArgument(&ast.Argument{
+				Name:  ast.NewName(&ast.Name{Value: "representations"}),
+				Value: ast.NewVariable(&ast.Variable{Name: ast.NewName(&ast.Name{Value: "representations"})}),
+			})},
+			SelectionSet: ast.NewSelectionSet(&ast.SelectionSet{Selections: fragments}),
+		})
+		dependent.Query = p.printOperation(dependent.operation, []*ast.VariableDefinition{representationsVariable()}, []ast.Selection{entities})
+		if err := p.planDependents(dependent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printOperation prints an operation made of selections, defining the
+// variables of the operation they use.
+func (p *planner) printOperation(operation string, variables []*ast.VariableDefinition, selections []ast.Selection) string {
+	used := map[string]bool{}
+	for _, selection := range selections {
+		usedVariables(selection, used)
+	}
+	for _, definition := range p.operation.VariableDefinitions {
+		if used[definition.Variable.Name.Value] {
+			variables = append(variables, definition)
+		}
+	}
+	return printer.Print(ast.NewOperationDefinition(&ast.OperationDefinition{
+		Operation:           operation,
+		VariableDefinitions: variables,
+		SelectionSet:        ast.NewSelectionSet(&ast.SelectionSet{Selections: selections}),
+	})).(string)
+}
+
+// representationsVariable returns the definition of the $representations
+// variable of entity fetches.
+func representationsVariable() *ast.VariableDefinition {
+	document, _ := parser.Parse(parser.ParseParams{Source: "query ($representations: [_Any!]!) { __typename }"})
+	return document.Definitions[0].(*ast.OperationDefinition).VariableDefinitions[0]
+}
+
+// usedVariables records the names of the variables used by a node.
+func usedVariables(node interface{}, used map[string]bool) {
+	switch node := node.(type) {
+	case *ast.Field:
+		for _, arg := range node.Arguments {
+			usedVariables(arg.Value, used)
+		}
+		for _, directive := range node.Directives {
+			usedVariables(directive, used)
+		}
+		if node.SelectionSet != nil {
+			for _, selection := range node.SelectionSet.Selections {
+				usedVariables(selection, used)
+			}
+		}
+	case *ast.InlineFragment:
+		for _, directive := range node.Directives {
+			usedVariables(directive, used)
+		}
+		for _, selection := range node.SelectionSet.Selections {
+			usedVariables(selection, used)
+		}
+	case *ast.Directive:
+		for _, arg := range node.Arguments {
+			usedVariables(arg.Value, used)
+		}
+	case *ast.Variable:
+		used[node.Name.Value] = true
+	case *ast.ListValue:
+		for _, value := range node.Values {
+			usedVariables(value, used)
+		}
+	case *ast.ObjectValue:
+		for _, field := range node.Fields {
+			usedVariables(field.Value, used)
+		}
+	}
+}
+
+// childPath returns the path of the values of a field, with "@" for each list
+// the field type wraps them in.
+func childPath(path []string, field *ast.Field, ttype graphql.Type) []string {
+	child := append(append([]string{}, path...), responseKey(field))
+	for {
+		switch t := ttype.(type) {
+		case *graphql.NonNull:
+			ttype = t.OfType
+			continue
+		case *graphql.List:
+			child = append(child, "@")
+			ttype = t.OfType
+			continue
+		}
+		return child
+	}
+}
+
+func fieldDefinition(parentType graphql.Type, name string) *graphql.FieldDefinition {
+	if name == graphql.TypeNameMetaFieldDef.Name {
+		return graphql.TypeNameMetaFieldDef
+	}
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		return parentType.Fields()[name]
+	case *graphql.Interface:
+		return parentType.Fields()[name]
+	}
+	return nil
+}
+
+// appendSelection appends a selection to selections, unless it is a leaf
+// field without arguments or directives already selected under the same
+// response key, as the fields representations are made of often are.
+func appendSelection(selections []ast.Selection, selection ast.Selection) []ast.Selection {
+	if field, ok := selection.(*ast.Field); ok && isPlainLeaf(field) {
+		for _, existing := range selections {
+			if existing, ok := existing.(*ast.Field); ok && isPlainLeaf(existing) &&
+				existing.Name.Value == field.Name.Value && responseKey(existing) == responseKey(field) {
+				return selections
+			}
+		}
+	}
+	return append(selections, selection)
+}
+
+func isPlainLeaf(field *ast.Field) bool {
+	return field.SelectionSet == nil && len(field.Arguments) == 0 && len(field.Directives) == 0
+}
+
+func findField(selections []ast.Selection, name string) *ast.Field {
+	for _, selection := range selections {
+		if field, ok := selection.(*ast.Field); ok && field.Name.Value == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func copyField(field *ast.Field) *ast.Field {
+	return ast.NewField(&ast.Field{
+		Alias:        field.Alias,
+		Name:         field.Name,
+		Arguments:    field.Arguments,
+		Directives:   field.Directives,
+		SelectionSet: field.SelectionSet,
+	})
+}
+
+func typenameField() *ast.Field {
+	return ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "__typename"})})
+}
+
+func responseKey(field *ast.Field) string {
+	if field.Alias != nil {
+		return field.Alias.Value
+	}
+	return field.Name.Value
+}
+
+func printSelections(selections []ast.Selection) string {
+	return printer.Print(ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})).(string)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,6 +2,7 @@ package gqlerrors
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/GannettDigital/graphql/language/location"
 )
@@ -9,12 +10,34 @@ import (
 type FormattedError struct {
 	Message   string                    `json:"message"`
 	Locations []location.SourceLocation `json:"locations"`
+	// Path is the path of the response field the error occurred at, made of
+	// field names and list indices, when it is known.
+	Path []interface{} `json:"path,omitempty"`
+	// Extensions are additional entries of the error, such as its code.
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// ExtendedError is an error carrying extensions, such as an error code, which
+// are reported as the extensions of its formatted error.
+type ExtendedError interface {
+	error
+	Extensions() map[string]interface{}
 }
 
 func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// Error joins the messages of errs, so that resolvers can return several
+// errors at once, such as the errors of a remote execution.
+func (errs FormattedErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "\n")
+}
+
 func NewFormattedError(message string) FormattedError {
 	err := errors.New(message)
 	return FormatError(err)
@@ -26,22 +49,33 @@ func FormatError(err error) FormattedError {
 		return err
 	case *Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Extensions: extensionsOf(err.OriginalError),
 		}
 	case Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Extensions: extensionsOf(err.OriginalError),
 		}
 	default:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: []location.SourceLocation{},
+			Message:    err.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: extensionsOf(err),
 		}
 	}
 }
 
+// extensionsOf returns the extensions of err when it is an ExtendedError.
+func extensionsOf(err error) map[string]interface{} {
+	if err, ok := err.(ExtendedError); ok {
+		return err.Extensions()
+	}
+	return nil
+}
+
 func FormatErrors(errs ...error) []FormattedError {
 	formattedErrors := []FormattedError{}
 	for _, err := range errs {
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/ast"
 	"github.com/GannettDigital/graphql/language/parser"
 	"github.com/GannettDigital/graphql/language/source"
 )
@@ -42,32 +43,82 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// Visibility hides the types, fields, arguments and enum values of the
+	// schema it reports as not visible from validation and introspection,
+	// as if they did not exist.
+	Visibility VisibilityFn
+
+	// Authorize authorizes the fields which have no Authorize function of
+	// their own, such as the fields with the @auth directive authorized by
+	// AuthorizeRequires. Denied fields resolve to null with a FORBIDDEN error.
+	Authorize AuthorizeFn
+
+	// AuthorizeOnValidation rejects the whole request when it selects fields
+	// whose authorization is denied, as checked by AuthorizationRule.
+	AuthorizeOnValidation bool
+
+	// DisableIntrospection rejects the requests querying the __schema and
+	// __type introspection fields, as checked by NoIntrospectionRule.
+	DisableIntrospection bool
+
+	// HideSuggestions strips the "Did you mean ...?" suggestions from the
+	// validation errors, so that the names of the schema cannot be guessed
+	// from them.
+	HideSuggestions bool
 }
 
 func Do(p Params) *Result {
-	if manager == nil {
-		managerInit.Do(func() {
-			manager = newResolveManager()
-		})
+	AST, result := parseAndValidate(p)
+	if result != nil {
+		return result
 	}
+	return execute(p, AST)
+}
 
+// parseAndValidate parses the request of p and validates it against its
+// schema, returning either the document or the result reporting its errors.
+func parseAndValidate(p Params) (*ast.Document, *Result) {
+	p.Schema = p.Schema.withVisibility(p.Context, p.Visibility)
+	p.Schema.hideSuggestions = p.HideSuggestions
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
 		Name: "GraphQL request",
 	})
 	AST, err := parser.Parse(parser.ParseParams{Source: source})
 	if err != nil {
-		return &Result{
+		return nil, &Result{
 			Errors: gqlerrors.FormatErrors(err),
 		}
 	}
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	var rules []ValidationRuleFn
+	if p.DisableIntrospection || p.AuthorizeOnValidation {
+		rules = append(rules, SpecifiedRules...)
+	}
+	if p.DisableIntrospection {
+		rules = append(rules, NoIntrospectionRule)
+	}
+	if p.AuthorizeOnValidation {
+		rules = append(rules, AuthorizationRule(p.Context, p.Authorize))
+	}
+	validationResult := ValidateDocument(&p.Schema, AST, rules)
 
 	if !validationResult.IsValid {
-		return &Result{
+		return nil, &Result{
 			Errors: validationResult.Errors,
 		}
 	}
+	return AST, nil
+}
+
+// execute executes a document validated against the schema of p.
+func execute(p Params, AST *ast.Document) *Result {
+	p.Schema = p.Schema.withVisibility(p.Context, p.Visibility)
+	if manager == nil {
+		managerInit.Do(func() {
+			manager = newResolveManager()
+		})
+	}
 
 	ep := ExecuteParams{
 		Schema:        p.Schema,
@@ -76,12 +127,14 @@ func Do(p Params) *Result {
 		OperationName: p.OperationName,
 		Args:          p.VariableValues,
 		Context:       p.Context,
+		Authorize:     p.Authorize,
 		manager:       manager,
 	}
 
 	var cost int
 	var costMap map[string]int
 	if p.MaxCost > 0 {
+		var err error
 		cost, costMap, err = QueryComplexity(ep)
 		if err != nil {
 			return &Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}}
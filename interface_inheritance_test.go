@@ -0,0 +1,184 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+// isTypename resolves the objects of the media schema by their __typename.
+func isTypename(name string) graphql.IsTypeOfFn {
+	return func(p graphql.IsTypeOfParams) bool {
+		return p.Value.(map[string]interface{})["__typename"] == name
+	}
+}
+
+var mediaNodeInterface = graphql.NewInterface(graphql.InterfaceConfig{
+	Name: "Node",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+	},
+})
+
+var mediaInterface = graphql.NewInterface(graphql.InterfaceConfig{
+	Name:       "Media",
+	Interfaces: []*graphql.Interface{mediaNodeInterface},
+	Fields: graphql.Fields{
+		"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"title": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var mediaTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"nodes": &graphql.Field{Type: graphql.NewList(mediaNodeInterface)},
+			"media": &graphql.Field{Type: mediaInterface},
+		},
+	}),
+	Types: []graphql.Type{
+		graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Video",
+			Interfaces: []*graphql.Interface{mediaInterface, mediaNodeInterface},
+			Fields: graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"title":    &graphql.Field{Type: graphql.String},
+				"duration": &graphql.Field{Type: graphql.Int},
+			},
+			IsTypeOf: isTypename("Video"),
+		}),
+		graphql.NewObject(graphql.ObjectConfig{
+			Name:       "User",
+			Interfaces: []*graphql.Interface{mediaNodeInterface},
+			Fields: graphql.Fields{
+				"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			},
+			IsTypeOf: isTypename("User"),
+		}),
+	},
+})
+
+func TestInterfaceInheritance_ExecutesFragmentsOnInterfaces(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: mediaTestSchema,
+		RequestString: `{
+			nodes {
+				id
+				... on Media { title }
+				... on Video { duration }
+			}
+		}`,
+		RootObject: map[string]interface{}{
+			"nodes": []interface{}{
+				map[string]interface{}{"__typename": "Video", "id": "1", "title": "Intro", "duration": 60},
+				map[string]interface{}{"__typename": "User", "id": "2"},
+			},
+		},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"nodes": []interface{}{
+			map[string]interface{}{"id": "1", "title": "Intro", "duration": 60},
+			map[string]interface{}{"id": "2"},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("unexpected result: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestInterfaceInheritance_ValidatesFragmentSpreads(t *testing.T) {
+	valid := testutil.TestParse(t, `{ media { ... on Node { id } } nodes { ... on Media { title } } }`)
+	if result := graphql.ValidateDocument(&mediaTestSchema, valid, nil); !result.IsValid {
+		t.Fatalf("expected document to be valid, got %v", result.Errors)
+	}
+	invalid := testutil.TestParse(t, `{ media { ... on User { id } } }`)
+	if result := graphql.ValidateDocument(&mediaTestSchema, invalid, nil); result.IsValid {
+		t.Fatalf("expected spread of User within Media to be invalid")
+	}
+}
+
+func TestInterfaceInheritance_Introspection(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: mediaTestSchema,
+		RequestString: `{
+			__type(name: "Media") {
+				kind
+				interfaces { name }
+				possibleTypes { name }
+			}
+		}`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"__type": map[string]interface{}{
+			"kind":          "INTERFACE",
+			"interfaces":    []interface{}{map[string]interface{}{"name": "Node"}},
+			"possibleTypes": []interface{}{map[string]interface{}{"name": "Video"}},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("unexpected result: %v", testutil.Diff(expected, result.Data))
+	}
+}
+
+func TestInterfaceInheritance_RejectsInvalidImplementations(t *testing.T) {
+	tests := []struct {
+		sdl      string
+		expected string
+	}{
+		{
+			sdl: `
+				interface Node { id: ID! }
+				interface Media implements Node { id: ID! }
+				type Video implements Media { id: ID! }
+				type Query { media: Media }`,
+			expected: `Type Video must implement Node because it is implemented by Media.`,
+		},
+		{
+			sdl: `
+				interface Node { id: ID! }
+				interface Media implements Node { title: String }
+				type Query { media: Media }`,
+			expected: `"Node" expects field "id" but "Media" does not provide it.`,
+		},
+		{
+			sdl: `
+				interface Node implements Node { id: ID! }
+				type Query { node: Node }`,
+			expected: `Type Node cannot implement itself because it would create a circular reference.`,
+		},
+		{
+			sdl: `
+				interface A implements B { id: ID }
+				interface B implements A { id: ID }
+				type Query { a: A }`,
+			expected: "Type A cannot implement B because it would create a circular reference.\n" +
+				"Type B cannot implement A because it would create a circular reference.",
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.BuildSchema(test.sdl)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("expected error %q, got %v", test.expected, err)
+		}
+	}
+}
+
+func TestInterfaceInheritance_FieldTypesMayBeSubInterfaces(t *testing.T) {
+	_, err := graphql.BuildSchema(`
+		interface Node { id: ID! parent: Node }
+		interface Media implements Node { id: ID! parent: Media }
+		type Video implements Media & Node { id: ID! parent: Video }
+		type Query { media: Media }`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
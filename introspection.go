@@ -73,7 +73,7 @@ func init() {
 			"INTERFACE": &EnumValueConfig{
 				Value: TypeKindInterface,
 				Description: "Indicates this type is an interface. " +
-					"`fields` and `possibleTypes` are valid fields.",
+					"`fields`, `interfaces`, and `possibleTypes` are valid fields.",
 			},
 			"UNION": &EnumValueConfig{
 				Value: TypeKindUnion,
@@ -282,6 +282,23 @@ func init() {
 				},
 				ResolveSerial: true,
 			},
+			"isDeprecated": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return inputValueDeprecationReason(p.Source) != "", nil
+				},
+				ResolveSerial: true,
+			},
+			"deprecationReason": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if reason := inputValueDeprecationReason(p.Source); reason != "" {
+						return reason, nil
+					}
+					return nil, nil
+				},
+				ResolveSerial: true,
+			},
 		},
 	})
 
@@ -313,9 +330,16 @@ func init() {
 			},
 			"args": &Field{
 				Type: NewNonNull(NewList(NewNonNull(InputValueType))),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if field, ok := p.Source.(*FieldDefinition); ok {
-						return field.Args, nil
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return filterDeprecatedArgs(p.Info.Schema.visibleArgs(field), includeDeprecated), nil
 					}
 					return []interface{}{}, nil
 				},
@@ -369,66 +393,23 @@ func init() {
 				Type: NewNonNull(NewList(
 					NewNonNull(InputValueType),
 				)),
-				ResolveSerial: true,
-			},
-			// NOTE: the following three fields are deprecated and are no longer part
-			// of the GraphQL specification.
-			"onOperation": &Field{
-				DeprecationReason: "Use `locations`.",
-				Type:              NewNonNull(Boolean),
-				Resolve: func(p ResolveParams) (interface{}, error) {
-					if dir, ok := p.Source.(*Directive); ok {
-						res := false
-						for _, loc := range dir.Locations {
-							if loc == DirectiveLocationQuery ||
-								loc == DirectiveLocationMutation ||
-								loc == DirectiveLocationSubscription {
-								res = true
-								break
-							}
-						}
-						return res, nil
-					}
-					return false, nil
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
 				},
-				ResolveSerial: true,
-			},
-			"onFragment": &Field{
-				DeprecationReason: "Use `locations`.",
-				Type:              NewNonNull(Boolean),
 				Resolve: func(p ResolveParams) (interface{}, error) {
-					if dir, ok := p.Source.(*Directive); ok {
-						res := false
-						for _, loc := range dir.Locations {
-							if loc == DirectiveLocationFragmentSpread ||
-								loc == DirectiveLocationInlineFragment ||
-								loc == DirectiveLocationFragmentDefinition {
-								res = true
-								break
-							}
-						}
-						return res, nil
+					if directive, ok := p.Source.(*Directive); ok {
+						includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+						return filterDeprecatedArgs(directive.Args, includeDeprecated), nil
 					}
-					return false, nil
+					return []interface{}{}, nil
 				},
 				ResolveSerial: true,
 			},
-			"onField": &Field{
-				DeprecationReason: "Use `locations`.",
-				Type:              NewNonNull(Boolean),
-				Resolve: func(p ResolveParams) (interface{}, error) {
-					if dir, ok := p.Source.(*Directive); ok {
-						res := false
-						for _, loc := range dir.Locations {
-							if loc == DirectiveLocationField {
-								res = true
-								break
-							}
-						}
-						return res, nil
-					}
-					return false, nil
-				},
+			"isRepeatable": &Field{
+				Type:          NewNonNull(Boolean),
 				ResolveSerial: true,
 			},
 		},
@@ -440,6 +421,16 @@ func init() {
 			`It exposes all available types and directives on the server, as well as ` +
 			`the entry points for query, mutation, and subscription operations.`,
 		Fields: Fields{
+			"description": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if schema, ok := p.Source.(Schema); ok && schema.Description() != "" {
+						return schema.Description(), nil
+					}
+					return nil, nil
+				},
+				ResolveSerial: true,
+			},
 			"types": &Field{
 				Description: "A list of all types supported by this server.",
 				Type: NewNonNull(NewList(
@@ -448,7 +439,12 @@ func init() {
 				Resolve: func(p ResolveParams) (interface{}, error) {
 					if schema, ok := p.Source.(Schema); ok {
 						results := []Type{}
-						for _, ttype := range schema.TypeMap() {
+						typeMap := schema.TypeMap()
+						for _, name := range sortedTypeNames(typeMap) {
+							ttype := typeMap[name]
+							if !schema.isTypeVisible(ttype) {
+								continue
+							}
 							results = append(results, ttype)
 						}
 						return results, nil
@@ -560,31 +556,12 @@ func init() {
 				if ttype == nil {
 					return nil, nil
 				}
-				fields := []*FieldDefinition{}
-				var fieldNames sort.StringSlice
-				for name, field := range ttype.Fields() {
-					if !includeDeprecated && field.DeprecationReason != "" {
-						continue
-					}
-					fieldNames = append(fieldNames, name)
-				}
-				sort.Sort(fieldNames)
-				for _, name := range fieldNames {
-					fields = append(fields, ttype.Fields()[name])
-				}
-				return fields, nil
+				return filterDeprecatedFields(p.Info.Schema.visibleFields(ttype), includeDeprecated), nil
 			case *Interface:
 				if ttype == nil {
 					return nil, nil
 				}
-				fields := []*FieldDefinition{}
-				for _, field := range ttype.Fields() {
-					if !includeDeprecated && field.DeprecationReason != "" {
-						continue
-					}
-					fields = append(fields, field)
-				}
-				return fields, nil
+				return filterDeprecatedFields(p.Info.Schema.visibleFields(ttype), includeDeprecated), nil
 			}
 			return nil, nil
 		},
@@ -595,7 +572,9 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			switch ttype := p.Source.(type) {
 			case *Object:
-				return ttype.Interfaces(), nil
+				return p.Info.Schema.visibleInterfaces(ttype.Interfaces()), nil
+			case *Interface:
+				return p.Info.Schema.visibleInterfaces(ttype.Interfaces()), nil
 			}
 			return nil, nil
 		},
@@ -606,9 +585,9 @@ func init() {
 		Resolve: func(p ResolveParams) (interface{}, error) {
 			switch ttype := p.Source.(type) {
 			case *Interface:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return sortedObjects(p.Info.Schema.visibleObjects(p.Info.Schema.PossibleTypes(ttype))), nil
 			case *Union:
-				return p.Info.Schema.PossibleTypes(ttype), nil
+				return sortedObjects(p.Info.Schema.visibleObjects(p.Info.Schema.PossibleTypes(ttype))), nil
 			}
 			return nil, nil
 		},
@@ -626,12 +605,14 @@ func init() {
 			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			switch ttype := p.Source.(type) {
 			case *Enum:
-				if includeDeprecated {
-					return ttype.Values(), nil
-				}
 				values := []*EnumValueDefinition{}
-				for _, value := range ttype.Values() {
-					if value.DeprecationReason != "" {
+				valuesByName := ttype.getNameLookup()
+				for _, name := range sortedEnumValueNames(ttype) {
+					value := valuesByName[name]
+					if !includeDeprecated && value.DeprecationReason != "" {
+						continue
+					}
+					if !p.Info.Schema.isEnumValueVisible(ttype, value) {
 						continue
 					}
 					values = append(values, value)
@@ -644,11 +625,26 @@ func init() {
 	})
 	TypeType.AddFieldConfig("inputFields", &Field{
 		Type: NewList(NewNonNull(InputValueType)),
+		Args: FieldConfigArgument{
+			"includeDeprecated": &ArgumentConfig{
+				Type:         Boolean,
+				DefaultValue: false,
+			},
+		},
 		Resolve: func(p ResolveParams) (interface{}, error) {
+			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			switch ttype := p.Source.(type) {
 			case *InputObject:
 				fields := []*InputObjectField{}
-				for _, field := range ttype.Fields() {
+				inputFields := ttype.Fields()
+				for _, name := range sortedInputFieldNames(inputFields) {
+					field := inputFields[name]
+					if !includeDeprecated && field.DeprecationReason != "" {
+						continue
+					}
+					if !p.Info.Schema.isInputFieldVisible(ttype, field) {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				return fields, nil
@@ -661,6 +657,26 @@ func init() {
 		Type:          TypeType,
 		ResolveSerial: true,
 	})
+	TypeType.AddFieldConfig("isOneOf", &Field{
+		Type: Boolean,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			if inputObject, ok := p.Source.(*InputObject); ok {
+				return inputObject.IsOneOf(), nil
+			}
+			return nil, nil
+		},
+		ResolveSerial: true,
+	})
+	TypeType.AddFieldConfig("specifiedByURL", &Field{
+		Type: String,
+		Resolve: func(p ResolveParams) (interface{}, error) {
+			if scalar, ok := p.Source.(*Scalar); ok && scalar.SpecifiedByURL() != "" {
+				return scalar.SpecifiedByURL(), nil
+			}
+			return nil, nil
+		},
+		ResolveSerial: true,
+	})
 
 	// Note that these are FieldDefinition and not FieldConfig,
 	// so the format for args is different.
@@ -689,7 +705,7 @@ func init() {
 			if !ok {
 				return nil, nil
 			}
-			return p.Info.Schema.Type(name), nil
+			return p.Info.Schema.visibleType(name), nil
 		},
 		ResolveSerial: true,
 	}
@@ -707,6 +723,52 @@ func init() {
 
 }
 
+// filterDeprecatedArgs returns args without the deprecated ones, unless includeDeprecated is set.
+func filterDeprecatedArgs(args []*Argument, includeDeprecated bool) []*Argument {
+	if includeDeprecated {
+		return args
+	}
+	filtered := []*Argument{}
+	for _, arg := range args {
+		if arg.DeprecationReason == "" {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered
+}
+
+// filterDeprecatedFields returns the fields sorted by name, without the
+// deprecated ones unless includeDeprecated is set.
+func filterDeprecatedFields(fields FieldDefinitionMap, includeDeprecated bool) []*FieldDefinition {
+	filtered := []*FieldDefinition{}
+	for _, name := range sortedFieldNames(fields) {
+		if field := fields[name]; includeDeprecated || field.DeprecationReason == "" {
+			filtered = append(filtered, field)
+		}
+	}
+	return filtered
+}
+
+// sortedObjects returns a copy of objects sorted by name.
+func sortedObjects(objects []*Object) []*Object {
+	sorted := append([]*Object{}, objects...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	return sorted
+}
+
+// inputValueDeprecationReason returns the deprecation reason of an argument or input field.
+func inputValueDeprecationReason(inputValue interface{}) string {
+	switch inputValue := inputValue.(type) {
+	case *Argument:
+		return inputValue.DeprecationReason
+	case *InputObjectField:
+		return inputValue.DeprecationReason
+	}
+	return ""
+}
+
 // Produces a GraphQL Value AST given a Golang value.
 //
 // Optionally, a GraphQL type may be provided, which will be used to
@@ -766,8 +828,26 @@ func astFromValue(value interface{}, ttype Type) ast.Value {
 		return val
 	}
 
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to Value
+	if ttype, ok := ttype.(*InputObject); ok && valueVal.Type().Kind() == reflect.Map && valueVal.Type().Key().Kind() == reflect.String {
+		fields := ttype.Fields()
+		objectFields := []*ast.ObjectField{}
+		for _, fieldName := range sortedInputFieldNames(fields) {
+			fieldValue := valueVal.MapIndex(reflect.ValueOf(fieldName).Convert(valueVal.Type().Key()))
+			if !fieldValue.IsValid() {
+				continue
+			}
+			fieldAST := astFromValue(fieldValue.Interface(), fields[fieldName].Type)
+			if fieldAST == nil {
+				continue
+			}
+			objectFields = append(objectFields, ast.NewObjectField(&ast.ObjectField{
+				Name:  ast.NewName(&ast.Name{Value: fieldName}),
+				Value: fieldAST,
+			}))
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{
+			Fields: objectFields,
+		})
 	}
 
 	if value, ok := value.(bool); ok {
@@ -0,0 +1,104 @@
+package graphql
+
+// IntrospectionQuery queries the full introspection of a schema, as described
+// by the October 2021 GraphQL specification along with the isOneOf field of
+// the oneOf input objects.
+const IntrospectionQuery = `
+  query IntrospectionQuery {
+    __schema {
+      description
+      queryType { name }
+      mutationType { name }
+      subscriptionType { name }
+      types {
+        ...FullType
+      }
+      directives {
+        name
+        description
+        isRepeatable
+        locations
+        args(includeDeprecated: true) {
+          ...InputValue
+        }
+      }
+    }
+  }
+
+  fragment FullType on __Type {
+    kind
+    name
+    description
+    specifiedByURL
+    isOneOf
+    fields(includeDeprecated: true) {
+      name
+      description
+      args(includeDeprecated: true) {
+        ...InputValue
+      }
+      type {
+        ...TypeRef
+      }
+      isDeprecated
+      deprecationReason
+    }
+    inputFields(includeDeprecated: true) {
+      ...InputValue
+    }
+    interfaces {
+      ...TypeRef
+    }
+    enumValues(includeDeprecated: true) {
+      name
+      description
+      isDeprecated
+      deprecationReason
+    }
+    possibleTypes {
+      ...TypeRef
+    }
+  }
+
+  fragment InputValue on __InputValue {
+    name
+    description
+    type { ...TypeRef }
+    defaultValue
+    isDeprecated
+    deprecationReason
+  }
+
+  fragment TypeRef on __Type {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+                ofType {
+                  kind
+                  name
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+`
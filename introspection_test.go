@@ -30,6 +30,7 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 	}
 	expectedDataSubSet := map[string]interface{}{
 		"__schema": map[string]interface{}{
+			"description":      nil,
 			"mutationType":     nil,
 			"subscriptionType": nil,
 			"queryType": map[string]interface{}{
@@ -670,51 +671,6 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							"isDeprecated":      false,
 							"deprecationReason": "",
 						},
-						map[string]interface{}{
-							"name": "onOperation",
-							"args": []interface{}{},
-							"type": map[string]interface{}{
-								"kind": "NON_NULL",
-								"name": nil,
-								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
-								},
-							},
-							"isDeprecated":      true,
-							"deprecationReason": "Use `locations`.",
-						},
-						map[string]interface{}{
-							"name": "onFragment",
-							"args": []interface{}{},
-							"type": map[string]interface{}{
-								"kind": "NON_NULL",
-								"name": nil,
-								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
-								},
-							},
-							"isDeprecated":      true,
-							"deprecationReason": "Use `locations`.",
-						},
-						map[string]interface{}{
-							"name": "onField",
-							"args": []interface{}{},
-							"type": map[string]interface{}{
-								"kind": "NON_NULL",
-								"name": nil,
-								"ofType": map[string]interface{}{
-									"kind":   "SCALAR",
-									"name":   "Boolean",
-									"ofType": nil,
-								},
-							},
-							"isDeprecated":      true,
-							"deprecationReason": "Use `locations`.",
-						},
 					},
 					"inputFields":   nil,
 					"interfaces":    []interface{}{},
@@ -790,10 +746,7 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							},
 						},
 					},
-					// deprecated, but included for coverage till removed
-					"onOperation": false,
-					"onFragment":  true,
-					"onField":     true,
+					"isRepeatable": false,
 				},
 				map[string]interface{}{
 					"name": "skip",
@@ -817,10 +770,7 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							},
 						},
 					},
-					// deprecated, but included for coverage till removed
-					"onOperation": false,
-					"onFragment":  true,
-					"onField":     true,
+					"isRepeatable": false,
 				},
 			},
 		},
@@ -1276,6 +1226,162 @@ func TestIntrospection_RespectsTheIncludeDeprecatedParameterForEnumValues(t *tes
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestIntrospection_IdentifiesDeprecatedArgsAndInputFields(t *testing.T) {
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"term": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"query": &graphql.InputObjectFieldConfig{
+				Type:              graphql.String,
+				DeprecationReason: "Use term.",
+			},
+		},
+	})
+	testDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "cached",
+		Locations: []string{graphql.DirectiveLocationField},
+		Args: graphql.FieldConfigArgument{
+			"seconds": &graphql.ArgumentConfig{
+				Type:              graphql.Int,
+				DeprecationReason: "Use ttl.",
+			},
+		},
+	})
+	testType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.Fields{
+			"search": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{
+						Type: filterType,
+					},
+					"text": &graphql.ArgumentConfig{
+						Type:              graphql.String,
+						DeprecationReason: "Use filter.",
+					},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:      testType,
+		Directives: []*graphql.Directive{testDirective},
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        testType: __type(name: "TestType") {
+          fields {
+            omittedArgs: args { name }
+            args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+          }
+        }
+        filter: __type(name: "Filter") {
+          omittedFields: inputFields { name }
+          inputFields(includeDeprecated: true) {
+            name
+            isDeprecated
+            deprecationReason
+          }
+        }
+        __schema {
+          directives {
+            omittedArgs: args { name }
+            args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+          }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"testType": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"omittedArgs": []interface{}{
+							map[string]interface{}{"name": "filter"},
+						},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":              "filter",
+								"isDeprecated":      false,
+								"deprecationReason": nil,
+							},
+							map[string]interface{}{
+								"name":              "text",
+								"isDeprecated":      true,
+								"deprecationReason": "Use filter.",
+							},
+						},
+					},
+				},
+			},
+			"filter": map[string]interface{}{
+				"omittedFields": []interface{}{
+					map[string]interface{}{"name": "term"},
+				},
+				"inputFields": []interface{}{
+					map[string]interface{}{
+						"name":              "query",
+						"isDeprecated":      true,
+						"deprecationReason": "Use term.",
+					},
+					map[string]interface{}{
+						"name":              "term",
+						"isDeprecated":      false,
+						"deprecationReason": nil,
+					},
+				},
+			},
+			"__schema": map[string]interface{}{
+				"directives": []interface{}{
+					map[string]interface{}{
+						"omittedArgs": []interface{}{},
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":              "seconds",
+								"isDeprecated":      true,
+								"deprecationReason": "Use ttl.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	data := result.Data.(map[string]interface{})
+	omitted := [][]interface{}{
+		data["testType"].(map[string]interface{})["fields"].([]interface{})[0].(map[string]interface{})["omittedArgs"].([]interface{}),
+		data["filter"].(map[string]interface{})["omittedFields"].([]interface{}),
+		data["__schema"].(map[string]interface{})["directives"].([]interface{})[0].(map[string]interface{})["omittedArgs"].([]interface{}),
+	}
+	for i, expectedLen := range []int{1, 1, 0} {
+		if len(omitted[i]) != expectedLen {
+			t.Fatalf("Expected deprecated input values to be omitted by default, got %v", omitted[i])
+		}
+	}
+}
+
 func TestIntrospection_FailsAsExpectedOnThe__TypeRootFieldWithoutAnArg(t *testing.T) {
 
 	testType := graphql.NewObject(graphql.ObjectConfig{
@@ -1435,7 +1541,7 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 					},
 					map[string]interface{}{
 						"name":        "INTERFACE",
-						"description": "Indicates this type is an interface. `fields` and `possibleTypes` are valid fields.",
+						"description": "Indicates this type is an interface. `fields`, `interfaces`, and `possibleTypes` are valid fields.",
 					},
 					map[string]interface{}{
 						"name":        "UNION",
@@ -1469,3 +1575,87 @@ func TestIntrospection_ExposesDescriptionsOnEnums(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestIntrospection_SortsFieldsAndValuesByName(t *testing.T) {
+	schema := mustBuildSchema(t, `
+		interface Named { zeta: String alpha: String mid: String }
+		type Beta implements Named { zeta: String alpha: String mid: String }
+		type Alpha implements Named { zeta: String alpha: String mid: String }
+		union Either = Beta | Alpha
+		enum Color { RED GREEN BLUE }
+		input Filter { zeta: String alpha: String mid: String }
+		type Query { named: Named either: Either color(filter: Filter): Color }
+	`)
+	result := g(t, graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			named: __type(name: "Named") { fields { name } possibleTypes { name } }
+			either: __type(name: "Either") { possibleTypes { name } }
+			color: __type(name: "Color") { enumValues { name } }
+			filter: __type(name: "Filter") { inputFields { name } }
+		}`,
+	})
+	names := func(names ...string) []interface{} {
+		list := []interface{}{}
+		for _, name := range names {
+			list = append(list, map[string]interface{}{"name": name})
+		}
+		return list
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"named": map[string]interface{}{
+				"fields":        names("alpha", "mid", "zeta"),
+				"possibleTypes": names("Alpha", "Beta"),
+			},
+			"either": map[string]interface{}{
+				"possibleTypes": names("Alpha", "Beta"),
+			},
+			"color": map[string]interface{}{
+				"enumValues": names("BLUE", "GREEN", "RED"),
+			},
+			"filter": map[string]interface{}{
+				"inputFields": names("alpha", "mid", "zeta"),
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_ExposesSchemaDescription(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Description: "The public API.",
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __schema { description directives { name isRepeatable } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__schema": map[string]interface{}{
+				"description": "The public API.",
+				"directives": []interface{}{
+					map[string]interface{}{"name": "include", "isRepeatable": false},
+					map[string]interface{}{"name": "skip", "isRepeatable": false},
+					map[string]interface{}{"name": "deprecated", "isRepeatable": false},
+					map[string]interface{}{"name": "specifiedBy", "isRepeatable": false},
+					map[string]interface{}{"name": "oneOf", "isRepeatable": false},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -42,6 +42,7 @@ var _ TypeSystemDefinition = (*DirectiveDefinition)(nil)
 type SchemaDefinition struct {
 	Kind           string
 	Loc            *Location
+	Description    *StringValue
 	Directives     []*Directive
 	OperationTypes []*OperationTypeDefinition
 }
@@ -53,6 +54,7 @@ func NewSchemaDefinition(def *SchemaDefinition) *SchemaDefinition {
 	return &SchemaDefinition{
 		Kind:           kinds.SchemaDefinition,
 		Loc:            def.Loc,
+		Description:    def.Description,
 		Directives:     def.Directives,
 		OperationTypes: def.OperationTypes,
 	}
@@ -292,6 +294,7 @@ type InterfaceDefinition struct {
 	Loc         *Location
 	Name        *Name
 	Description *StringValue
+	Interfaces  []*Named
 	Directives  []*Directive
 	Fields      []*FieldDefinition
 }
@@ -305,6 +308,7 @@ func NewInterfaceDefinition(def *InterfaceDefinition) *InterfaceDefinition {
 		Loc:         def.Loc,
 		Name:        def.Name,
 		Description: def.Description,
+		Interfaces:  def.Interfaces,
 		Directives:  def.Directives,
 		Fields:      def.Fields,
 	}
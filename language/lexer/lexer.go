@@ -31,6 +31,7 @@ const (
 	FLOAT
 	STRING
 	BLOCK_STRING
+	AMP
 )
 
 // NAME -> keyword relationship
@@ -48,6 +49,7 @@ const (
 	INPUT        = "input"
 	EXTEND       = "extend"
 	DIRECTIVE    = "directive"
+	REPEATABLE   = "repeatable"
 )
 
 var TokenKind map[int]int
@@ -75,6 +77,7 @@ func init() {
 		TokenKind[FLOAT] = FLOAT
 		TokenKind[STRING] = STRING
 		TokenKind[BLOCK_STRING] = BLOCK_STRING
+		TokenKind[AMP] = AMP
 	}
 	tokenDescription = make(map[int]string)
 	{
@@ -97,6 +100,7 @@ func init() {
 		tokenDescription[TokenKind[FLOAT]] = "Float"
 		tokenDescription[TokenKind[STRING]] = "String"
 		tokenDescription[TokenKind[BLOCK_STRING]] = "BlockString"
+		tokenDescription[TokenKind[AMP]] = "&"
 	}
 }
 
@@ -558,6 +562,9 @@ func readToken(s *source.Source, fromPosition int) (Token, error) {
 	// {
 	case '{':
 		return makeToken(TokenKind[BRACE_L], position, position+1, ""), nil
+	// &
+	case '&':
+		return makeToken(TokenKind[AMP], position, position+1, ""), nil
 	// |
 	case '|':
 		return makeToken(TokenKind[PIPE], position, position+1, ""), nil
@@ -79,8 +79,9 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	return doc, nil
 }
 
-// TODO: test and expose parseValue as a public
-func parseValue(p ParseParams) (ast.Value, error) {
+// ParseValue parses a single GraphQL value literal such as `[1, 2]` or
+// `{lat: 1.5}`, as found in default values returned by introspection.
+func ParseValue(p ParseParams) (ast.Value, error) {
 	var value ast.Value
 	var sourceObj *source.Source
 	switch p.Source.(type) {
@@ -876,13 +877,17 @@ func parseTypeSystemDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * SchemaDefinition : schema Directives? { OperationTypeDefinition+ }
+ * SchemaDefinition : Description? schema Directives? { OperationTypeDefinition+ }
  *
  * OperationTypeDefinition : OperationType : NamedType
  */
 func parseSchemaDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "schema")
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "schema")
 	if err != nil {
 		return nil, err
 	}
@@ -905,6 +910,7 @@ func parseSchemaDefinition(parser *Parser) (ast.Node, error) {
 		}
 	}
 	return ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		Description:    description,
 		OperationTypes: operationTypes,
 		Directives:     directives,
 		Loc:            loc(parser, start),
@@ -1013,7 +1019,12 @@ func parseObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * ImplementsInterfaces : implements NamedType+
+ * ImplementsInterfaces :
+ *   - implements `&`? NamedType
+ *   - ImplementsInterfaces & NamedType
+ *
+ * The legacy form, which separates the names by whitespace or commas, is
+ * also accepted.
  */
 func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 	types := []*ast.Named{}
@@ -1021,12 +1032,20 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
+		if _, err := skip(parser, lexer.TokenKind[lexer.AMP]); err != nil {
+			return nil, err
+		}
 		for {
 			ttype, err := parseNamed(parser)
 			if err != nil {
 				return types, err
 			}
 			types = append(types, ttype)
+			if skp, err := skip(parser, lexer.TokenKind[lexer.AMP]); err != nil {
+				return types, err
+			} else if skp {
+				continue
+			}
 			if !peek(parser, lexer.TokenKind[lexer.NAME]) {
 				break
 			}
@@ -1150,7 +1169,7 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 /**
  * InterfaceTypeDefinition :
  *   Description?
- *   interface Name Directives? { FieldDefinition+ }
+ *   interface Name ImplementsInterfaces? Directives? { FieldDefinition+ }
  */
 func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1166,6 +1185,10 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
 	directives, err := parseDirectives(parser)
 	if err != nil {
 		return nil, err
@@ -1186,6 +1209,7 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
 		Name:        name,
 		Description: description,
+		Interfaces:  interfaces,
 		Directives:  directives,
 		Loc:         loc(parser, start),
 		Fields:      fields,
@@ -1388,7 +1412,7 @@ func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 
 /**
  * DirectiveDefinition :
- *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
+ *   - directive @ Name ArgumentsDefinition? repeatable? on DirectiveLocations
  */
 func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	var (
@@ -1396,6 +1420,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		description *ast.StringValue
 		name        *ast.Name
 		args        []*ast.InputValueDefinition
+		repeatable  bool
 		locations   []*ast.Name
 	)
 	start := parser.Token.Start
@@ -1414,6 +1439,12 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if args, err = parseArgumentDefs(parser); err != nil {
 		return nil, err
 	}
+	if peek(parser, lexer.TokenKind[lexer.NAME]) && parser.Token.Value == lexer.REPEATABLE {
+		if err = advance(parser); err != nil {
+			return nil, err
+		}
+		repeatable = true
+	}
 	if _, err = expectKeyWord(parser, "on"); err != nil {
 		return nil, err
 	}
@@ -1426,6 +1457,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 		Name:        name,
 		Description: description,
 		Arguments:   args,
+		Repeatable:  repeatable,
 		Locations:   locations,
 	}), nil
 }
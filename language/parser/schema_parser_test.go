@@ -238,6 +238,75 @@ func TestSchemaParser_SimpleTypeInheritingMultipleInterfaces(t *testing.T) {
 	}
 }
 
+func TestSchemaParser_SimpleTypeInheritingMultipleInterfacesWithAmpersand(t *testing.T) {
+	body := `type Hello implements & Wo & rld { }`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 36),
+		Definitions: []ast.Node{
+			ast.NewObjectDefinition(&ast.ObjectDefinition{
+				Loc: testLoc(0, 36),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(5, 10),
+				}),
+				Directives: []*ast.Directive{},
+				Interfaces: []*ast.Named{
+					ast.NewNamed(&ast.Named{
+						Name: ast.NewName(&ast.Name{
+							Value: "Wo",
+							Loc:   testLoc(24, 26),
+						}),
+						Loc: testLoc(24, 26),
+					}),
+					ast.NewNamed(&ast.Named{
+						Name: ast.NewName(&ast.Name{
+							Value: "rld",
+							Loc:   testLoc(29, 32),
+						}),
+						Loc: testLoc(29, 32),
+					}),
+				},
+				Fields: []*ast.FieldDefinition{},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_SimpleInterfaceInheritingInterface(t *testing.T) {
+	body := `interface Hello implements World { }`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 36),
+		Definitions: []ast.Node{
+			ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+				Loc: testLoc(0, 36),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(10, 15),
+				}),
+				Directives: []*ast.Directive{},
+				Interfaces: []*ast.Named{
+					ast.NewNamed(&ast.Named{
+						Name: ast.NewName(&ast.Name{
+							Value: "World",
+							Loc:   testLoc(27, 32),
+						}),
+						Loc: testLoc(27, 32),
+					}),
+				},
+				Fields: []*ast.FieldDefinition{},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
 func TestSchemaParser_SingleValueEnum(t *testing.T) {
 	body := `enum Hello { WORLD }`
 	astDoc := parse(t, body)
@@ -323,6 +392,7 @@ interface Hello {
 					Value: "Hello",
 					Loc:   testLoc(11, 16),
 				}),
+				Interfaces: []*ast.Named{},
 				Directives: []*ast.Directive{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
@@ -791,3 +861,27 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_RepeatableDirectiveDefinition(t *testing.T) {
+	for body, repeatable := range map[string]bool{
+		`directive @tag(name: String!) repeatable on OBJECT | INTERFACE`: true,
+		`directive @tag(name: String!) on OBJECT | INTERFACE`:            false,
+	} {
+		astDoc := parse(t, body)
+		def, ok := astDoc.Definitions[0].(*ast.DirectiveDefinition)
+		if !ok {
+			t.Fatalf("expected a directive definition, got %T", astDoc.Definitions[0])
+		}
+		if def.Repeatable != repeatable {
+			t.Fatalf("%v: expected repeatable to be %v", body, repeatable)
+		}
+		if len(def.Locations) != 2 || def.Locations[1].Value != "INTERFACE" {
+			t.Fatalf("%v: unexpected locations %v", body, def.Locations)
+		}
+	}
+
+	_, err := Parse(ParseParams{Source: `directive @tag repeatable`})
+	if err == nil {
+		t.Fatalf("expected an error for missing locations")
+	}
+}
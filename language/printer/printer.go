@@ -535,7 +535,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			str := join([]string{
 				"type",
 				name,
-				wrap("implements ", join(interfaces, ", "), ""),
+				wrap("implements ", join(interfaces, " & "), ""),
 				join(directives, " "),
 				block(fields),
 			}, " ")
@@ -551,7 +551,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			str := join([]string{
 				"type",
 				name,
-				wrap("implements ", join(interfaces, ", "), ""),
+				wrap("implements ", join(interfaces, " & "), ""),
 				join(directives, " "),
 				block(fields),
 			}, " ")
@@ -622,6 +622,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case *ast.InterfaceDefinition:
 			name := fmt.Sprintf("%v", node.Name)
+			interfaces := toSliceString(node.Interfaces)
 			fields := node.Fields
 			directives := []string{}
 			for _, directive := range node.Directives {
@@ -630,12 +631,14 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			str := join([]string{
 				"interface",
 				name,
+				wrap("implements ", join(interfaces, " & "), ""),
 				join(directives, " "),
 				block(fields),
 			}, " ")
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
+			interfaces := toSliceString(getMapValue(node, "Interfaces"))
 			fields := getMapValue(node, "Fields")
 			directives := []string{}
 			for _, directive := range getMapSliceValue(node, "Directives") {
@@ -644,6 +647,7 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 			str := join([]string{
 				"interface",
 				name,
+				wrap("implements ", join(interfaces, " & "), ""),
 				join(directives, " "),
 				block(fields),
 			}, " ")
@@ -794,14 +798,22 @@ var printDocASTReducer = map[string]visitor.VisitFunc{
 		switch node := p.Node.(type) {
 		case *ast.DirectiveDefinition:
 			args := wrap("(", join(toSliceString(node.Arguments), ", "), ")")
-			str := fmt.Sprintf("directive @%v%v on %v", node.Name, args, join(toSliceString(node.Locations), " | "))
+			repeatable := ""
+			if node.Repeatable {
+				repeatable = " repeatable"
+			}
+			str := fmt.Sprintf("directive @%v%v%v on %v", node.Name, args, repeatable, join(toSliceString(node.Locations), " | "))
 			return visitor.ActionUpdate, str
 		case map[string]interface{}:
 			name := getMapValueString(node, "Name")
 			locations := toSliceString(getMapValue(node, "Locations"))
 			args := toSliceString(getMapValue(node, "Arguments"))
 			argsStr := wrap("(", join(args, ", "), ")")
-			str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
+			repeatable := ""
+			if getMapValueString(node, "Repeatable") == "true" {
+				repeatable = " repeatable"
+			}
+			str := fmt.Sprintf("directive @%v%v%v on %v", name, argsStr, repeatable, join(locations, " | "))
 			return visitor.ActionUpdate, str
 		}
 		return visitor.ActionNoChange, nil
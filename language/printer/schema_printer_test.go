@@ -124,3 +124,31 @@ directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
 	}
 }
+
+func TestSchemaPrinter_PrintsImplementedInterfaces(t *testing.T) {
+	astDoc := parse(t, `
+interface Media implements Node { id: ID }
+type Video implements Media, Node { id: ID }`)
+	results := printer.Print(astDoc)
+	expected := `interface Media implements Node {
+  id: ID
+}
+
+type Video implements Media & Node {
+  id: ID
+}
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestSchemaPrinter_PrintsRepeatableDirectives(t *testing.T) {
+	astDoc := parse(t, `directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION`)
+	results := printer.Print(astDoc)
+	expected := `directive @tag(name: String!) repeatable on OBJECT | FIELD_DEFINITION
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
@@ -114,6 +114,7 @@ var QueryDocumentKeys = KeyMap{
 	},
 	"InterfaceDefinition": []string{
 		"Name",
+		"Interfaces",
 		"Directives",
 		"Fields",
 	},
@@ -754,6 +755,10 @@ func VisitWithTypeInfo(ttypeInfo typeInfo.TypeInfoI, visitorOpts *VisitorOptions
 				fn := GetVisitFn(visitorOpts, node.GetKind(), false)
 				if fn != nil {
 					action, result := fn(p)
+					// Skipped nodes are never left, so leave them now.
+					if action == ActionSkip {
+						ttypeInfo.Leave(node)
+					}
 					if action == ActionUpdate {
 						ttypeInfo.Leave(node)
 						if isNode(result) {
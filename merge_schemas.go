@@ -0,0 +1,451 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// MergeConflictResolution is the way MergeSchemas resolves a conflict.
+type MergeConflictResolution int
+
+const (
+	// MergeFirstWins keeps the type, or root field, of the first schema
+	// defining it. The types of the other schemas refer to that type instead
+	// of their own.
+	MergeFirstWins MergeConflictResolution = iota
+	// MergeRename renames the conflicting type, or root field, of the later
+	// schema with MergeOptions.Rename.
+	MergeRename
+	// MergeError fails the merge, reporting every conflict.
+	MergeError
+)
+
+// MergeConflict is a type, or root field, defined differently by a schema
+// and by a schema merged before it.
+type MergeConflict struct {
+	// TypeName is the name of the conflicting type, or of the root type of
+	// the conflicting root field.
+	TypeName string
+	// FieldName is the name of the conflicting root field, empty for
+	// conflicting types.
+	FieldName string
+	// Schema is the index of the later schema.
+	Schema int
+	// NewName is the name given to the type, or root field, of the later
+	// schema when it is renamed.
+	NewName string
+}
+
+// MergeOptions configure MergeSchemas.
+type MergeOptions struct {
+	// OnConflict is the resolution of conflicts, MergeFirstWins by default.
+	OnConflict MergeConflictResolution
+
+	// Rename returns the new name of the type, or root field, of the later
+	// schema of a conflict resolved with MergeRename. By default, the index
+	// of the schema is appended to the name, as in "Product_1".
+	Rename func(conflict MergeConflict) string
+
+	// Report is called with each conflict resolved by MergeFirstWins or
+	// MergeRename.
+	Report func(conflict MergeConflict)
+}
+
+// MergeSchemas merges schemas into one.
+//
+// The root types of the schemas are combined into root types having the
+// fields of all of them, named after the first root type of each operation.
+// The other types of the schemas are copied into the merged schema, with
+// conflicts between the types of different schemas sharing a name resolved
+// as configured by options. Types shared by several schemas are not in
+// conflict.
+//
+// The fields of the merged schema are resolved by the resolvers of the
+// schemas they come from, and the objects their abstract types resolve to are
+// mapped to the objects of the merged schema.
+func MergeSchemas(schemas []Schema, options MergeOptions) (Schema, error) {
+	if options.Rename == nil {
+		options.Rename = func(conflict MergeConflict) string {
+			name := conflict.TypeName
+			if conflict.FieldName != "" {
+				name = conflict.FieldName
+			}
+			return fmt.Sprintf("%v_%v", name, conflict.Schema)
+		}
+	}
+	m := &schemaMerger{
+		options:   options,
+		names:     make([]map[string]string, len(schemas)),
+		originals: make([]map[string]string, len(schemas)),
+		owners:    map[string]Type{},
+		types:     map[string]Type{},
+		errs:      SchemaErrors{},
+	}
+	roots := [3][]*Object{}
+	for _, schema := range schemas {
+		roots[0] = append(roots[0], schema.QueryType())
+		roots[1] = append(roots[1], schema.MutationType())
+		roots[2] = append(roots[2], schema.SubscriptionType())
+	}
+	rootNames := [3]string{}
+	for operation, objects := range roots {
+		for _, object := range objects {
+			if object != nil && rootNames[operation] == "" {
+				rootNames[operation] = object.Name()
+				m.owners[object.Name()] = object
+			}
+		}
+	}
+
+	order := []string{}
+	for i, schema := range schemas {
+		m.names[i] = map[string]string{}
+		m.originals[i] = map[string]string{}
+		for operation := range roots {
+			if object := roots[operation][i]; object != nil {
+				m.names[i][object.Name()] = rootNames[operation]
+			}
+		}
+		typeMap := schema.TypeMap()
+		for _, name := range sortedTypeNames(typeMap) {
+			ttype := typeMap[name]
+			if _, ok := m.names[i][name]; ok || isIntrospectionType(ttype) || isSpecifiedScalar(ttype) {
+				continue
+			}
+			owner, claimed := m.owners[name]
+			switch {
+			case !claimed:
+				m.owners[name] = ttype
+				m.names[i][name] = name
+				order = append(order, name)
+			case owner == ttype:
+				m.names[i][name] = name
+			default:
+				newName, ok := m.resolve(MergeConflict{TypeName: name, Schema: i}, func(name string) bool {
+					_, taken := m.owners[name]
+					return taken
+				})
+				if !ok {
+					continue
+				}
+				m.names[i][name] = newName
+				if newName != name {
+					m.owners[newName] = ttype
+					order = append(order, newName)
+				}
+			}
+		}
+		for name, newName := range m.names[i] {
+			if newName != name {
+				m.originals[i][newName] = name
+			}
+		}
+	}
+
+	// Unions list their member objects eagerly, so they are copied last.
+	sources := map[string]int{}
+	for i := range schemas {
+		for name, newName := range m.names[i] {
+			if _, ok := sources[newName]; !ok && m.owners[newName] == schemas[i].Type(name) {
+				sources[newName] = i
+			}
+		}
+	}
+	for _, unions := range []bool{false, true} {
+		for _, name := range order {
+			if _, isUnion := m.owners[name].(*Union); isUnion == unions {
+				m.types[name] = m.copyType(sources[name], m.owners[name], name)
+			}
+		}
+	}
+
+	config := SchemaConfig{}
+	for operation, objects := range roots {
+		if rootNames[operation] == "" {
+			continue
+		}
+		root := m.mergeRoots(rootNames[operation], objects)
+		m.types[root.Name()] = root
+		switch operation {
+		case 0:
+			config.Query = root
+		case 1:
+			config.Mutation = root
+		case 2:
+			config.Subscription = root
+		}
+	}
+	for _, name := range order {
+		config.Types = append(config.Types, m.types[name])
+	}
+	for _, schema := range schemas {
+		for _, directive := range schema.Directives() {
+			if !containsDirective(config.Directives, directive) {
+				config.Directives = append(config.Directives, directive)
+			}
+		}
+	}
+
+	if err := m.errs.err(); err != nil {
+		return Schema{}, err
+	}
+	return NewSchema(config)
+}
+
+// schemaMerger is the state of MergeSchemas.
+type schemaMerger struct {
+	options MergeOptions
+	// names[i] maps the names of the types of the i-th schema to the names
+	// of the types of the merged schema they become.
+	names []map[string]string
+	// originals[i] maps the names of the types and root fields of the i-th
+	// schema which were renamed back to their names, as exposed to resolvers
+	// by ResolveInfo.OriginalNames.
+	originals []map[string]string
+	// owners are the types of the schemas the types of the merged schema are
+	// copied from, by name in the merged schema.
+	owners map[string]Type
+	types  map[string]Type
+	errs   SchemaErrors
+}
+
+// resolve resolves a conflict, returning the name the type, or root field, of
+// the later schema takes in the merged schema, and whether it is kept. taken
+// reports whether a name is already used by another type, or root field.
+func (m *schemaMerger) resolve(conflict MergeConflict, taken func(name string) bool) (string, bool) {
+	name, coordinate := conflict.TypeName, conflict.TypeName
+	if conflict.FieldName != "" {
+		name, coordinate = conflict.FieldName, conflict.TypeName+"."+conflict.FieldName
+	}
+	switch m.options.OnConflict {
+	case MergeError:
+		if conflict.FieldName != "" {
+			m.errs.addf(coordinate, `Field "%v" of schema %v conflicts with a field of a schema merged before.`, coordinate, conflict.Schema)
+		} else {
+			m.errs.addf(coordinate, `Type "%v" of schema %v conflicts with a type of a schema merged before.`, name, conflict.Schema)
+		}
+		return "", false
+	case MergeRename:
+		conflict.NewName = m.options.Rename(conflict)
+		if taken(conflict.NewName) {
+			if conflict.FieldName != "" {
+				m.errs.addf(coordinate, `Field "%v" of schema %v cannot be renamed "%v": the name is taken.`, coordinate, conflict.Schema, conflict.NewName)
+			} else {
+				m.errs.addf(coordinate, `Type "%v" of schema %v cannot be renamed "%v": the name is taken.`, name, conflict.Schema, conflict.NewName)
+			}
+			return "", false
+		}
+		name = conflict.NewName
+	}
+	if m.options.Report != nil {
+		m.options.Report(conflict)
+	}
+	return name, true
+}
+
+// typeOf returns the type of the merged schema a type of the i-th schema
+// becomes.
+func (m *schemaMerger) typeOf(i int, ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return NewList(m.typeOf(i, ttype.OfType))
+	case *NonNull:
+		return NewNonNull(m.typeOf(i, ttype.OfType))
+	}
+	if name, ok := m.names[i][ttype.Name()]; ok {
+		return m.types[name]
+	}
+	return ttype
+}
+
+func (m *schemaMerger) objectOf(i int, object *Object) *Object {
+	if object == nil {
+		return nil
+	}
+	merged, _ := m.typeOf(i, object).(*Object)
+	return merged
+}
+
+// copyType copies a type of the i-th schema into the merged schema.
+func (m *schemaMerger) copyType(i int, ttype Type, name string) Type {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		if ttype.Name() == name {
+			return ttype
+		}
+		config := ttype.scalarConfig
+		config.Name = name
+		return NewScalar(config)
+	case *Object:
+		config := ttype.typeConfig
+		config.Name = name
+		config.IsTypeOf = ttype.IsTypeOf
+		config.ResolveReference = ttype.ResolveReference
+		config.Interfaces = m.interfacesThunk(i, ttype.Interfaces)
+		config.Fields = FieldsThunk(func() Fields {
+			return m.fields(i, ttype.Fields())
+		})
+		return NewObject(config)
+	case *Interface:
+		config := ttype.typeConfig
+		config.Name = name
+		config.Interfaces = m.interfacesThunk(i, ttype.Interfaces)
+		config.Fields = FieldsThunk(func() Fields {
+			return m.fields(i, ttype.Fields())
+		})
+		config.ResolveType = m.resolveType(i, ttype.ResolveType)
+		return NewInterface(config)
+	case *Union:
+		config := ttype.typeConfig
+		config.Name = name
+		config.Types = []*Object{}
+		for _, object := range ttype.Types() {
+			config.Types = append(config.Types, m.objectOf(i, object))
+		}
+		config.ResolveType = m.resolveType(i, ttype.ResolveType)
+		return NewUnion(config)
+	case *Enum:
+		config := ttype.enumConfig
+		config.Name = name
+		return NewEnum(config)
+	case *InputObject:
+		config := ttype.typeConfig
+		config.Name = name
+		config.Fields = InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			fields := InputObjectConfigFieldMap{}
+			for fieldName, field := range ttype.Fields() {
+				fields[fieldName] = &InputObjectFieldConfig{
+					Type:              m.typeOf(i, field.Type),
+					DefaultValue:      field.DefaultValue,
+					Description:       field.PrivateDescription,
+					DeprecationReason: field.DeprecationReason,
+					AppliedDirectives: field.AppliedDirectives,
+				}
+			}
+			return fields
+		})
+		return NewInputObject(config)
+	}
+	return ttype
+}
+
+func (m *schemaMerger) interfacesThunk(i int, interfaces func() []*Interface) InterfacesThunk {
+	return func() []*Interface {
+		merged := []*Interface{}
+		for _, iface := range interfaces() {
+			if iface, ok := m.typeOf(i, iface).(*Interface); ok {
+				merged = append(merged, iface)
+			}
+		}
+		return merged
+	}
+}
+
+// resolveType maps the objects resolved by the type resolver of an abstract
+// type of the i-th schema to the objects of the merged schema.
+func (m *schemaMerger) resolveType(i int, resolveType ResolveTypeFn) ResolveTypeFn {
+	if resolveType == nil {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		return m.objectOf(i, resolveType(p))
+	}
+}
+
+// fields copies the fields of a type of the i-th schema, keeping their
+// resolvers.
+func (m *schemaMerger) fields(i int, definitions FieldDefinitionMap) Fields {
+	fields := Fields{}
+	for name, definition := range definitions {
+		fields[name] = m.field(i, definition)
+	}
+	return fields
+}
+
+func (m *schemaMerger) field(i int, definition *FieldDefinition) *Field {
+	args := FieldConfigArgument{}
+	for _, arg := range definition.Args {
+		args[arg.Name()] = &ArgumentConfig{
+			Type:              m.typeOf(i, arg.Type),
+			DefaultValue:      arg.DefaultValue,
+			Description:       arg.Description(),
+			DeprecationReason: arg.DeprecationReason,
+			AppliedDirectives: arg.AppliedDirectives,
+		}
+	}
+	return &Field{
+		Name:              definition.Name,
+		Cost:              definition.Cost,
+		CostMultipliers:   definition.CostMultipliers,
+		Type:              m.typeOf(i, definition.Type),
+		Args:              args,
+		Resolve:           m.withOriginalNames(i, definition.Resolve),
+		ResolveSerial:     definition.ResolveSerial,
+		Authorize:         definition.Authorize,
+		DeprecationReason: definition.DeprecationReason,
+		Description:       definition.Description,
+		AppliedDirectives: definition.AppliedDirectives,
+	}
+}
+
+// withOriginalNames wraps a resolver of a field of the i-th schema so that it
+// finds the original names of the types and root fields of its schema which
+// were renamed in ResolveInfo.OriginalNames.
+func (m *schemaMerger) withOriginalNames(i int, resolve FieldResolveFn) FieldResolveFn {
+	originals := m.originals[i]
+	if resolve == nil {
+		return nil
+	}
+	return func(p ResolveParams) (interface{}, error) {
+		if len(originals) > 0 {
+			p.Info.OriginalNames = originals
+		}
+		return resolve(p)
+	}
+}
+
+// mergeRoots merges the root types of an operation, roots[i] being the one of
+// the i-th schema, into a root type named name.
+func (m *schemaMerger) mergeRoots(name string, roots []*Object) *Object {
+	config := ObjectConfig{Name: name}
+	type rootField struct {
+		schema     int
+		definition *FieldDefinition
+	}
+	fields := map[string]rootField{}
+	for i, root := range roots {
+		if root == nil {
+			continue
+		}
+		if config.Description == "" {
+			config.Description = root.PrivateDescription
+		}
+		config.AppliedDirectives = append(config.AppliedDirectives, root.AppliedDirectives()...)
+		definitions := root.Fields()
+		for _, fieldName := range sortedFieldNames(definitions) {
+			newName := fieldName
+			if _, taken := fields[fieldName]; taken {
+				var ok bool
+				conflict := MergeConflict{TypeName: name, FieldName: fieldName, Schema: i}
+				if newName, ok = m.resolve(conflict, func(name string) bool {
+					_, taken := fields[name]
+					return taken
+				}); !ok || newName == fieldName {
+					continue
+				}
+			}
+			if newName != fieldName {
+				m.originals[i][name+"."+newName] = fieldName
+			}
+			fields[newName] = rootField{schema: i, definition: definitions[fieldName]}
+		}
+	}
+	config.Fields = FieldsThunk(func() Fields {
+		merged := Fields{}
+		for fieldName, field := range fields {
+			merged[fieldName] = m.field(field.schema, field.definition)
+			merged[fieldName].Name = fieldName
+		}
+		return merged
+	})
+	return NewObject(config)
+}
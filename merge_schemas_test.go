@@ -0,0 +1,192 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+var booksNodeInterface = graphql.NewInterface(graphql.InterfaceConfig{
+	Name: "Node",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+	},
+})
+
+var booksTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type: booksNodeInterface,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return map[string]interface{}{"id": p.Args["id"], "title": "Dune"}, nil
+				},
+			},
+			"version": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "books", nil
+				},
+			},
+		},
+	}),
+	Types: []graphql.Type{
+		graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Book",
+			Interfaces: []*graphql.Interface{booksNodeInterface},
+			Fields: graphql.Fields{
+				"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"title": &graphql.Field{Type: graphql.String},
+			},
+			// Every node of the books schema is a book.
+			IsTypeOf: func(p graphql.IsTypeOfParams) bool {
+				return true
+			},
+		}),
+	},
+})
+
+var moviesBookObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Book",
+	Fields: graphql.Fields{
+		"isbn": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var moviesMovieObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Movie",
+	Fields: graphql.Fields{
+		"title":    &graphql.Field{Type: graphql.String},
+		"basedOn":  &graphql.Field{Type: moviesBookObject},
+		"released": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var moviesTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "RootQuery",
+		Fields: graphql.Fields{
+			"movies": &graphql.Field{
+				Type: graphql.NewList(moviesMovieObject),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []interface{}{
+						map[string]interface{}{
+							"title":    "Dune",
+							"released": 2021,
+							"basedOn":  map[string]interface{}{"isbn": "0441013597"},
+						},
+					}, nil
+				},
+			},
+			"version": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "movies", nil
+				},
+			},
+		},
+	}),
+})
+
+func TestMergeSchemas_CombinesRootTypesAndDelegatesResolution(t *testing.T) {
+	conflicts := []graphql.MergeConflict{}
+	schema, err := graphql.MergeSchemas([]graphql.Schema{booksTestSchema, moviesTestSchema}, graphql.MergeOptions{
+		OnConflict: graphql.MergeRename,
+		Report: func(conflict graphql.MergeConflict) {
+			conflicts = append(conflicts, conflict)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedConflicts := []graphql.MergeConflict{
+		{TypeName: "Book", Schema: 1, NewName: "Book_1"},
+		{TypeName: "Query", FieldName: "version", Schema: 1, NewName: "version_1"},
+	}
+	if !reflect.DeepEqual(conflicts, expectedConflicts) {
+		t.Fatalf("Unexpected conflicts, Diff: %v", testutil.Diff(expectedConflicts, conflicts))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			node(id: "1") { __typename id ... on Book { title } }
+			movies { title basedOn { __typename isbn } }
+			version
+			version_1
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"node": map[string]interface{}{"__typename": "Book", "id": "1", "title": "Dune"},
+			"movies": []interface{}{
+				map[string]interface{}{
+					"title":   "Dune",
+					"basedOn": map[string]interface{}{"__typename": "Book_1", "isbn": "0441013597"},
+				},
+			},
+			"version":   "books",
+			"version_1": "movies",
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMergeSchemas_ResolvesConflicts(t *testing.T) {
+	schema, err := graphql.MergeSchemas([]graphql.Schema{booksTestSchema, moviesTestSchema}, graphql.MergeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	basedOn := schema.Type("Movie").(*graphql.Object).Fields()["basedOn"]
+	if basedOn.Type != schema.Type("Book") || schema.Type("Book_1") != nil {
+		t.Fatalf("expected the first Book type to win, got %v", basedOn.Type)
+	}
+	if _, ok := schema.QueryType().Fields()["version_1"]; ok || schema.QueryType().Name() != "Query" {
+		t.Fatalf("expected the first version field to win")
+	}
+}
+
+func TestMergeSchemas_ReportsUnresolvedConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  graphql.MergeOptions
+		expected string
+	}{
+		{
+			name:    "MergeError",
+			options: graphql.MergeOptions{OnConflict: graphql.MergeError},
+			expected: `Type "Book" of schema 1 conflicts with a type of a schema merged before.
+Field "Query.version" of schema 1 conflicts with a field of a schema merged before.`,
+		},
+		{
+			name: "MergeRename to taken names",
+			options: graphql.MergeOptions{
+				OnConflict: graphql.MergeRename,
+				Rename: func(conflict graphql.MergeConflict) string {
+					if conflict.FieldName != "" {
+						return "movies"
+					}
+					return "Node"
+				},
+			},
+			expected: `Type "Book" of schema 1 cannot be renamed "Node": the name is taken.
+Field "Query.version" of schema 1 cannot be renamed "movies": the name is taken.`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := graphql.MergeSchemas([]graphql.Schema{booksTestSchema, moviesTestSchema}, test.options)
+			if err == nil || err.Error() != test.expected {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package relay
+
+import (
+	"github.com/GannettDigital/graphql"
+)
+
+// ConnectionCursor is an opaque cursor locating an edge in a connection.
+type ConnectionCursor string
+
+// PageInfo is the information about the page of a connection.
+type PageInfo struct {
+	StartCursor     ConnectionCursor `json:"startCursor"`
+	EndCursor       ConnectionCursor `json:"endCursor"`
+	HasPreviousPage bool             `json:"hasPreviousPage"`
+	HasNextPage     bool             `json:"hasNextPage"`
+}
+
+// Edge is an edge of a connection: a node and its cursor.
+type Edge struct {
+	Node   interface{}      `json:"node"`
+	Cursor ConnectionCursor `json:"cursor"`
+}
+
+// Connection is a page of a connection, resolved by the connection types
+// returned by NewConnectionDefinitions.
+type Connection struct {
+	Edges    []*Edge  `json:"edges"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// ConnectionArguments are the values of the arguments of a connection field.
+// First and Last are nil when absent.
+type ConnectionArguments struct {
+	Before ConnectionCursor `json:"before"`
+	After  ConnectionCursor `json:"after"`
+	First  *int             `json:"first"`
+	Last   *int             `json:"last"`
+}
+
+// NewConnectionArguments reads the arguments of a connection field from the
+// arguments of a resolver.
+func NewConnectionArguments(args map[string]interface{}) ConnectionArguments {
+	connectionArgs := ConnectionArguments{}
+	if before, ok := args["before"].(string); ok {
+		connectionArgs.Before = ConnectionCursor(before)
+	}
+	if after, ok := args["after"].(string); ok {
+		connectionArgs.After = ConnectionCursor(after)
+	}
+	if first, ok := args["first"].(int); ok {
+		connectionArgs.First = &first
+	}
+	if last, ok := args["last"].(int); ok {
+		connectionArgs.Last = &last
+	}
+	return connectionArgs
+}
+
+// ConnectionArgs returns the arguments of a connection field, paging forward
+// with first and after and backward with last and before, along with the
+// extra arguments given.
+func ConnectionArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"before": &graphql.ArgumentConfig{
+			Type: graphql.String,
+		},
+		"after": &graphql.ArgumentConfig{
+			Type: graphql.String,
+		},
+		"first": &graphql.ArgumentConfig{
+			Type: graphql.Int,
+		},
+		"last": &graphql.ArgumentConfig{
+			Type: graphql.Int,
+		},
+	}
+	for name, arg := range extra {
+		args[name] = arg
+	}
+	return args
+}
+
+// PageInfoType is the PageInfo type shared by all connections.
+var PageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "PageInfo",
+	Description: "Information about pagination in a connection.",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "When paginating forwards, are there more items?",
+		},
+		"hasPreviousPage": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "When paginating backwards, are there more items?",
+		},
+		"startCursor": &graphql.Field{
+			Type:        graphql.String,
+			Description: "When paginating backwards, the cursor to continue.",
+		},
+		"endCursor": &graphql.Field{
+			Type:        graphql.String,
+			Description: "When paginating forwards, the cursor to continue.",
+		},
+	},
+})
+
+// ConnectionConfig configures NewConnectionDefinitions.
+type ConnectionConfig struct {
+	// Name prefixes the names of the generated types, and defaults to the
+	// name of NodeType.
+	Name string
+
+	NodeType graphql.Output
+
+	// EdgeFields and ConnectionFields are extra fields of the edge and
+	// connection types, such as a totalCount field.
+	EdgeFields       graphql.Fields
+	ConnectionFields graphql.Fields
+}
+
+// ConnectionDefinitions are the types of the connections to a type of nodes.
+type ConnectionDefinitions struct {
+	EdgeType       *graphql.Object
+	ConnectionType *graphql.Object
+}
+
+// NewConnectionDefinitions returns the XEdge and XConnection types of the
+// connections to the nodes of config.NodeType, where X is the name of the
+// connection. They resolve the Connection and Edge values returned by
+// ConnectionFromSlice.
+func NewConnectionDefinitions(config ConnectionConfig) *ConnectionDefinitions {
+	name := config.Name
+	if name == "" {
+		if config.NodeType != nil {
+			name = graphql.GetNamed(config.NodeType).(graphql.Type).Name()
+		}
+	}
+
+	edgeFields := graphql.Fields{
+		"node": &graphql.Field{
+			Type:        config.NodeType,
+			Description: "The item at the end of the edge",
+		},
+		"cursor": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "A cursor for use in pagination",
+		},
+	}
+	for fieldName, field := range config.EdgeFields {
+		edgeFields[fieldName] = field
+	}
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Edge",
+		Description: "An edge in a connection",
+		Fields:      edgeFields,
+	})
+
+	connectionFields := graphql.Fields{
+		"pageInfo": &graphql.Field{
+			Type:        graphql.NewNonNull(PageInfoType),
+			Description: "Information to aid in pagination.",
+		},
+		"edges": &graphql.Field{
+			Type:        graphql.NewList(edgeType),
+			Description: "A list of edges.",
+		},
+	}
+	for fieldName, field := range config.ConnectionFields {
+		connectionFields[fieldName] = field
+	}
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Connection",
+		Description: "A connection to a list of items.",
+		Fields:      connectionFields,
+	})
+
+	return &ConnectionDefinitions{
+		EdgeType:       edgeType,
+		ConnectionType: connectionType,
+	}
+}
+
+// Field returns a field of the connection type, taking the connection
+// arguments along with the extra arguments given. The selections of the field
+// cost as many times as the number of edges requested with first or last.
+func (d *ConnectionDefinitions) Field(extra graphql.FieldConfigArgument, resolve graphql.FieldResolveFn) *graphql.Field {
+	return &graphql.Field{
+		Type:            d.ConnectionType,
+		Args:            ConnectionArgs(extra),
+		Resolve:         resolve,
+		CostMultipliers: []string{"first", "last"},
+	}
+}
@@ -0,0 +1,182 @@
+// Package relay provides the helpers to build schemas following the Relay
+// server specification: the Node interface with its refetching fields, global
+// object identifiers, and cursor based connections.
+//
+// Types implementing the Node interface expose a global ID, encoding the name
+// of the type and the ID of the object, which the node field resolves back to
+// the object:
+//
+//	nodeDefinitions := relay.NewNodeDefinitions(relay.NodeDefinitionsConfig{
+//		IDFetcher: func(ctx context.Context, id string, info graphql.ResolveInfo) (interface{}, error) {
+//			resolvedID, err := relay.FromGlobalID(id)
+//			...
+//		},
+//	})
+//
+// Connections page through lists with the first, after, last and before
+// arguments, and their types are generated from the type of their nodes:
+//
+//	userConnection := relay.NewConnectionDefinitions(relay.ConnectionConfig{NodeType: userType})
+//	"friends": userConnection.Field(nil, func(p graphql.ResolveParams) (interface{}, error) {
+//		return relay.ConnectionFromSlice(friends, relay.NewConnectionArguments(p.Args))
+//	}),
+package relay
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+)
+
+// ResolvedGlobalID is a global ID decoded into the name of the type and the
+// ID of the object it identifies.
+type ResolvedGlobalID struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ToGlobalID returns the global ID of the object of type ttype with the given
+// ID, which is unique across all types.
+func ToGlobalID(ttype string, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(ttype + ":" + id))
+}
+
+// FromGlobalID decodes a global ID returned by ToGlobalID.
+func FromGlobalID(globalID string) (*ResolvedGlobalID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid global ID %q.", globalID)
+	}
+	tokens := strings.SplitN(string(decoded), ":", 2)
+	if len(tokens) != 2 || tokens[0] == "" {
+		return nil, fmt.Errorf("Invalid global ID %q.", globalID)
+	}
+	return &ResolvedGlobalID{Type: tokens[0], ID: tokens[1]}, nil
+}
+
+// IDFetcherFn fetches the object identified by a global ID.
+type IDFetcherFn func(ctx context.Context, id string, info graphql.ResolveInfo) (interface{}, error)
+
+// GlobalIDFetcherFn returns the ID of an object, local to its type.
+type GlobalIDFetcherFn func(obj interface{}, info graphql.ResolveInfo, ctx context.Context) (string, error)
+
+// NodeDefinitionsConfig configures NewNodeDefinitions.
+type NodeDefinitionsConfig struct {
+	// IDFetcher fetches the objects resolved by the node and nodes fields.
+	IDFetcher IDFetcherFn
+
+	// TypeResolve resolves the types of the objects implementing the Node
+	// interface. When nil, the objects' types use their IsTypeOf functions.
+	TypeResolve graphql.ResolveTypeFn
+}
+
+// NodeDefinitions are the Node interface and the fields refetching objects
+// by their global IDs, to be added to the query type.
+type NodeDefinitions struct {
+	NodeInterface *graphql.Interface
+	NodeField     *graphql.Field
+	NodesField    *graphql.Field
+}
+
+// NewNodeDefinitions returns the Node interface, the node(id: ID!) field
+// returning the object with a global ID, and the nodes(ids: [ID!]!) field
+// returning the objects with a list of global IDs.
+func NewNodeDefinitions(config NodeDefinitionsConfig) *NodeDefinitions {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Node",
+		Description: "An object with an ID",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "The id of the object",
+			},
+		},
+		ResolveType: config.TypeResolve,
+	})
+
+	fetch := func(p graphql.ResolveParams, id string) (interface{}, error) {
+		if config.IDFetcher == nil {
+			return nil, errors.New("The node definitions have no ID fetcher.")
+		}
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return config.IDFetcher(ctx, id, p.Info)
+	}
+
+	nodeField := &graphql.Field{
+		Name:        "node",
+		Description: "Fetches an object given its ID",
+		Type:        nodeInterface,
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "The ID of an object",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, _ := p.Args["id"].(string)
+			return fetch(p, id)
+		},
+	}
+	nodesField := &graphql.Field{
+		Name:        "nodes",
+		Description: "Fetches objects given their IDs",
+		Type:        graphql.NewNonNull(graphql.NewList(nodeInterface)),
+		Args: graphql.FieldConfigArgument{
+			"ids": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.ID))),
+				Description: "The IDs of objects",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			ids, _ := p.Args["ids"].([]interface{})
+			nodes := make([]interface{}, len(ids))
+			for i, id := range ids {
+				id, _ := id.(string)
+				node, err := fetch(p, id)
+				if err != nil {
+					return nil, err
+				}
+				nodes[i] = node
+			}
+			return nodes, nil
+		},
+	}
+	return &NodeDefinitions{
+		NodeInterface: nodeInterface,
+		NodeField:     nodeField,
+		NodesField:    nodesField,
+	}
+}
+
+// GlobalIDField returns the id field of a type implementing the Node
+// interface, resolving to the global ID of the object. The ID of the object
+// local to its type is returned by idFetcher or, when nil, read from the "id"
+// field of the object.
+func GlobalIDField(typeName string, idFetcher GlobalIDFetcherFn) *graphql.Field {
+	return &graphql.Field{
+		Name:        "id",
+		Description: "The ID of an object",
+		Type:        graphql.NewNonNull(graphql.ID),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if idFetcher != nil {
+				id, err := idFetcher(p.Source, p.Info, p.Context)
+				if err != nil {
+					return nil, err
+				}
+				return ToGlobalID(typeName, id), nil
+			}
+			id, err := graphql.DefaultResolveFn(p)
+			if err != nil || id == nil {
+				return nil, err
+			}
+			return ToGlobalID(typeName, fmt.Sprintf("%v", id)), nil
+		},
+	}
+}
@@ -0,0 +1,284 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/relay"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+var users = []interface{}{
+	&user{ID: "1", Name: "Ada"},
+	&user{ID: "2", Name: "Alan"},
+	&user{ID: "3", Name: "Grace"},
+	&user{ID: "4", Name: "Edsger"},
+}
+
+var usersTestSchema graphql.Schema
+
+func init() {
+	var userType *graphql.Object
+	nodeDefinitions := relay.NewNodeDefinitions(relay.NodeDefinitionsConfig{
+		IDFetcher: func(ctx context.Context, id string, info graphql.ResolveInfo) (interface{}, error) {
+			resolvedID, err := relay.FromGlobalID(id)
+			if err != nil {
+				return nil, err
+			}
+			for _, u := range users {
+				if resolvedID.Type == "User" && u.(*user).ID == resolvedID.ID {
+					return u, nil
+				}
+			}
+			return nil, errors.New("Unknown user.")
+		},
+		TypeResolve: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return userType
+		},
+	})
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:       "User",
+		Interfaces: []*graphql.Interface{nodeDefinitions.NodeInterface},
+		Fields: graphql.Fields{
+			"id": relay.GlobalIDField("User", nil),
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Cost: 1,
+			},
+		},
+	})
+	userConnection := relay.NewConnectionDefinitions(relay.ConnectionConfig{
+		NodeType: userType,
+		ConnectionFields: graphql.Fields{
+			"totalCount": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return len(users), nil
+				},
+			},
+		},
+	})
+	usersField := userConnection.Field(nil, func(p graphql.ResolveParams) (interface{}, error) {
+		return relay.ConnectionFromSlice(users, relay.NewConnectionArguments(p.Args))
+	})
+	usersField.Cost = 5
+
+	usersTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node":  nodeDefinitions.NodeField,
+				"nodes": nodeDefinitions.NodesField,
+				"users": usersField,
+			},
+		}),
+		Types: []graphql.Type{userType},
+	})
+}
+
+func TestGlobalID_RoundTrips(t *testing.T) {
+	globalID := relay.ToGlobalID("User", "1:a")
+	if globalID != "VXNlcjoxOmE=" {
+		t.Fatalf("unexpected global ID: %v", globalID)
+	}
+	resolvedID, err := relay.FromGlobalID(globalID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &relay.ResolvedGlobalID{Type: "User", ID: "1:a"}
+	if !reflect.DeepEqual(resolvedID, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, resolvedID))
+	}
+	if _, err := relay.FromGlobalID("not a global ID"); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestConnectionFromSlice(t *testing.T) {
+	letters := []interface{}{"A", "B", "C", "D", "E"}
+	edges := func(offsets ...int) []*relay.Edge {
+		edges := []*relay.Edge{}
+		for _, offset := range offsets {
+			edges = append(edges, &relay.Edge{Node: letters[offset], Cursor: relay.OffsetToCursor(offset)})
+		}
+		return edges
+	}
+	tests := []struct {
+		description string
+		args        map[string]interface{}
+		want        *relay.Connection
+	}{
+		{
+			description: "all elements without pagination",
+			args:        map[string]interface{}{},
+			want: &relay.Connection{
+				Edges: edges(0, 1, 2, 3, 4),
+				PageInfo: relay.PageInfo{
+					StartCursor: relay.OffsetToCursor(0),
+					EndCursor:   relay.OffsetToCursor(4),
+				},
+			},
+		},
+		{
+			description: "first elements after a cursor",
+			args:        map[string]interface{}{"first": 2, "after": string(relay.OffsetToCursor(1))},
+			want: &relay.Connection{
+				Edges: edges(2, 3),
+				PageInfo: relay.PageInfo{
+					StartCursor: relay.OffsetToCursor(2),
+					EndCursor:   relay.OffsetToCursor(3),
+					HasNextPage: true,
+				},
+			},
+		},
+		{
+			description: "last elements before a cursor",
+			args:        map[string]interface{}{"last": 2, "before": string(relay.OffsetToCursor(2))},
+			want: &relay.Connection{
+				Edges: edges(0, 1),
+				PageInfo: relay.PageInfo{
+					StartCursor: relay.OffsetToCursor(0),
+					EndCursor:   relay.OffsetToCursor(1),
+				},
+			},
+		},
+		{
+			description: "last elements",
+			args:        map[string]interface{}{"last": 2},
+			want: &relay.Connection{
+				Edges: edges(3, 4),
+				PageInfo: relay.PageInfo{
+					StartCursor:     relay.OffsetToCursor(3),
+					EndCursor:       relay.OffsetToCursor(4),
+					HasPreviousPage: true,
+				},
+			},
+		},
+		{
+			description: "no elements after the last cursor",
+			args:        map[string]interface{}{"first": 2, "after": string(relay.OffsetToCursor(4))},
+			want: &relay.Connection{
+				Edges: edges(),
+			},
+		},
+	}
+	for _, test := range tests {
+		got, err := relay.ConnectionFromSlice(letters, relay.NewConnectionArguments(test.args))
+		if err != nil {
+			t.Fatalf("Test %q - unexpected error: %v", test.description, err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Test %q - Diff: %v", test.description, testutil.Diff(test.want, got))
+		}
+	}
+
+	got, err := relay.ConnectionFromSlicePart(letters[2:4], relay.NewConnectionArguments(map[string]interface{}{
+		"first": 2,
+		"after": string(relay.OffsetToCursor(1)),
+	}), relay.SlicePartInfo{SliceStart: 2, SliceLength: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &relay.Connection{
+		Edges: edges(2, 3),
+		PageInfo: relay.PageInfo{
+			StartCursor: relay.OffsetToCursor(2),
+			EndCursor:   relay.OffsetToCursor(3),
+			HasNextPage: true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected slice part connection, Diff: %v", testutil.Diff(want, got))
+	}
+}
+
+func TestConnectionFromSlice_RejectsNegativeArguments(t *testing.T) {
+	tests := []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"first": -1}, `Argument "first" must be a non-negative integer.`},
+		{map[string]interface{}{"last": -1}, `Argument "last" must be a non-negative integer.`},
+	}
+	for _, test := range tests {
+		_, err := relay.ConnectionFromSlice(users, relay.NewConnectionArguments(test.args))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("expected error %q for %v, got %v", test.want, test.args, err)
+		}
+	}
+}
+
+func TestNodeDefinitions_RefetchesNodes(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: usersTestSchema,
+		RequestString: `{
+			node(id: "VXNlcjoy") { id ... on User { name } }
+			nodes(ids: ["VXNlcjox", "VXNlcjoz"]) { ... on User { name } }
+			users(first: 2) {
+				totalCount
+				edges { cursor node { id name } }
+				pageInfo { hasNextPage endCursor }
+			}
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"node": map[string]interface{}{"id": "VXNlcjoy", "name": "Alan"},
+			"nodes": []interface{}{
+				map[string]interface{}{"name": "Ada"},
+				map[string]interface{}{"name": "Grace"},
+			},
+			"users": map[string]interface{}{
+				"totalCount": 4,
+				"edges": []interface{}{
+					map[string]interface{}{
+						"cursor": "YXJyYXljb25uZWN0aW9uOjA=",
+						"node":   map[string]interface{}{"id": "VXNlcjox", "name": "Ada"},
+					},
+					map[string]interface{}{
+						"cursor": "YXJyYXljb25uZWN0aW9uOjE=",
+						"node":   map[string]interface{}{"id": "VXNlcjoy", "name": "Alan"},
+					},
+				},
+				"pageInfo": map[string]interface{}{
+					"hasNextPage": true,
+					"endCursor":   "YXJyYXljb25uZWN0aW9uOjE=",
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestConnectionDefinitions_FieldMultipliesCost(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{`{ users(first: 10) { edges { node { name } } } }`, 15},
+		{`{ users(first: -10) { edges { node { name } } } }`, 5},
+		{`{ users(first: -10, last: 2) { edges { node { name } } } }`, 7},
+	}
+	for _, test := range tests {
+		cost, _, err := graphql.QueryComplexity(graphql.ExecuteParams{
+			Schema: usersTestSchema,
+			AST:    testutil.TestParse(t, test.query),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cost != test.want {
+			t.Errorf("expected a cost of %v for %v, got %v", test.want, test.query, cost)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package relay
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const sliceCursorPrefix = "arrayconnection:"
+
+// SlicePartInfo locates a part of a slice within the whole slice.
+type SlicePartInfo struct {
+	// SliceStart is the offset of the part in the whole slice.
+	SliceStart int
+
+	// SliceLength is the length of the whole slice.
+	SliceLength int
+}
+
+// ConnectionFromSlice returns the page of data selected by the arguments of
+// a connection field. The cursors of the edges are their offsets in data.
+func ConnectionFromSlice(data []interface{}, args ConnectionArguments) (*Connection, error) {
+	return ConnectionFromSlicePart(data, args, SlicePartInfo{
+		SliceStart:  0,
+		SliceLength: len(data),
+	})
+}
+
+// ConnectionFromSlicePart returns the page selected by the arguments of a
+// connection field from a part of a slice, such as the rows fetched with an
+// offset and a limit. The part must contain the edges selected by the
+// arguments, and the cursors of the edges are their offsets in the whole
+// slice.
+func ConnectionFromSlicePart(part []interface{}, args ConnectionArguments, info SlicePartInfo) (*Connection, error) {
+	if args.First != nil && *args.First < 0 {
+		return nil, errors.New(`Argument "first" must be a non-negative integer.`)
+	}
+	if args.Last != nil && *args.Last < 0 {
+		return nil, errors.New(`Argument "last" must be a non-negative integer.`)
+	}
+
+	sliceEnd := info.SliceStart + len(part)
+	beforeOffset := GetOffsetWithDefault(args.Before, info.SliceLength)
+	afterOffset := GetOffsetWithDefault(args.After, -1)
+
+	startOffset := max(info.SliceStart-1, afterOffset, -1) + 1
+	endOffset := min(sliceEnd, beforeOffset, info.SliceLength)
+	if args.First != nil {
+		endOffset = min(endOffset, startOffset+*args.First)
+	}
+	if args.Last != nil {
+		startOffset = max(startOffset, endOffset-*args.Last)
+	}
+
+	edges := []*Edge{}
+	begin := max(startOffset-info.SliceStart, 0)
+	end := len(part) - (sliceEnd - endOffset)
+	if begin < end {
+		for i, node := range part[begin:end] {
+			edges = append(edges, &Edge{
+				Node:   node,
+				Cursor: OffsetToCursor(startOffset + i),
+			})
+		}
+	}
+
+	pageInfo := PageInfo{}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	lowerBound := 0
+	if args.After != "" {
+		lowerBound = afterOffset + 1
+	}
+	upperBound := info.SliceLength
+	if args.Before != "" {
+		upperBound = beforeOffset
+	}
+	if args.Last != nil {
+		pageInfo.HasPreviousPage = startOffset > lowerBound
+	}
+	if args.First != nil {
+		pageInfo.HasNextPage = endOffset < upperBound
+	}
+
+	return &Connection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// OffsetToCursor returns the cursor of the edge at offset in a slice.
+func OffsetToCursor(offset int) ConnectionCursor {
+	return ConnectionCursor(base64.StdEncoding.EncodeToString([]byte(sliceCursorPrefix + strconv.Itoa(offset))))
+}
+
+// CursorToOffset returns the offset in a slice of the edge at cursor.
+func CursorToOffset(cursor ConnectionCursor) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(cursor))
+	if err != nil || !strings.HasPrefix(string(decoded), sliceCursorPrefix) {
+		return 0, fmt.Errorf("Invalid cursor %q.", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), sliceCursorPrefix))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid cursor %q.", cursor)
+	}
+	return offset, nil
+}
+
+// CursorForObjectInConnection returns the cursor of the edge to object in
+// data, or an empty cursor when data has no such object.
+func CursorForObjectInConnection(data []interface{}, object interface{}) ConnectionCursor {
+	for offset, value := range data {
+		if reflect.DeepEqual(value, object) {
+			return OffsetToCursor(offset)
+		}
+	}
+	return ""
+}
+
+// GetOffsetWithDefault returns the offset of cursor, or defaultOffset when
+// the cursor is empty or invalid.
+func GetOffsetWithDefault(cursor ConnectionCursor, defaultOffset int) int {
+	if cursor == "" {
+		return defaultOffset
+	}
+	offset, err := CursorToOffset(cursor)
+	if err != nil {
+		return defaultOffset
+	}
+	return offset
+}
+
+func max(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value > result {
+			result = value
+		}
+	}
+	return result
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
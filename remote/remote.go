@@ -0,0 +1,311 @@
+// Package remote mounts the schemas of remote GraphQL services into local
+// schemas, delegating the execution of their fields to the services.
+//
+// The schema of a service is built from its introspection, and merged into a
+// local schema with graphql.MergeSchemas:
+//
+//	remoteSchema, err := remote.NewSchema(ctx, &client.HTTPTransport{URL: url})
+//	schema, err := graphql.MergeSchemas([]graphql.Schema{localSchema, remoteSchema}, graphql.MergeOptions{})
+//
+// Each root field of the service then forwards its selections to the
+// service, and the data and errors of the response are merged back into the
+// local result.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/client"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/printer"
+)
+
+// NewSchema builds the schema of the service behind transport from its
+// introspection. The query and mutation fields of the schema are resolved
+// with Delegate, and the fields of the types they return read the data of
+// the service's response.
+func NewSchema(ctx context.Context, transport client.Transport) (graphql.Schema, error) {
+	response, err := transport.Do(ctx, &client.Request{Query: graphql.IntrospectionQuery})
+	if err == nil && len(response.Errors) > 0 {
+		err = response.Errors
+	}
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("Cannot introspect the remote schema: %v", err)
+	}
+	introspection := map[string]interface{}{}
+	if err := json.Unmarshal(response.Data, &introspection); err != nil {
+		return graphql.Schema{}, fmt.Errorf("Cannot introspect the remote schema: %v", err)
+	}
+	schema, err := graphql.BuildClientSchema(introspection)
+	if err != nil {
+		return schema, err
+	}
+
+	delegate := Delegate(transport)
+	for name, ttype := range schema.TypeMap() {
+		object, ok := ttype.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		isRoot := object == schema.QueryType() || object == schema.MutationType()
+		for _, field := range object.Fields() {
+			if isRoot {
+				field.Resolve = delegate
+			} else {
+				field.Resolve = resolveResponseKey
+			}
+		}
+	}
+	return schema, nil
+}
+
+// Delegate returns a resolver forwarding the root field being resolved, with
+// its selections, to the service behind transport, as built by Request.
+//
+// The field resolves to its data in the response of the service. The errors
+// of the response are returned as gqlerrors.FormattedErrors, located at the
+// field and keeping their paths, which start at the field as well.
+func Delegate(transport client.Transport) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		response, err := transport.Do(ctx, Request(p.Info))
+		if err != nil {
+			return nil, err
+		}
+		data := map[string]interface{}{}
+		if len(response.Data) > 0 {
+			if err := json.Unmarshal(response.Data, &data); err != nil {
+				return nil, err
+			}
+		}
+		value := data[responseKey(p.Info.FieldASTs[0])]
+		if len(response.Errors) == 0 {
+			return value, nil
+		}
+		nodes := graphql.FieldASTsToNodeASTs(p.Info.FieldASTs)
+		errs := gqlerrors.FormattedErrors{}
+		for _, remoteErr := range response.Errors {
+			err := gqlerrors.FormatError(graphql.NewLocatedError(remoteErr.Message, nodes))
+			err.Path = remoteErr.Path
+			errs = append(errs, err)
+		}
+		return value, errs
+	}
+}
+
+// Request returns the request forwarding the field being resolved to a
+// remote service: an operation of the same type and name selecting the
+// field, the fragments it spreads, and the variables it uses with their
+// values. Every selection set also selects "__typename", so that abstract
+// types can resolve the types of their values.
+//
+// The types and the field renamed by graphql.MergeSchemas take back their
+// names in the remote schema, found in info.OriginalNames. The field is then
+// aliased to its response key, under which its data is found in the response.
+func Request(info graphql.ResolveInfo) *client.Request {
+	operation, _ := info.Operation.(*ast.OperationDefinition)
+	if operation == nil {
+		operation = ast.NewOperationDefinition(&ast.OperationDefinition{Operation: ast.OperationTypeQuery})
+	}
+	names := info.OriginalNames
+	selections := []ast.Selection{}
+	for _, field := range info.FieldASTs {
+		field = withTypename(field, names)
+		if info.ParentType != nil {
+			if name, ok := names[info.ParentType.Name()+"."+field.Name.Value]; ok {
+				field.Alias = ast.NewName(&ast.Name{Value: responseKey(field)})
+				field.Name = ast.NewName(&ast.Name{Value: name})
+			}
+		}
+		selections = append(selections, field)
+	}
+
+	fragments := map[string]bool{}
+	used := map[string]bool{}
+	for _, field := range info.FieldASTs {
+		collectUsages(field, info.Fragments, fragments, used)
+	}
+	variableDefinitions := []*ast.VariableDefinition{}
+	variables := map[string]interface{}{}
+	for _, definition := range operation.VariableDefinitions {
+		name := definition.Variable.Name.Value
+		if used[name] {
+			variableDefinitions = append(variableDefinitions, ast.NewVariableDefinition(&ast.VariableDefinition{
+				Variable:     definition.Variable,
+				Type:         originalType(definition.Type, names),
+				DefaultValue: definition.DefaultValue,
+			}))
+			if value, ok := info.VariableValues[name]; ok {
+				variables[name] = value
+			}
+		}
+	}
+
+	document := ast.NewDocument(&ast.Document{Definitions: []ast.Node{
+		ast.NewOperationDefinition(&ast.OperationDefinition{
+			Operation:           operation.Operation,
+			Name:                operation.Name,
+			VariableDefinitions: variableDefinitions,
+			SelectionSet:        ast.NewSelectionSet(&ast.SelectionSet{Selections: selections}),
+		}),
+	}})
+	for _, definition := range info.Fragments {
+		fragment, ok := definition.(*ast.FragmentDefinition)
+		if !ok || !fragments[fragment.Name.Value] {
+			continue
+		}
+		document.Definitions = append(document.Definitions, ast.NewFragmentDefinition(&ast.FragmentDefinition{
+			Name:          fragment.Name,
+			TypeCondition: originalNamed(fragment.TypeCondition, names),
+			Directives:    fragment.Directives,
+			SelectionSet:  withTypenameSelectionSet(fragment.SelectionSet, names),
+		}))
+	}
+
+	request := &client.Request{
+		Query:     printer.Print(document).(string),
+		Variables: variables,
+	}
+	if operation.Name != nil {
+		request.OperationName = operation.Name.Value
+	}
+	return request
+}
+
+// resolveResponseKey resolves a field of a type of a remote schema from the
+// data of the response of the service, which is keyed by the response keys
+// of the forwarded fields.
+func resolveResponseKey(p graphql.ResolveParams) (interface{}, error) {
+	source, _ := p.Source.(map[string]interface{})
+	return source[responseKey(p.Info.FieldASTs[0])], nil
+}
+
+func responseKey(field *ast.Field) string {
+	if field.Alias != nil && field.Alias.Value != "" {
+		return field.Alias.Value
+	}
+	return field.Name.Value
+}
+
+// collectUsages records the names of the fragments a node spreads and of the
+// variables it uses, following the spread fragments.
+func collectUsages(node interface{}, definitions map[string]ast.Definition, fragments map[string]bool, variables map[string]bool) {
+	switch node := node.(type) {
+	case *ast.Field:
+		for _, arg := range node.Arguments {
+			collectUsages(arg.Value, definitions, fragments, variables)
+		}
+		for _, directive := range node.Directives {
+			collectUsages(directive, definitions, fragments, variables)
+		}
+		collectUsages(node.SelectionSet, definitions, fragments, variables)
+	case *ast.InlineFragment:
+		for _, directive := range node.Directives {
+			collectUsages(directive, definitions, fragments, variables)
+		}
+		collectUsages(node.SelectionSet, definitions, fragments, variables)
+	case *ast.FragmentSpread:
+		for _, directive := range node.Directives {
+			collectUsages(directive, definitions, fragments, variables)
+		}
+		if fragments[node.Name.Value] {
+			return
+		}
+		fragments[node.Name.Value] = true
+		if fragment, ok := definitions[node.Name.Value].(*ast.FragmentDefinition); ok {
+			for _, directive := range fragment.Directives {
+				collectUsages(directive, definitions, fragments, variables)
+			}
+			collectUsages(fragment.SelectionSet, definitions, fragments, variables)
+		}
+	case *ast.SelectionSet:
+		if node == nil {
+			return
+		}
+		for _, selection := range node.Selections {
+			collectUsages(selection, definitions, fragments, variables)
+		}
+	case *ast.Directive:
+		for _, arg := range node.Arguments {
+			collectUsages(arg.Value, definitions, fragments, variables)
+		}
+	case *ast.Variable:
+		variables[node.Name.Value] = true
+	case *ast.ListValue:
+		for _, value := range node.Values {
+			collectUsages(value, definitions, fragments, variables)
+		}
+	case *ast.ObjectValue:
+		for _, field := range node.Fields {
+			collectUsages(field.Value, definitions, fragments, variables)
+		}
+	}
+}
+
+// withTypename returns a copy of a field whose selection sets also select
+// "__typename", with type conditions on the types in names renamed back.
+func withTypename(field *ast.Field, names map[string]string) *ast.Field {
+	return ast.NewField(&ast.Field{
+		Alias:        field.Alias,
+		Name:         field.Name,
+		Arguments:    field.Arguments,
+		Directives:   field.Directives,
+		SelectionSet: withTypenameSelectionSet(field.SelectionSet, names),
+	})
+}
+
+func withTypenameSelectionSet(selectionSet *ast.SelectionSet, names map[string]string) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	selections := []ast.Selection{
+		ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "__typename"})}),
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selections = append(selections, withTypename(selection, names))
+		case *ast.InlineFragment:
+			selections = append(selections, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: originalNamed(selection.TypeCondition, names),
+				Directives:    selection.Directives,
+				SelectionSet:  withTypenameSelectionSet(selection.SelectionSet, names),
+			}))
+		default:
+			selections = append(selections, selection)
+		}
+	}
+	return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+}
+
+// originalType returns a type of a variable definition with the named type it
+// wraps renamed back as found in names.
+func originalType(ttype ast.Type, names map[string]string) ast.Type {
+	switch ttype := ttype.(type) {
+	case *ast.List:
+		return ast.NewList(&ast.List{Type: originalType(ttype.Type, names)})
+	case *ast.NonNull:
+		return ast.NewNonNull(&ast.NonNull{Type: originalType(ttype.Type, names)})
+	case *ast.Named:
+		return originalNamed(ttype, names)
+	}
+	return ttype
+}
+
+func originalNamed(named *ast.Named, names map[string]string) *ast.Named {
+	if named == nil || named.Name == nil {
+		return named
+	}
+	if name, ok := names[named.Name.Value]; ok {
+		return ast.NewNamed(&ast.Named{Name: ast.NewName(&ast.Name{Value: name})})
+	}
+	return named
+}
@@ -0,0 +1,318 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/client"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/language/location"
+	"github.com/GannettDigital/graphql/remote"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+// serviceTransport executes requests against schema, as a remote service
+// would, recording their queries.
+func serviceTransport(t *testing.T, schema graphql.Schema, queries *[]string) client.Transport {
+	var mu sync.Mutex
+	return client.TransportFunc(func(ctx context.Context, request *client.Request) (*client.Response, error) {
+		mu.Lock()
+		*queries = append(*queries, request.Query)
+		mu.Unlock()
+		variables, _ := request.Variables.(map[string]interface{})
+		body, err := json.Marshal(graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  request.Query,
+			OperationName:  request.OperationName,
+			VariableValues: variables,
+			Context:        ctx,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		response := &client.Response{}
+		return response, json.Unmarshal(body, response)
+	})
+}
+
+var usersTestSchema graphql.Schema
+
+func init() {
+	users := map[string]map[string]interface{}{
+		"1": {"id": "1", "name": "Ada", "friendIDs": []string{"2"}},
+		"2": {"id": "2", "name": "Alan", "friendIDs": []string{}},
+	}
+	var user *graphql.Object
+	user = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name": &graphql.Field{Type: graphql.String},
+				"friends": &graphql.Field{
+					Type: graphql.NewList(user),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						friends := []interface{}{}
+						for _, id := range p.Source.(map[string]interface{})["friendIDs"].([]string) {
+							friends = append(friends, users[id])
+						}
+						return friends, nil
+					},
+				},
+				"email": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("Not allowed to see emails.")
+					},
+				},
+			}
+		}),
+	})
+	group := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Group",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	member := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Member",
+		Types: []*graphql.Object{user, group},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(map[string]interface{})["title"]; ok {
+				return group
+			}
+			return user
+		},
+	})
+	usersTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: user,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return users[p.Args["id"].(string)], nil
+					},
+				},
+				"members": &graphql.Field{
+					Type: graphql.NewList(member),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{users["2"], map[string]interface{}{"title": "Admins"}}, nil
+					},
+				},
+			},
+		}),
+	})
+}
+
+func TestRemoteSchema_DelegatesSelections(t *testing.T) {
+	queries := []string{}
+	remoteSchema, err := remote.NewSchema(context.Background(), serviceTransport(t, usersTestSchema, &queries))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	localSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema, err := graphql.MergeSchemas([]graphql.Schema{localSchema, remoteSchema}, graphql.MergeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query Profile($id: ID!) {
+			hello
+			me: user(id: $id) {
+				...UserFields
+				friends { handle: name }
+			}
+			members { ... on Group { title } ... on User { name } }
+		}
+
+		fragment UserFields on User { id name }`,
+		VariableValues: map[string]interface{}{"id": "1"},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hello": "world",
+			"me": map[string]interface{}{
+				"id":      "1",
+				"name":    "Ada",
+				"friends": []interface{}{map[string]interface{}{"handle": "Alan"}},
+			},
+			"members": []interface{}{
+				map[string]interface{}{"name": "Alan"},
+				map[string]interface{}{"title": "Admins"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	// The first query is the introspection query; the forwarded fields
+	// are executed in parallel.
+	expectedQuery := `query Profile($id: ID!) {
+  me: user(id: $id) {
+    __typename
+    ...UserFields
+    friends {
+      __typename
+      handle: name
+    }
+  }
+}
+
+fragment UserFields on User {
+  __typename
+  id
+  name
+}
+`
+	if len(queries) != 3 || (queries[1] != expectedQuery && queries[2] != expectedQuery) {
+		t.Fatalf("unexpected forwarded queries: %v", queries[1:])
+	}
+}
+
+func TestRemoteSchema_MergesRemoteErrors(t *testing.T) {
+	queries := []string{}
+	introspection := serviceTransport(t, usersTestSchema, &queries)
+	transport := client.TransportFunc(func(ctx context.Context, request *client.Request) (*client.Response, error) {
+		if strings.Contains(request.Query, "__schema") {
+			return introspection.Do(ctx, request)
+		}
+		return &client.Response{
+			Data: json.RawMessage(`{"user": {"__typename": "User", "name": "Alan", "email": null}}`),
+			Errors: client.Errors{{
+				Message:   "Not allowed to see emails.",
+				Locations: []client.Location{{Line: 5, Column: 5}},
+				Path:      []interface{}{"user", "email"},
+			}},
+		}, nil
+	})
+	schema, err := remote.NewSchema(context.Background(), transport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user(id: "2") { name email } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"name": "Alan", "email": nil},
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "Not allowed to see emails.",
+				Locations: []location.SourceLocation{{Line: 1, Column: 3}},
+				Path:      []interface{}{"user", "email"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRemoteSchema_DelegatesRenamedTypesAndFields(t *testing.T) {
+	queries := []string{}
+	remoteSchema, err := remote.NewSchema(context.Background(), serviceTransport(t, usersTestSchema, &queries))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	localUser := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "User",
+		Fields: graphql.Fields{"login": &graphql.Field{Type: graphql.String}},
+	})
+	localSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: localUser,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"login": "root"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema, err := graphql.MergeSchemas([]graphql.Schema{localSchema, remoteSchema}, graphql.MergeOptions{
+		OnConflict: graphql.MergeRename,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query Profile($id: ID!) {
+			user { login }
+			user_1(id: $id) { ...UserFields }
+		}
+
+		fragment UserFields on User_1 { name friends { ... on User_1 { id } } }`,
+		VariableValues: map[string]interface{}{"id": "1"},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{"login": "root"},
+			"user_1": map[string]interface{}{
+				"name":    "Ada",
+				"friends": []interface{}{map[string]interface{}{"id": "2"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	expectedQuery := `query Profile($id: ID!) {
+  user_1: user(id: $id) {
+    __typename
+    ...UserFields
+  }
+}
+
+fragment UserFields on User {
+  __typename
+  name
+  friends {
+    __typename
+    ... on User {
+      __typename
+      id
+    }
+  }
+}
+`
+	if len(queries) != 2 || queries[1] != expectedQuery {
+		t.Fatalf("unexpected forwarded queries: %v", queries[1:])
+	}
+}
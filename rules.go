@@ -34,6 +34,7 @@ var SpecifiedRules = []ValidationRuleFn{
 	ProvidedNonNullArgumentsRule,
 	ScalarLeafsRule,
 	UniqueArgumentNamesRule,
+	UniqueDirectivesPerLocationRule,
 	UniqueFragmentNamesRule,
 	UniqueInputFieldNamesRule,
 	UniqueOperationNamesRule,
@@ -74,7 +75,7 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if argAST, ok := p.Node.(*ast.Argument); ok {
 						if argDef := context.Argument(); argDef != nil {
-							if isValid, messages := isValidLiteralValue(argDef.Type, argAST.Value); !isValid {
+							if isValid, messages := isValidLiteralValue(context.Schema(), argDef.Type, argAST.Value); !isValid {
 								var messagesStr, argNameValue string
 								if argAST.Name != nil {
 									argNameValue = argAST.Name.Value
@@ -132,7 +133,7 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 								[]ast.Node{defaultValue},
 							)
 						}
-						if isValid, messages := isValidLiteralValue(ttype, defaultValue); !isValid && defaultValue != nil {
+						if isValid, messages := isValidLiteralValue(context.Schema(), ttype, defaultValue); !isValid && defaultValue != nil {
 							if len(messages) > 0 {
 								messagesStr = "\n" + strings.Join(messages, "\n")
 							}
@@ -229,7 +230,10 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 								nodeName = node.Name.Value
 							}
 							// First determine if there are any suggested types to condition on.
-							suggestedTypeNames := getSuggestedTypeNames(context.Schema(), ttype, nodeName)
+							suggestedTypeNames := []string{}
+							if !context.Schema().hideSuggestions {
+								suggestedTypeNames = getSuggestedTypeNames(context.Schema(), ttype, nodeName)
+							}
 
 							// If there are no suggested types, then perhaps this was a typo?
 							suggestedFieldNames := []string{}
@@ -266,18 +270,18 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 		// stores a maps of object name => true to remove duplicates from results
 		suggestedObjectMap = map[string]bool{}
 	)
-	possibleTypes := schema.PossibleTypes(ttype)
+	possibleTypes := schema.visibleObjects(schema.PossibleTypes(ttype))
 
 	for _, possibleType := range possibleTypes {
-		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil {
+		if field, ok := schema.visibleFields(possibleType)[fieldName]; !ok || field == nil {
 			continue
 		}
 		// This object type defines this field.
 		suggestedObjectTypes = append(suggestedObjectTypes, possibleType.Name())
 		suggestedObjectMap[possibleType.Name()] = true
 
-		for _, possibleInterface := range possibleType.Interfaces() {
-			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil {
+		for _, possibleInterface := range schema.visibleInterfaces(possibleType.Interfaces()) {
+			if field, ok := schema.visibleFields(possibleInterface)[fieldName]; !ok || field == nil {
 				continue
 			}
 
@@ -325,21 +329,11 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 // that may be the result of a typo.
 func getSuggestedFieldNames(schema *Schema, ttype Output, fieldName string) []string {
 
-	fields := FieldDefinitionMap{}
-	switch ttype := ttype.(type) {
-	case *Object:
-		fields = ttype.Fields()
-	case *Interface:
-		fields = ttype.Fields()
-	default:
-		return []string{}
-	}
-
 	possibleFieldNames := []string{}
-	for possibleFieldName := range fields {
+	for possibleFieldName := range schema.visibleFields(ttype) {
 		possibleFieldNames = append(possibleFieldNames, possibleFieldName)
 	}
-	return suggestionList(fieldName, possibleFieldNames)
+	return suggestions(schema, fieldName, possibleFieldNames)
 }
 
 // suggestedInterface an internal struct to sort interface by usage count
@@ -463,7 +457,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 							if fieldDef == nil {
 								return action, nil
 							}
-							for _, arg := range fieldDef.Args {
+							for _, arg := range context.Schema().visibleArgs(fieldDef) {
 								if arg.Name() == node.Name.Value {
 									fieldArgDef = arg
 									break
@@ -480,7 +474,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 									unknownArgMessage(
 										node.Name.Value,
 										fieldDef.Name,
-										parentTypeName, suggestionList(node.Name.Value, argNames),
+										parentTypeName, suggestions(context.Schema(), node.Name.Value, argNames),
 									),
 									[]ast.Node{node},
 								)
@@ -502,7 +496,7 @@ func KnownArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 									unknownDirectiveArgMessage(
 										node.Name.Value,
 										directive.Name,
-										suggestionList(node.Name.Value, argNames),
+										suggestions(context.Schema(), node.Name.Value, argNames),
 									),
 									[]ast.Node{node},
 								)
@@ -745,15 +739,12 @@ func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 						if typeName != nil {
 							typeNameValue = typeName.Value
 						}
-						ttype := context.Schema().Type(typeNameValue)
+						ttype := context.Schema().visibleType(typeNameValue)
 						if ttype == nil {
-							suggestedTypes := []string{}
-							for key := range context.Schema().TypeMap() {
-								suggestedTypes = append(suggestedTypes, key)
-							}
+							suggestedTypes := context.Schema().visibleTypeNames()
 							reportError(
 								context,
-								unknownTypeMessage(typeNameValue, suggestionList(typeNameValue, suggestedTypes)),
+								unknownTypeMessage(typeNameValue, suggestions(context.Schema(), typeNameValue, suggestedTypes)),
 								[]ast.Node{node},
 							)
 						}
@@ -1170,6 +1161,13 @@ func doTypesOverlap(schema *Schema, t1 Type, t2 Type) bool {
 			}
 			return false
 		}
+		// An interface overlaps with the interfaces it implements, even
+		// before any object implements it.
+		if t1, ok := t1.(*Interface); ok {
+			if t2, ok := t2.(*Interface); ok && (declaresInterface(t1, t2) || declaresInterface(t2, t1)) {
+				return true
+			}
+		}
 		t1TypeNames := map[string]bool{}
 		for _, ttype := range schema.PossibleTypes(t1) {
 			t1TypeNames[ttype.Name()] = true
@@ -1268,7 +1266,7 @@ func ProvidedNonNullArgumentsRule(context *ValidationContext) *ValidationRuleIns
 							}
 							argASTMap[name] = arg
 						}
-						for _, argDef := range fieldDef.Args {
+						for _, argDef := range context.Schema().visibleArgs(fieldDef) {
 							argAST, _ := argASTMap[argDef.Name()]
 							if argAST == nil {
 								if argDefType, ok := argDef.Type.(*NonNull); ok {
@@ -1429,6 +1427,53 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 	}
 }
 
+// UniqueDirectivesPerLocationRule Unique directive names per location
+//
+// A GraphQL document is only valid if all non-repeatable directives at
+// a given location are uniquely named.
+func UniqueDirectivesPerLocationRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			var directives []*ast.Directive
+			switch node := p.Node.(type) {
+			case *ast.OperationDefinition:
+				directives = node.Directives
+			case *ast.Field:
+				directives = node.Directives
+			case *ast.FragmentSpread:
+				directives = node.Directives
+			case *ast.InlineFragment:
+				directives = node.Directives
+			case *ast.FragmentDefinition:
+				directives = node.Directives
+			}
+			knownDirectives := map[string]*ast.Directive{}
+			for _, directive := range directives {
+				if directive == nil || directive.Name == nil {
+					continue
+				}
+				directiveName := directive.Name.Value
+				if directiveDef := context.Schema().Directive(directiveName); directiveDef == nil || directiveDef.IsRepeatable {
+					continue
+				}
+				if knownDirective, ok := knownDirectives[directiveName]; ok {
+					reportError(
+						context,
+						fmt.Sprintf(`The directive "%v" can only be used once at this location.`, directiveName),
+						[]ast.Node{knownDirective, directive},
+					)
+				} else {
+					knownDirectives[directiveName] = directive
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
 // UniqueFragmentNamesRule Unique fragment names
 //
 // A GraphQL document is only valid if all defined fragments have unique names.
@@ -1690,6 +1735,18 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 											`expecting type "%v".`, varName, varType, usage.Type),
 										[]ast.Node{varDef, usage.Node},
 									)
+									continue
+								}
+								// The fields of a OneOf input object are nullable, yet the
+								// one given must not be null.
+								parentType, _ := GetNamed(usage.ParentType).(*InputObject)
+								if _, ok := varType.(*NonNull); varType != nil && !ok && parentType != nil && parentType.IsOneOf() {
+									reportError(
+										context,
+										fmt.Sprintf(`Variable "$%v" is of type "%v" but must be non-nullable `+
+											`to be used for OneOf input object "%v".`, varName, varType, parentType),
+										[]ast.Node{varDef, usage.Node},
+									)
 								}
 							}
 						}
@@ -1724,7 +1781,7 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 //
 // Note that this only validates literal values, variables are assumed to
 // provide values of the correct type.
-func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
+func isValidLiteralValue(schema *Schema, ttype Input, valueAST ast.Value) (bool, []string) {
 	if _, ok := ttype.(*NonNull); !ok {
 		if valueAST == nil {
 			return true, nil
@@ -1749,28 +1806,28 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 			return false, []string{"Expected non-null value, found null."}
 		}
 		ofType, _ := ttype.OfType.(Input)
-		return isValidLiteralValue(ofType, valueAST)
+		return isValidLiteralValue(schema, ofType, valueAST)
 	case *List:
 		// Lists accept a non-list value as a list of one.
 		itemType, _ := ttype.OfType.(Input)
 		if valueAST, ok := valueAST.(*ast.ListValue); ok {
 			messagesReduce := []string{}
 			for _, value := range valueAST.Values {
-				_, messages := isValidLiteralValue(itemType, value)
+				_, messages := isValidLiteralValue(schema, itemType, value)
 				for idx, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
 				}
 			}
 			return (len(messagesReduce) == 0), messagesReduce
 		}
-		return isValidLiteralValue(itemType, valueAST)
+		return isValidLiteralValue(schema, itemType, valueAST)
 	case *InputObject:
 		// Input objects check each defined field and look for undefined fields.
 		valueAST, ok := valueAST.(*ast.ObjectValue)
 		if !ok {
 			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
 		}
-		fields := ttype.Fields()
+		fields := schema.visibleInputFields(ttype)
 		messagesReduce := []string{}
 
 		// Ensure every provided field is defined.
@@ -1783,13 +1840,16 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldAST.Name.Value))
 			}
 		}
+		if ttype.IsOneOf() && len(fieldASTs) != 1 {
+			messagesReduce = append(messagesReduce, fmt.Sprintf(`Exactly one key must be specified for OneOf type "%v".`, ttype.Name()))
+		}
 		// Ensure every defined field is valid.
 		for fieldName, field := range fields {
 			var fieldASTValue ast.Value
 			if fieldAST := fieldASTMap[fieldName]; fieldAST != nil {
 				fieldASTValue = fieldAST.Value
 			}
-			if isValid, messages := isValidLiteralValue(field.Type, fieldASTValue); !isValid {
+			if isValid, messages := isValidLiteralValue(schema, field.Type, fieldASTValue); !isValid {
 				for _, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf("In field \"%v\": %v", fieldName, message))
 				}
@@ -1797,11 +1857,15 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 		}
 		return (len(messagesReduce) == 0), messagesReduce
 	case *Scalar:
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		parsed := ttype.ParseLiteral(valueAST)
+		if err, ok := parsed.(error); ok {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found %v; %v`, ttype.Name(), printer.Print(valueAST), err.Error())}
+		}
+		if isNullish(parsed) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	case *Enum:
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		if isNullish(ttype.ParseLiteral(valueAST)) || !isEnumLiteralVisible(schema, ttype, valueAST) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	}
@@ -1809,6 +1873,63 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 	return true, nil
 }
 
+// isEnumLiteralVisible reports whether the enum value of a literal is visible.
+func isEnumLiteralVisible(schema *Schema, enum *Enum, valueAST ast.Value) bool {
+	enumValueAST, ok := valueAST.(*ast.EnumValue)
+	if !ok {
+		return true
+	}
+	return schema.isEnumNameVisible(enum, enumValueAST.Value)
+}
+
+// IntrospectionDisabledMessage is the message of the error reported by
+// NoIntrospectionRule.
+func IntrospectionDisabledMessage(fieldName string) string {
+	return fmt.Sprintf(`Cannot query field "%v": introspection is disabled.`, fieldName)
+}
+
+// NoIntrospectionRule No introspection
+//
+// A GraphQL document is only valid if it does not query the __schema and
+// __type introspection fields. The __typename field is still allowed.
+//
+// It is not one of the SpecifiedRules; it is used to disable introspection
+// with Params.DisableIntrospection.
+func NoIntrospectionRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Field)
+					if !ok {
+						return visitor.ActionNoChange, nil
+					}
+					if fieldDef := context.FieldDef(); fieldDef == SchemaMetaFieldDef || fieldDef == TypeMetaFieldDef {
+						return reportError(
+							context,
+							IntrospectionDisabledMessage(fieldDef.Name),
+							[]ast.Node{node},
+						)
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// suggestions returns the suggestionList of input, or no suggestions when the
+// schema hides them.
+func suggestions(schema *Schema, input string, options []string) []string {
+	if schema.hideSuggestions {
+		return []string{}
+	}
+	return suggestionList(input, options)
+}
+
 // Internal struct to sort results from suggestionList()
 type suggestionListResult struct {
 	Options   []string
@@ -799,3 +799,53 @@ func TestValidate_ArgValuesOfCorrectType_DirectiveArguments_WithDirectivesWithIn
 			),
 		})
 }
+func TestValidate_ArgValuesOfCorrectType_OneOfInputObject_ExactlyOneField(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            oneOfArgField(oneOfArg: { slug: "hello" })
+          }
+        }
+        `)
+}
+func TestValidate_ArgValuesOfCorrectType_OneOfInputObject_ExactlyOneVariableField(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        query ($id: ID!) {
+          complicatedArgs {
+            oneOfArgField(oneOfArg: { id: $id })
+          }
+        }
+        `)
+}
+func TestValidate_ArgValuesOfCorrectType_OneOfInputObject_NoFields(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            oneOfArgField(oneOfArg: {})
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				`Argument "oneOfArg" has invalid value {}.`+
+					"\nExactly one key must be specified for OneOf type \"OneOfInput\".",
+				4, 37,
+			),
+		})
+}
+func TestValidate_ArgValuesOfCorrectType_OneOfInputObject_MoreThanOneField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            oneOfArgField(oneOfArg: { id: "1", slug: "hello" })
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				`Argument "oneOfArg" has invalid value {id: "1", slug: "hello"}.`+
+					"\nExactly one key must be specified for OneOf type \"OneOfInput\".",
+				4, 37,
+			),
+		})
+}
@@ -0,0 +1,82 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+func TestValidate_NoIntrospection_TypenameIsValid(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.NoIntrospectionRule, `
+      {
+        __typename
+        dog { __typename name }
+      }
+    `)
+}
+func TestValidate_NoIntrospection_IntrospectionFieldsAreInvalid(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.NoIntrospectionRule, `
+      {
+        __schema { queryType { name } }
+        dog { name }
+        __type(name: "Dog") { name }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "__schema": introspection is disabled.`, 3, 9),
+		testutil.RuleError(`Cannot query field "__type": introspection is disabled.`, 5, 9),
+	})
+}
+
+var noIntrospectionTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "hello", nil
+				},
+			},
+		},
+	}),
+})
+
+func TestDisableIntrospectionAndHideSuggestions(t *testing.T) {
+	tests := []struct {
+		description     string
+		query           string
+		hideSuggestions bool
+		expected        *graphql.Result
+	}{
+		{
+			description:     "introspection and suggestions are rejected",
+			query:           `{ __typename __schema { types { name } } helo }`,
+			hideSuggestions: true,
+			expected: &graphql.Result{
+				Errors: []gqlerrors.FormattedError{
+					testutil.RuleError(`Cannot query field "__schema": introspection is disabled.`, 1, 14),
+					testutil.RuleError(`Cannot query field "helo" on type "Query".`, 1, 42),
+				},
+			},
+		},
+		{
+			description: "__typename is still allowed",
+			query:       `{ __typename hello }`,
+			expected:    &graphql.Result{Data: map[string]interface{}{"__typename": "Query", "hello": "hello"}},
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:               noIntrospectionTestSchema,
+			RequestString:        test.query,
+			DisableIntrospection: true,
+			HideSuggestions:      test.hideSuggestions,
+		})
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test %q - Diff: %v", test.description, testutil.Diff(test.expected, result))
+		}
+	}
+}
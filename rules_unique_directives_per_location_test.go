@@ -0,0 +1,104 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+func TestValidate_UniqueDirectivesPerLocation_NoDirectives(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) {
+        field @include(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInSameLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) @include(if: true) {
+        field @skip(if: true) @include(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) {
+        field @skip(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInSimilarLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true)
+        field @skip(if: true)
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_RepeatableDirectivesInSameLocation(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      query Test @repeatable @repeatable {
+        dog @repeatable @repeatable {
+          ...Frag @repeatable @repeatable
+        }
+      }
+      fragment Frag on Dog {
+        name
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UnknownDirectivesInSameLocation(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      {
+        field @unknown @unknown
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true) @skip(if: false)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 3, 15, 3, 31),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_ManyDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true) @skip(if: true) @skip(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 3, 15, 3, 31),
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 3, 15, 3, 47),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DifferentDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @skip(if: true) @include(if: true) @skip(if: true) @include(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 3, 15, 3, 50),
+		testutil.RuleError(`The directive "include" can only be used once at this location.`, 3, 31, 3, 66),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInManyLocations(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @skip(if: true) @skip(if: true) {
+        field @skip(if: true) @skip(if: true)
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 2, 29, 2, 45),
+		testutil.RuleError(`The directive "skip" can only be used once at this location.`, 3, 15, 3, 31),
+	})
+}
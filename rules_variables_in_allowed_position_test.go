@@ -244,3 +244,24 @@ func TestValidate_VariablesInAllowedPosition_StringToNonNullableBooleanInDirecti
 			`expecting type "Boolean!".`, 2, 19, 3, 26),
 	})
 }
+func TestValidate_VariablesInAllowedPosition_NonNullableVariableInOneOfField(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.VariablesInAllowedPositionRule, `
+      query Query($slug: String!) {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { slug: $slug })
+        }
+      }
+    `)
+}
+func TestValidate_VariablesInAllowedPosition_NullableVariableInOneOfField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.VariablesInAllowedPositionRule, `
+      query Query($slug: String) {
+        complicatedArgs {
+          oneOfArgField(oneOfArg: { slug: $slug })
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Variable "$slug" is of type "String" but must be non-nullable `+
+			`to be used for OneOf input object "OneOfInput".`, 2, 19, 4, 43),
+	})
+}
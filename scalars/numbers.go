@@ -0,0 +1,261 @@
+package scalars
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+)
+
+// Int64 represents a signed 64-bit integer. Int64 values are given as numbers
+// or as strings, since JSON numbers lose precision beyond 2^53, parsed into an
+// int64, and serialized as numbers from Go integers, integral floats and
+// strings.
+var Int64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Int64",
+	Description: "The `Int64` scalar type represents a signed 64-bit integer, given as a number or a string.",
+	Serialize:   parseInt64,
+	ParseValue:  parseInt64,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return integerLiteral("Int64", valueAST, parseInt64)
+	},
+})
+
+func parseInt64(value interface{}) interface{} {
+	i, ok := toBigInt(value)
+	if !ok {
+		return valueError("Int64", value, "expected an integer number or string")
+	}
+	if !i.IsInt64() {
+		return valueError("Int64", value, "expected an integer between -2^63 and 2^63 - 1")
+	}
+	return i.Int64()
+}
+
+// BigInt represents an integer of arbitrary size. BigInt values are given as
+// numbers or as strings, parsed into a *big.Int, and serialized as strings
+// from a big.Int, a *big.Int, Go integers, integral floats and strings.
+var BigInt = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "The `BigInt` scalar type represents an integer of arbitrary size, given as a number or a string and serialized as a string.",
+	Serialize:   serializeBigInt,
+	ParseValue:  parseBigInt,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return integerLiteral("BigInt", valueAST, parseBigInt)
+	},
+})
+
+func serializeBigInt(value interface{}) interface{} {
+	i, ok := parseBigInt(value).(*big.Int)
+	if !ok {
+		return valueError("BigInt", value, "expected a big.Int, an integer number or an integer string")
+	}
+	return i.String()
+}
+
+func parseBigInt(value interface{}) interface{} {
+	if i, ok := toBigInt(value); ok {
+		return i
+	}
+	return valueError("BigInt", value, "expected an integer number or string")
+}
+
+// integerLiteral parses an integer or string literal with parse, as a scalar
+// parses a value.
+func integerLiteral(scalar string, valueAST ast.Value, parse func(value interface{}) interface{}) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		return parse(valueAST.Value)
+	case *ast.StringValue:
+		return parse(valueAST.Value)
+	}
+	return literalError(scalar, valueAST, "expected an integer or a string")
+}
+
+// toBigInt converts an integer value to a *big.Int.
+func toBigInt(value interface{}) (*big.Int, bool) {
+	switch value := value.(type) {
+	case big.Int:
+		return new(big.Int).Set(&value), true
+	case *big.Int:
+		if value == nil {
+			return nil, false
+		}
+		return new(big.Int).Set(value), true
+	case int:
+		return big.NewInt(int64(value)), true
+	case int8:
+		return big.NewInt(int64(value)), true
+	case int16:
+		return big.NewInt(int64(value)), true
+	case int32:
+		return big.NewInt(int64(value)), true
+	case int64:
+		return big.NewInt(value), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(value)), true
+	case uint64:
+		return new(big.Int).SetUint64(value), true
+	case float32:
+		return toBigInt(float64(value))
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) || math.Trunc(value) != value {
+			return nil, false
+		}
+		i, _ := big.NewFloat(value).Int(nil)
+		return i, true
+	case json.Number:
+		return toBigInt(string(value))
+	case *string:
+		if value == nil {
+			return nil, false
+		}
+		return toBigInt(*value)
+	case string:
+		return new(big.Int).SetString(value, 10)
+	}
+	return nil, false
+}
+
+// Decimal represents an exact decimal number, such as 19.99 or -1.5e-3.
+// Decimal values are given as numbers or as strings, parsed into a *big.Rat,
+// and serialized as strings in positional notation, such as "19.99", from a
+// big.Rat, a *big.Rat, Go numbers and strings. Decimals are given as strings
+// to keep their precision, since JSON numbers are parsed as floats. They are
+// limited to 1000 digits and to exponents between -1000 and 1000.
+var Decimal = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "The `Decimal` scalar type represents an exact decimal number, given as a number or a string and serialized as a string.",
+	Serialize:   serializeDecimal,
+	ParseValue:  parseDecimal,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return parseDecimal(valueAST.Value)
+		case *ast.FloatValue:
+			return parseDecimal(valueAST.Value)
+		case *ast.StringValue:
+			return parseDecimal(valueAST.Value)
+		}
+		return literalError("Decimal", valueAST, "expected a number or a string")
+	},
+})
+
+func serializeDecimal(value interface{}) interface{} {
+	r, ok := parseDecimal(value).(*big.Rat)
+	if !ok {
+		return valueError("Decimal", value, "expected a big.Rat, a number or a decimal string")
+	}
+	digits, ok := decimalDigits(r)
+	if !ok {
+		return valueError("Decimal", value, "expected a number with a finite decimal expansion")
+	}
+	return r.FloatString(digits)
+}
+
+func parseDecimal(value interface{}) interface{} {
+	if r, ok := toRat(value); ok {
+		return r
+	}
+	return valueError("Decimal", value, "expected a decimal number or string")
+}
+
+// toRat converts a number value to a *big.Rat.
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch value := value.(type) {
+	case big.Rat:
+		return new(big.Rat).Set(&value), true
+	case *big.Rat:
+		if value == nil {
+			return nil, false
+		}
+		return new(big.Rat).Set(value), true
+	case float32:
+		return toRat(float64(value))
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, false
+		}
+		// The shortest representation of the float is the decimal it was
+		// most likely written as, rather than its exact binary value.
+		return new(big.Rat).SetString(big.NewFloat(value).Text('g', -1))
+	case json.Number:
+		return toRat(string(value))
+	case *string:
+		if value == nil {
+			return nil, false
+		}
+		return toRat(*value)
+	case string:
+		if !isDecimalString(value) {
+			return nil, false
+		}
+		return new(big.Rat).SetString(value)
+	}
+	if i, ok := toBigInt(value); ok {
+		return new(big.Rat).SetInt(i), true
+	}
+	return nil, false
+}
+
+// maxDecimalExponent and maxDecimalDigits bound the exponent and the digits of
+// decimal strings, since the exact value of a decimal such as 1e-300000 takes
+// unbounded time and memory to compute.
+const (
+	maxDecimalExponent = 1000
+	maxDecimalDigits   = 1000
+)
+
+// isDecimalString reports whether str is written as a decimal number, such as
+// -1.5e-3, within maxDecimalExponent and maxDecimalDigits. big.Rat also parses
+// fractions such as 1/3 and hexadecimal numbers, which are not decimals.
+func isDecimalString(str string) bool {
+	mantissa, exponent := str, "0"
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		mantissa, exponent = str[:i], str[i+1:]
+	}
+	if e, err := strconv.Atoi(exponent); err != nil || e < -maxDecimalExponent || e > maxDecimalExponent {
+		return false
+	}
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		mantissa = mantissa[1:]
+	}
+	if mantissa == "" || len(mantissa) > maxDecimalDigits {
+		return false
+	}
+	return strings.Trim(mantissa, "0123456789.") == ""
+}
+
+// decimalDigits returns the number of digits after the decimal point needed to
+// write r exactly, or false when its decimal expansion is infinite.
+func decimalDigits(r *big.Rat) (int, bool) {
+	twos := int(r.Denom().TrailingZeroBits())
+	denominator := new(big.Int).Rsh(r.Denom(), uint(twos))
+	fives := 0
+	five := big.NewInt(5)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for {
+		if quotient.QuoRem(denominator, five, remainder); remainder.Sign() != 0 {
+			break
+		}
+		denominator.Set(quotient)
+		fives++
+	}
+	if denominator.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
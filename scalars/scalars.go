@@ -0,0 +1,126 @@
+// Package scalars provides custom scalars beyond the ones of the GraphQL
+// specification: JSON, dates, times and durations, URLs, UUIDs, email
+// addresses, large integers, decimals and binary data.
+//
+// Each scalar parses its input values, from variables or from literals, into a
+// Go value and serializes Go values into their JSON representation. Invalid
+// values are rejected with an error explaining why, reported by validation
+// for input values and as the error of the field for output values:
+//
+//	"publishedOn": &graphql.Field{
+//		Type: scalars.Date,
+//		Args: graphql.FieldConfigArgument{
+//			"timeout": &graphql.ArgumentConfig{Type: scalars.Duration},
+//		},
+//	},
+//
+// Schemas built from SDL use the scalars given to graphql.BuildSchema in place
+// of the scalars they define with the same names:
+//
+//	schema, err := graphql.BuildSchema(sdl, scalars.Date, scalars.Duration)
+package scalars
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/printer"
+)
+
+// JSON represents any JSON value: objects, lists, strings, numbers, booleans
+// and null. Objects are parsed into map[string]interface{} and lists into
+// []interface{}.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "JSON",
+	Description:    "The `JSON` scalar type represents any JSON value.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc8259",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		value, err := parseJSONLiteral(valueAST)
+		if err != nil {
+			return literalError("JSON", valueAST, err.Error())
+		}
+		return value
+	},
+})
+
+// parseJSONLiteral returns the Go value of a literal.
+func parseJSONLiteral(valueAST ast.Value) (interface{}, error) {
+	switch valueAST := valueAST.(type) {
+	case *ast.StringValue:
+		return valueAST.Value, nil
+	case *ast.BooleanValue:
+		return valueAST.Value, nil
+	case *ast.IntValue:
+		if value, err := strconv.Atoi(valueAST.Value); err == nil {
+			return value, nil
+		}
+		return strconv.ParseFloat(valueAST.Value, 64)
+	case *ast.FloatValue:
+		return strconv.ParseFloat(valueAST.Value, 64)
+	case *ast.EnumValue:
+		return valueAST.Value, nil
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, itemAST := range valueAST.Values {
+			value, err := parseJSONLiteral(itemAST)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case *ast.ObjectValue:
+		values := map[string]interface{}{}
+		for _, fieldAST := range valueAST.Fields {
+			value, err := parseJSONLiteral(fieldAST.Value)
+			if err != nil {
+				return nil, err
+			}
+			values[fieldAST.Name.Value] = value
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("variables are not supported in JSON literals")
+}
+
+// valueError returns the error of a scalar rejecting a value.
+func valueError(scalar string, value interface{}, reason string) error {
+	if str, ok := value.(string); ok {
+		return fmt.Errorf("%v cannot represent %q: %v.", scalar, str, reason)
+	}
+	return fmt.Errorf("%v cannot represent %v: %v.", scalar, value, reason)
+}
+
+// literalError returns the error of a scalar rejecting a literal.
+func literalError(scalar string, valueAST ast.Value, reason string) error {
+	return fmt.Errorf("%v cannot represent %v: %v.", scalar, printer.Print(valueAST), reason)
+}
+
+// stringValue returns the value of a string, or of a pointer to a string.
+func stringValue(value interface{}) (string, bool) {
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case *string:
+		if value != nil {
+			return *value, true
+		}
+	}
+	return "", false
+}
+
+// stringLiteral parses a string literal with parse, as a scalar parses a value.
+func stringLiteral(scalar string, valueAST ast.Value, parse func(value interface{}) interface{}) interface{} {
+	if valueAST, ok := valueAST.(*ast.StringValue); ok {
+		return parse(valueAST.Value)
+	}
+	return literalError(scalar, valueAST, "expected a string")
+}
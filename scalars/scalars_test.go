@@ -0,0 +1,230 @@
+package scalars_test
+
+import (
+	"math"
+	"math/big"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+	"github.com/GannettDigital/graphql/language/parser"
+	"github.com/GannettDigital/graphql/scalars"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+type scalarTest struct {
+	scalar   *graphql.Scalar
+	value    interface{}
+	expected interface{}
+}
+
+func errorMessage(value interface{}) interface{} {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return value
+}
+
+func TestScalars_ParseValue(t *testing.T) {
+	exampleURL, _ := url.Parse("https://example.com/a?b=c")
+	tests := []scalarTest{
+		{scalars.JSON, map[string]interface{}{"a": []interface{}{1.0}}, map[string]interface{}{"a": []interface{}{1.0}}},
+		{scalars.Date, "2021-10-31", time.Date(2021, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{scalars.Date, "2021-02-30", `Date cannot represent "2021-02-30": expected a valid YYYY-MM-DD date.`},
+		{scalars.Date, 20211031, `Date cannot represent 20211031: expected a YYYY-MM-DD string.`},
+		{scalars.Time, "14:30:00.5+02:00", time.Date(0, 1, 1, 14, 30, 0, 500000000, time.FixedZone("", 2*60*60))},
+		{scalars.Time, "14:30:00", time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{scalars.Time, "25:00:00", `Time cannot represent "25:00:00": expected a valid hh:mm:ss time.`},
+		{scalars.Duration, "P1DT12H30M0.5S", 36*time.Hour + 30*time.Minute + 500*time.Millisecond},
+		{scalars.Duration, "-PT90S", -90 * time.Second},
+		{scalars.Duration, "P1W", 7 * 24 * time.Hour},
+		{scalars.Duration, "P1M", `Duration cannot represent "P1M": years and months have no fixed duration.`},
+		{scalars.Duration, "P1DT", `Duration cannot represent "P1DT": expected hours, minutes or seconds after T.`},
+		{scalars.Duration, "P", `Duration cannot represent "P": expected at least one component.`},
+		{scalars.Duration, "PT1H1H", `Duration cannot represent "PT1H1H": designator 'H' is repeated or out of order.`},
+		{scalars.Duration, "PT1S1H", `Duration cannot represent "PT1S1H": designator 'H' is repeated or out of order.`},
+		{scalars.Duration, "-PT2562047H47M16.854775808S", time.Duration(math.MinInt64)},
+		{scalars.Duration, "PT2562047H47M16.854775808S", `Duration cannot represent "PT2562047H47M16.854775808S": expected a duration between -2^63 and 2^63 - 1 nanoseconds.`},
+		{scalars.Duration, "P100000W", `Duration cannot represent "P100000W": expected a duration between -2^63 and 2^63 - 1 nanoseconds.`},
+		{scalars.URL, "https://example.com/a?b=c", exampleURL},
+		{scalars.URL, "/a?b=c", `URL cannot represent "/a?b=c": expected an absolute URL.`},
+		{scalars.UUID, "123E4567-E89B-12D3-A456-426614174000", "123e4567-e89b-12d3-a456-426614174000"},
+		{scalars.UUID, "123e4567e89b12d3a456426614174000", `UUID cannot represent "123e4567e89b12d3a456426614174000": expected 32 hexadecimal digits in groups of 8-4-4-4-12.`},
+		{scalars.Email, "jane@example.com", "jane@example.com"},
+		{scalars.Email, "Jane <jane@example.com>", `Email cannot represent "Jane <jane@example.com>": expected an email address such as jane@example.com.`},
+		{scalars.Int64, "9223372036854775807", int64(9223372036854775807)},
+		{scalars.Int64, 42.0, int64(42)},
+		{scalars.Int64, "9223372036854775808", `Int64 cannot represent "9223372036854775808": expected an integer between -2^63 and 2^63 - 1.`},
+		{scalars.Int64, 4.2, `Int64 cannot represent 4.2: expected an integer number or string.`},
+		{scalars.BigInt, "123456789012345678901234567890", bigInt("123456789012345678901234567890")},
+		{scalars.Decimal, "19.99", big.NewRat(1999, 100)},
+		{scalars.Decimal, 0.1, big.NewRat(1, 10)},
+		{scalars.Decimal, "1/3", `Decimal cannot represent "1/3": expected a decimal number or string.`},
+		{scalars.Decimal, "1e-1000", new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(1000), nil))},
+		{scalars.Decimal, "1e-300000", `Decimal cannot represent "1e-300000": expected a decimal number or string.`},
+		{scalars.Decimal, "0x1p-3", `Decimal cannot represent "0x1p-3": expected a decimal number or string.`},
+		{scalars.Base64, "aGVsbG8=", []byte("hello")},
+		{scalars.Base64, "aGVsbG8", `Base64 cannot represent "aGVsbG8": expected valid standard base64.`},
+	}
+	for _, test := range tests {
+		if val := errorMessage(test.scalar.ParseValue(test.value)); !reflect.DeepEqual(val, test.expected) {
+			t.Errorf("%v.ParseValue(%#v) - Diff: %v", test.scalar.Name(), test.value, testutil.Diff(test.expected, val))
+		}
+	}
+}
+
+func TestScalars_Serialize(t *testing.T) {
+	exampleURL, _ := url.Parse("https://example.com/a?b=c")
+	tests := []scalarTest{
+		{scalars.Date, time.Date(2021, 10, 31, 23, 0, 0, 0, time.UTC), "2021-10-31"},
+		{scalars.Date, "2021-10-31", "2021-10-31"},
+		{scalars.Date, "yesterday", `Date cannot represent "yesterday": expected a time.Time or a YYYY-MM-DD string.`},
+		{scalars.Time, time.Date(2021, 10, 31, 14, 30, 0, 0, time.UTC), "14:30:00Z"},
+		{scalars.Duration, 36*time.Hour + 90*time.Second + 500*time.Millisecond, "PT36H1M30.5S"},
+		{scalars.Duration, time.Duration(0), "PT0S"},
+		{scalars.Duration, "P1D", "PT24H"},
+		{scalars.Duration, time.Duration(math.MinInt64), "-PT2562047H47M16.854775808S"},
+		{scalars.URL, exampleURL, "https://example.com/a?b=c"},
+		{scalars.UUID, [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}, "123e4567-e89b-12d3-a456-426614174000"},
+		{scalars.Int64, int64(-9223372036854775808), int64(-9223372036854775808)},
+		{scalars.Int64, uint64(18446744073709551615), `Int64 cannot represent 18446744073709551615: expected an integer between -2^63 and 2^63 - 1.`},
+		{scalars.BigInt, uint64(18446744073709551615), "18446744073709551615"},
+		{scalars.Decimal, big.NewRat(-3, 8), "-0.375"},
+		{scalars.Decimal, big.NewRat(5, 1), "5"},
+		{scalars.Decimal, big.NewRat(1, 3), `Decimal cannot represent 1/3: expected a number with a finite decimal expansion.`},
+		{scalars.Base64, []byte("hello"), "aGVsbG8="},
+	}
+	for _, test := range tests {
+		if val := errorMessage(test.scalar.Serialize(test.value)); !reflect.DeepEqual(val, test.expected) {
+			t.Errorf("%v.Serialize(%#v) - Diff: %v", test.scalar.Name(), test.value, testutil.Diff(test.expected, val))
+		}
+	}
+}
+
+func TestScalars_ParseLiteral(t *testing.T) {
+	tests := []struct {
+		scalar   *graphql.Scalar
+		literal  string
+		expected interface{}
+	}{
+		{scalars.JSON, `{a: [1, 2.5, "b", true, ENUM], c: {d: []}}`, map[string]interface{}{
+			"a": []interface{}{1, 2.5, "b", true, "ENUM"},
+			"c": map[string]interface{}{"d": []interface{}{}},
+		}},
+		{scalars.JSON, `{a: $b}`, `JSON cannot represent {a: $b}: variables are not supported in JSON literals.`},
+		{scalars.Date, `"2021-10-31"`, time.Date(2021, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{scalars.Date, `20211031`, `Date cannot represent 20211031: expected a string.`},
+		{scalars.Int64, `-42`, int64(-42)},
+		{scalars.Int64, `"-42"`, int64(-42)},
+		{scalars.Int64, `4.2`, `Int64 cannot represent 4.2: expected an integer or a string.`},
+		{scalars.BigInt, `123456789012345678901234567890`, bigInt("123456789012345678901234567890")},
+		{scalars.Decimal, `1.5e-3`, big.NewRat(3, 2000)},
+	}
+	for _, test := range tests {
+		valueAST, err := parser.ParseValue(parser.ParseParams{Source: test.literal})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if val := errorMessage(test.scalar.ParseLiteral(valueAST)); !reflect.DeepEqual(val, test.expected) {
+			t.Errorf("%v.ParseLiteral(%v) - Diff: %v", test.scalar.Name(), test.literal, testutil.Diff(test.expected, val))
+		}
+	}
+	if val := errorMessage(scalars.UUID.ParseLiteral(ast.NewIntValue(&ast.IntValue{Value: "1"}))); val != `UUID cannot represent 1: expected a string.` {
+		t.Errorf("unexpected UUID.ParseLiteral result: %v", val)
+	}
+}
+
+func TestScalars_ReportsErrorsOfInvalidValues(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"dayAfter": &graphql.Field{
+					Type: scalars.Date,
+					Args: graphql.FieldConfigArgument{
+						"date": &graphql.ArgumentConfig{Type: scalars.Date},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if date, ok := p.Args["date"].(time.Time); ok {
+							return date.AddDate(0, 0, 1), nil
+						}
+						return "tomorrow", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ dayAfter(date: "2021-12-31") }`})
+	expected := &graphql.Result{Data: map[string]interface{}{"dayAfter": "2022-01-01"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ dayAfter(date: "2021-12-32") }`})
+	message := "Argument \"date\" has invalid value \"2021-12-32\".\n" +
+		"Expected type \"Date\", found \"2021-12-32\"; Date cannot represent \"2021-12-32\": expected a valid YYYY-MM-DD date."
+	if len(result.Errors) != 1 || result.Errors[0].Message != message {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query ($date: Date) { dayAfter(date: $date) }`,
+		VariableValues: map[string]interface{}{"date": "31/12/2021"},
+	})
+	message = "Variable \"$date\" got invalid value \"31/12/2021\".\n" +
+		"Expected type \"Date\", found \"31/12/2021\"; Date cannot represent \"31/12/2021\": expected a valid YYYY-MM-DD date."
+	if len(result.Errors) != 1 || result.Errors[0].Message != message {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ dayAfter }`})
+	if result.Data.(map[string]interface{})["dayAfter"] != nil || len(result.Errors) != 1 ||
+		result.Errors[0].Message != `Date cannot represent "tomorrow": expected a time.Time or a YYYY-MM-DD string.` {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestScalars_ReplaceScalarsOfSchemasBuiltFromSDL(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+		scalar Date
+		scalar Duration
+
+		type Query {
+			echo(date: Date, timeout: Duration): Duration
+		}
+	`, scalars.Date, scalars.Duration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Type("Date") != scalars.Date || schema.Type("Duration") != scalars.Duration {
+		t.Fatalf("expected the scalars to replace the scalars defined by the SDL")
+	}
+	schema.QueryType().Fields()["echo"].Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		return p.Args["timeout"], nil
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ echo(timeout: "P1D") }`})
+	expected := &graphql.Result{Data: map[string]interface{}{"echo": "PT24H"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ echo(date: "2021-12-32") }`})
+	message := "Argument \"date\" has invalid value \"2021-12-32\".\n" +
+		"Expected type \"Date\", found \"2021-12-32\"; Date cannot represent \"2021-12-32\": expected a valid YYYY-MM-DD date."
+	if len(result.Errors) != 1 || result.Errors[0].Message != message {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func bigInt(str string) *big.Int {
+	i, _ := new(big.Int).SetString(str, 10)
+	return i
+}
@@ -0,0 +1,152 @@
+package scalars
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+)
+
+// URL represents an absolute URL. URLs are parsed into a *url.URL, and
+// serialized from a url.URL, a *url.URL or a string.
+var URL = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "URL",
+	Description:    "The `URL` scalar type represents an absolute URL.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc3986",
+	Serialize:      serializeURL,
+	ParseValue:     parseURL,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("URL", valueAST, parseURL)
+	},
+})
+
+func serializeURL(value interface{}) interface{} {
+	switch value := value.(type) {
+	case url.URL:
+		return value.String()
+	case *url.URL:
+		if value != nil {
+			return value.String()
+		}
+		return nil
+	}
+	if u, ok := parseURL(value).(*url.URL); ok {
+		return u.String()
+	}
+	return valueError("URL", value, "expected a url.URL or an absolute URL string")
+}
+
+func parseURL(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("URL", value, "expected an absolute URL string")
+	}
+	u, err := url.Parse(str)
+	if err != nil || !u.IsAbs() {
+		return valueError("URL", value, "expected an absolute URL")
+	}
+	return u
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUID represents a UUID formatted as 32 hexadecimal digits in groups of 8, 4,
+// 4, 4 and 12 separated by hyphens. UUIDs are parsed into a lower case string,
+// and serialized from a string or from a [16]byte.
+var UUID = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "UUID",
+	Description:    "The `UUID` scalar type represents a UUID, such as 123e4567-e89b-12d3-a456-426614174000.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc4122",
+	Serialize:      serializeUUID,
+	ParseValue:     parseUUID,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("UUID", valueAST, parseUUID)
+	},
+})
+
+func serializeUUID(value interface{}) interface{} {
+	if b, ok := value.([16]byte); ok {
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	}
+	if _, ok := stringValue(value); !ok {
+		return valueError("UUID", value, "expected a [16]byte or a UUID string")
+	}
+	return parseUUID(value)
+}
+
+func parseUUID(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("UUID", value, "expected a UUID string")
+	}
+	if !uuidPattern.MatchString(str) {
+		return valueError("UUID", value, "expected 32 hexadecimal digits in groups of 8-4-4-4-12")
+	}
+	return strings.ToLower(str)
+}
+
+// Email represents an email address, such as jane@example.com, without a
+// display name. Email addresses are parsed into a string, and serialized from
+// a string.
+var Email = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Email",
+	Description:    "The `Email` scalar type represents an email address, such as jane@example.com.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc5322#section-3.4.1",
+	Serialize:      parseEmail,
+	ParseValue:     parseEmail,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("Email", valueAST, parseEmail)
+	},
+})
+
+func parseEmail(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("Email", value, "expected an email address string")
+	}
+	address, err := mail.ParseAddress(str)
+	if err != nil || address.Name != "" || address.Address != str {
+		return valueError("Email", value, "expected an email address such as jane@example.com")
+	}
+	return str
+}
+
+// Base64 represents binary data encoded in standard, padded base64. Data is
+// parsed into a []byte, and serialized by encoding a []byte or a string.
+var Base64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Base64",
+	Description:    "The `Base64` scalar type represents binary data encoded in standard base64.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc4648#section-4",
+	Serialize:      serializeBase64,
+	ParseValue:     parseBase64,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("Base64", valueAST, parseBase64)
+	},
+})
+
+func serializeBase64(value interface{}) interface{} {
+	if data, ok := value.([]byte); ok {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+	if str, ok := stringValue(value); ok {
+		return base64.StdEncoding.EncodeToString([]byte(str))
+	}
+	return valueError("Base64", value, "expected a []byte or a string")
+}
+
+func parseBase64(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("Base64", value, "expected a base64 string")
+	}
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return valueError("Base64", value, "expected valid standard base64")
+	}
+	return data
+}
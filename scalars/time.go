@@ -0,0 +1,262 @@
+package scalars
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// timeLayout and localTimeLayout parse times with an optional fraction of
+	// a second, with and without an offset.
+	timeLayout      = "15:04:05Z07:00"
+	localTimeLayout = "15:04:05"
+	timeFormat      = "15:04:05.999999999Z07:00"
+)
+
+// Date represents a calendar date formatted as YYYY-MM-DD. Dates are parsed
+// into a time.Time at midnight UTC, and serialized from a time.Time in its own
+// location or from a string.
+var Date = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Date",
+	Description:    "The `Date` scalar type represents a calendar date formatted as YYYY-MM-DD.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc3339#section-5.6",
+	Serialize:      serializeDate,
+	ParseValue:     parseDate,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("Date", valueAST, parseDate)
+	},
+})
+
+func serializeDate(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Time:
+		return value.Format(dateLayout)
+	case *time.Time:
+		if value != nil {
+			return value.Format(dateLayout)
+		}
+		return nil
+	}
+	if date, ok := parseDate(value).(time.Time); ok {
+		return date.Format(dateLayout)
+	}
+	return valueError("Date", value, "expected a time.Time or a YYYY-MM-DD string")
+}
+
+func parseDate(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("Date", value, "expected a YYYY-MM-DD string")
+	}
+	date, err := time.Parse(dateLayout, str)
+	if err != nil {
+		return valueError("Date", value, "expected a valid YYYY-MM-DD date")
+	}
+	return date
+}
+
+// Time represents a time of day formatted as hh:mm:ss, with an optional
+// fraction of a second and an optional offset from UTC, such as 14:30:00 or
+// 14:30:00.5+02:00. Times are parsed into a time.Time on January 1 of year 0,
+// in UTC when they have no offset, and serialized from a time.Time or from a
+// string.
+var Time = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Time",
+	Description:    "The `Time` scalar type represents a time of day formatted as hh:mm:ss, with an optional fraction of a second and offset.",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc3339#section-5.6",
+	Serialize:      serializeTime,
+	ParseValue:     parseTime,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("Time", valueAST, parseTime)
+	},
+})
+
+func serializeTime(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Time:
+		return value.Format(timeFormat)
+	case *time.Time:
+		if value != nil {
+			return value.Format(timeFormat)
+		}
+		return nil
+	}
+	if t, ok := parseTime(value).(time.Time); ok {
+		return t.Format(timeFormat)
+	}
+	return valueError("Time", value, "expected a time.Time or an hh:mm:ss string")
+}
+
+func parseTime(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("Time", value, "expected an hh:mm:ss string")
+	}
+	for _, layout := range []string{timeLayout, localTimeLayout} {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t
+		}
+	}
+	return valueError("Time", value, "expected a valid hh:mm:ss time")
+}
+
+// Duration represents an ISO 8601 duration such as P1DT12H or PT0.5S, in
+// weeks, days, hours, minutes and seconds, optionally negated with a leading
+// minus sign. Years and months are rejected since they have no fixed length.
+// Durations are parsed into a time.Duration, days being 24 hours long, and
+// serialized from a time.Duration or from a string. Durations beyond the range
+// of a time.Duration, about 292 years, are rejected.
+var Duration = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Duration",
+	Description: "The `Duration` scalar type represents an ISO 8601 duration, such as P1DT12H.",
+	Serialize:   serializeDuration,
+	ParseValue:  parseDurationValue,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return stringLiteral("Duration", valueAST, parseDurationValue)
+	},
+})
+
+func serializeDuration(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Duration:
+		return formatDuration(value)
+	case *time.Duration:
+		if value != nil {
+			return formatDuration(*value)
+		}
+		return nil
+	}
+	if d, ok := parseDurationValue(value).(time.Duration); ok {
+		return formatDuration(d)
+	}
+	return valueError("Duration", value, "expected a time.Duration or an ISO 8601 duration string")
+}
+
+func parseDurationValue(value interface{}) interface{} {
+	str, ok := stringValue(value)
+	if !ok {
+		return valueError("Duration", value, "expected an ISO 8601 duration string")
+	}
+	d, err := parseDuration(str)
+	if err != nil {
+		return valueError("Duration", value, err.Error())
+	}
+	return d
+}
+
+// durationUnits are the lengths of the designators of durations, before and
+// after the T separating the date from the time.
+var durationUnits = []map[byte]time.Duration{
+	{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour},
+	{'H': time.Hour, 'M': time.Minute, 'S': time.Second},
+}
+
+// durationDesignators are the designators of durations in the order they are
+// written, before and after the T.
+var durationDesignators = []string{"WD", "HMS"}
+
+// parseDuration parses an ISO 8601 duration.
+func parseDuration(str string) (time.Duration, error) {
+	s := str
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("expected an ISO 8601 duration starting with P")
+	}
+	s = s[1:]
+
+	// The duration is summed as a magnitude, which may reach 2^63 when it is
+	// negated.
+	var total uint64
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	part := 0
+	last := -1
+	components := 0
+	for s != "" {
+		if s[0] == 'T' {
+			if part == 1 || len(s) == 1 {
+				return 0, fmt.Errorf("expected hours, minutes or seconds after T")
+			}
+			part = 1
+			last = -1
+			s = s[1:]
+			continue
+		}
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if end <= 0 {
+			return 0, fmt.Errorf("expected a number before each designator")
+		}
+		number, err := strconv.ParseFloat(s[:end], 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number before each designator")
+		}
+		designator := s[end]
+		unit, ok := durationUnits[part][designator]
+		if !ok {
+			if part == 0 && (designator == 'Y' || designator == 'M') {
+				return 0, fmt.Errorf("years and months have no fixed duration")
+			}
+			return 0, fmt.Errorf("unexpected designator %q", designator)
+		}
+		index := strings.IndexByte(durationDesignators[part], designator)
+		if index <= last {
+			return 0, fmt.Errorf("designator %q is repeated or out of order", designator)
+		}
+		last = index
+		value := number * float64(unit)
+		if value > float64(limit) || uint64(value) > limit-total {
+			return 0, fmt.Errorf("expected a duration between -2^63 and 2^63 - 1 nanoseconds")
+		}
+		total += uint64(value)
+		components++
+		s = s[end+1:]
+	}
+	if components == 0 {
+		return 0, fmt.Errorf("expected at least one component")
+	}
+	d := time.Duration(total)
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// formatDuration formats a duration in hours, minutes and seconds, such as
+// PT36H or -PT1M30.5S.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	// The magnitude of the duration is taken as a uint64, since the negation
+	// of math.MinInt64 overflows an int64.
+	str, n := "PT", uint64(d)
+	if d < 0 {
+		str, n = "-PT", uint64(-d)
+	}
+	hour, minute, second := uint64(time.Hour), uint64(time.Minute), uint64(time.Second)
+	if hours := n / hour; hours > 0 {
+		str += fmt.Sprintf("%dH", hours)
+	}
+	if minutes := n % hour / minute; minutes > 0 {
+		str += fmt.Sprintf("%dM", minutes)
+	}
+	seconds, nanoseconds := n%minute/second, n%second
+	if nanoseconds > 0 {
+		str += strings.TrimRight(fmt.Sprintf("%d.%09d", seconds, nanoseconds), "0") + "S"
+	} else if seconds > 0 {
+		str += fmt.Sprintf("%dS", seconds)
+	}
+	return str
+}
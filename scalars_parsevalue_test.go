@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/language/ast"
 )
 
 func TestTypeSystem_Scalar_ParseValueOutputDateTime(t *testing.T) {
@@ -24,3 +25,21 @@ func TestTypeSystem_Scalar_ParseValueOutputDateTime(t *testing.T) {
 		}
 	}
 }
+
+func TestTypeSystem_Scalar_ParseLiteralOutputDateTime(t *testing.T) {
+	t1, _ := time.Parse(time.RFC3339, "2017-07-23T03:46:56.647Z")
+	tests := []struct {
+		Value    ast.Value
+		Expected interface{}
+	}{
+		{ast.NewStringValue(&ast.StringValue{Value: "2017-07-23T03:46:56.647Z"}), t1},
+		{ast.NewStringValue(&ast.StringValue{Value: "2017-07-23"}), nil},
+		{ast.NewIntValue(&ast.IntValue{Value: "1"}), nil},
+	}
+	for _, test := range tests {
+		val := graphql.DateTime.ParseLiteral(test.Value)
+		if val != test.Expected {
+			t.Fatalf("failed DateTime.ParseLiteral(%v), expected: %v, got %v", test.Value, test.Expected, val)
+		}
+	}
+}
@@ -6,11 +6,15 @@ import (
 )
 
 type SchemaConfig struct {
+	// Description describes the schema, as exposed by __Schema.description.
+	Description  string
 	Query        *Object
 	Mutation     *Object
 	Subscription *Object
 	Types        []Type
 	Directives   []*Directive
+	// AppliedDirectives are the directives applied to the schema itself.
+	AppliedDirectives []*AppliedDirective
 }
 
 type TypeMap map[string]Type
@@ -36,8 +40,10 @@ type TypeMap map[string]Type
 //       directives: specifiedDirectives.concat([ myCustomDirective ]),
 //     })
 type Schema struct {
-	typeMap    TypeMap
-	directives []*Directive
+	description       string
+	typeMap           TypeMap
+	directives        []*Directive
+	appliedDirectives []*AppliedDirective
 
 	queryType        *Object
 	mutationType     *Object
@@ -46,26 +52,27 @@ type Schema struct {
 	possibleTypeMap  map[string]map[string]bool
 
 	mu *sync.Mutex
+
+	// visibility hides elements of the copies of the schema executing a
+	// request with Params.Visibility.
+	visibility *schemaVisibility
+
+	// hideSuggestions strips the suggestions from the validation errors of
+	// the copies of the schema validating a request with
+	// Params.HideSuggestions.
+	hideSuggestions bool
 }
 
+// NewSchema builds a schema from its configuration. When the schema breaks the
+// rules of the type system, the returned error is a SchemaErrors listing every
+// violation, as reported by ValidateSchema.
 func NewSchema(config SchemaConfig) (Schema, error) {
-	var err error
-
 	schema := Schema{mu: &sync.Mutex{}}
+	errs := SchemaErrors{}
 
-	if err = invariant(config.Query != nil, "Schema query must be Object Type but got: nil."); err != nil {
-		return schema, err
-	}
-
-	// if schema config contains error at creation time, return those errors
-	if config.Query != nil && config.Query.err != nil {
-		return schema, config.Query.err
-	}
-	if config.Mutation != nil && config.Mutation.err != nil {
-		return schema, config.Mutation.err
-	}
-
+	schema.description = config.Description
 	schema.queryType = config.Query
+	schema.appliedDirectives = config.AppliedDirectives
 	schema.mutationType = config.Mutation
 	schema.subscriptionType = config.Subscription
 
@@ -74,12 +81,6 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	if len(schema.directives) == 0 {
 		schema.directives = SpecifiedDirectives
 	}
-	// Ensure directive definitions are error-free
-	for _, dir := range schema.directives {
-		if dir.err != nil {
-			return schema, dir.err
-		}
-	}
 
 	// Build type map now to detect any errors within this schema.
 	typeMap := TypeMap{}
@@ -103,11 +104,12 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	}
 
 	for _, ttype := range initialTypes {
-		if ttype.Error() != nil {
-			return schema, ttype.Error()
-		}
-		if typeMap, err = typeMapReducer(&schema, typeMap, ttype); err != nil {
-			return schema, err
+		typeMap = typeMapReducer(&schema, typeMap, ttype, &errs)
+	}
+	// Arguments of directives may be given as variables, so their types are part of the schema.
+	for _, directive := range schema.directives {
+		for _, arg := range directive.Args {
+			typeMap = typeMapReducer(&schema, typeMap, arg.Type, &errs)
 		}
 	}
 
@@ -131,19 +133,10 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
-	// Enforce correct interface implementations
-	for _, ttype := range schema.typeMap {
-		if ttype, ok := ttype.(*Object); ok {
-			for _, iface := range ttype.Interfaces() {
-				err := assertObjectImplementsInterface(&schema, ttype, iface)
-				if err != nil {
-					return schema, err
-				}
-			}
-		}
-	}
+	// Enforce the type system rules, including correct interface implementations.
+	errs = append(errs, ValidateSchema(&schema)...)
 
-	return schema, nil
+	return schema, errs.err()
 }
 
 //Added Check implementation of interfaces at runtime..
@@ -171,7 +164,7 @@ func (gq *Schema) AddImplementation() error {
 	for _, ttype := range gq.typeMap {
 		if ttype, ok := ttype.(*Object); ok {
 			for _, iface := range ttype.Interfaces() {
-				err := assertObjectImplementsInterface(gq, ttype, iface)
+				err := assertImplementsInterface(gq, ttype, iface)
 				if err != nil {
 					return err
 				}
@@ -188,13 +181,21 @@ func (gq *Schema) AppendType(objectType Type) error {
 	if objectType.Error() != nil {
 		return objectType.Error()
 	}
-	var err error
-	gq.typeMap, err = typeMapReducer(gq, gq.typeMap, objectType)
-	if err != nil {
+	errs := SchemaErrors{}
+	gq.typeMap = typeMapReducer(gq, gq.typeMap, objectType, &errs)
+	if err := errs.err(); err != nil {
 		return err
 	}
 	//Now Add interface implementation..
-	return gq.AddImplementation()
+	if err := gq.AddImplementation(); err != nil {
+		return err
+	}
+	return ValidateSchema(gq).err()
+}
+
+// Description returns the description of the schema.
+func (gq *Schema) Description() string {
+	return gq.description
 }
 
 func (gq *Schema) QueryType() *Object {
@@ -222,6 +223,11 @@ func (gq *Schema) Directive(name string) *Directive {
 	return nil
 }
 
+// AppliedDirectives returns the directives applied to the schema itself.
+func (gq *Schema) AppliedDirectives() []*AppliedDirective {
+	return gq.appliedDirectives
+}
+
 func (gq *Schema) TypeMap() TypeMap {
 	gq.mu.Lock()
 	defer gq.mu.Unlock()
@@ -278,147 +284,138 @@ func (gq *Schema) IsPossibleType(abstractType Abstract, possibleType *Object) bo
 	return false
 }
 
-func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap, error) {
-	var err error
-	if objectType == nil || objectType.Name() == "" {
-		return typeMap, nil
+// typeMapReducer adds objectType and the types it references to typeMap. Types
+// which failed to build are added without their references and are reported
+// by ValidateSchema, only names shared by distinct types are recorded in errs.
+func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type, errs *SchemaErrors) TypeMap {
+	if objectType == nil {
+		return typeMap
+	}
+	// A type which failed before being named never reaches the type map, so
+	// its error is recorded here rather than by ValidateSchema.
+	if objectType.Name() == "" {
+		errs.add("", objectType.Error())
+		return typeMap
 	}
 
 	// first:
 	switch objectType := objectType.(type) {
 	case *List:
 		if objectType.OfType != nil {
-			return typeMapReducer(schema, typeMap, objectType.OfType)
+			return typeMapReducer(schema, typeMap, objectType.OfType, errs)
 		}
 	case *NonNull:
 		if objectType.OfType != nil {
-			return typeMapReducer(schema, typeMap, objectType.OfType)
-		}
-	case *Object:
-		if objectType.err != nil {
-			return typeMap, objectType.err
+			return typeMapReducer(schema, typeMap, objectType.OfType, errs)
 		}
 	}
 
 	if mappedObjectType, ok := typeMap[objectType.Name()]; ok {
-		err = invariantf(
+		errs.add(objectType.Name(), invariantf(
 			mappedObjectType == objectType,
-			`Schema must contain unique named types but contains multiple types named "%v".`, objectType.Name())
-		return typeMap, err
+			`Schema must contain unique named types but contains multiple types named "%v".`, objectType.Name()))
+		return typeMap
 	}
 	typeMap[objectType.Name()] = objectType
 
 	// second:
+	referencedTypes := []Type{}
 	switch objectType := objectType.(type) {
 	case *Union, *Interface:
-		types := schema.PossibleTypes(objectType)
-		if objectType.Error() != nil {
-			return typeMap, objectType.Error()
-		}
-		for _, innerObjectType := range types {
-			if innerObjectType.err != nil {
-				return typeMap, innerObjectType.err
-			}
-			if typeMap, err = typeMapReducer(schema, typeMap, innerObjectType); err != nil {
-				return typeMap, err
-			}
+		for _, innerObjectType := range schema.PossibleTypes(objectType) {
+			referencedTypes = append(referencedTypes, innerObjectType)
 		}
 	case *Object:
-		interfaces := objectType.Interfaces()
-		if objectType.err != nil {
-			return typeMap, objectType.err
+		for _, innerObjectType := range objectType.Interfaces() {
+			referencedTypes = append(referencedTypes, innerObjectType)
 		}
-		for _, innerObjectType := range interfaces {
-			if innerObjectType.err != nil {
-				return typeMap, innerObjectType.err
-			}
-			if typeMap, err = typeMapReducer(schema, typeMap, innerObjectType); err != nil {
-				return typeMap, err
-			}
+	}
+	if objectType, ok := objectType.(*Interface); ok {
+		for _, innerObjectType := range objectType.Interfaces() {
+			referencedTypes = append(referencedTypes, innerObjectType)
 		}
 	}
 
 	switch objectType := objectType.(type) {
 	case *Object:
-		fieldMap := objectType.Fields()
-		if objectType.err != nil {
-			return typeMap, objectType.err
-		}
-		for _, field := range fieldMap {
+		for _, field := range objectType.Fields() {
 			for _, arg := range field.Args {
-				typeMap, err = typeMapReducer(schema, typeMap, arg.Type)
-				if err != nil {
-					return typeMap, err
-				}
-			}
-			typeMap, err = typeMapReducer(schema, typeMap, field.Type)
-			if err != nil {
-				return typeMap, err
+				referencedTypes = append(referencedTypes, arg.Type)
 			}
+			referencedTypes = append(referencedTypes, field.Type)
 		}
 	case *Interface:
-		fieldMap := objectType.Fields()
-		if objectType.err != nil {
-			return typeMap, objectType.err
-		}
-		for _, field := range fieldMap {
+		for _, field := range objectType.Fields() {
 			for _, arg := range field.Args {
-				typeMap, err = typeMapReducer(schema, typeMap, arg.Type)
-				if err != nil {
-					return typeMap, err
-				}
-			}
-			typeMap, err = typeMapReducer(schema, typeMap, field.Type)
-			if err != nil {
-				return typeMap, err
+				referencedTypes = append(referencedTypes, arg.Type)
 			}
+			referencedTypes = append(referencedTypes, field.Type)
 		}
 	case *InputObject:
-		fieldMap := objectType.Fields()
-		if objectType.err != nil {
-			return typeMap, objectType.err
+		for _, field := range objectType.Fields() {
+			referencedTypes = append(referencedTypes, field.Type)
 		}
-		for _, field := range fieldMap {
-			typeMap, err = typeMapReducer(schema, typeMap, field.Type)
-			if err != nil {
-				return typeMap, err
-			}
+	}
+
+	if objectType.Error() != nil {
+		return typeMap
+	}
+	for _, referencedType := range referencedTypes {
+		typeMap = typeMapReducer(schema, typeMap, referencedType, errs)
+	}
+	return typeMap
+}
+
+// implementingType is a type which may implement interfaces, an Object or an Interface.
+type implementingType interface {
+	Type
+	Fields() FieldDefinitionMap
+	Interfaces() []*Interface
+}
+
+var _ implementingType = (*Object)(nil)
+var _ implementingType = (*Interface)(nil)
+
+// declaresInterface reports whether ttype lists iface among the interfaces it
+// implements. Types must list every interface they implement transitively.
+func declaresInterface(ttype implementingType, iface *Interface) bool {
+	for _, declared := range ttype.Interfaces() {
+		if declared == iface {
+			return true
 		}
 	}
-	return typeMap, nil
+	return false
 }
 
-func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Interface) error {
-	objectFieldMap := object.Fields()
+// assertImplementsInterface returns a SchemaErrors listing every way in which
+// implementation, an Object or an Interface, fails to implement iface, or nil.
+func assertImplementsInterface(schema *Schema, implementation implementingType, iface *Interface) error {
+	errs := SchemaErrors{}
+	objectFieldMap := implementation.Fields()
 	ifaceFieldMap := iface.Fields()
 
 	// Assert each interface field is implemented.
-	for fieldName := range ifaceFieldMap {
+	for _, fieldName := range sortedFieldNames(ifaceFieldMap) {
 		objectField := objectFieldMap[fieldName]
 		ifaceField := ifaceFieldMap[fieldName]
+		coordinate := implementation.Name() + "." + fieldName
 
-		// Assert interface field exists on object.
-		err := invariantf(
-			objectField != nil,
-			`"%v" expects field "%v" but "%v" does not `+
-				`provide it.`, iface, fieldName, object)
-
-		if err != nil {
-			return err
+		// Assert interface field exists on implementation.
+		if objectField == nil {
+			errs.addf(implementation.Name(), `"%v" expects field "%v" but "%v" does not `+
+				`provide it.`, iface, fieldName, implementation)
+			continue
 		}
 
-		// Assert interface field type is satisfied by object field type, by being
+		// Assert interface field type is satisfied by implementation field type, by being
 		// a valid subtype. (covariant)
-		err = invariant(
+		errs.add(coordinate, invariant(
 			isTypeSubTypeOf(schema, objectField.Type, ifaceField.Type),
 			fmt.Sprintf(`%v.%v expects type "%v" but `+
 				`%v.%v provides type "%v".`,
 				iface, fieldName, ifaceField.Type,
-				object, fieldName, objectField.Type),
-		)
-		if err != nil {
-			return err
-		}
+				implementation, fieldName, objectField.Type),
+		))
 
 		// Assert each interface field arg is implemented.
 		for _, ifaceArg := range ifaceField.Args {
@@ -430,32 +427,26 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 					break
 				}
 			}
-			// Assert interface field arg exists on object field.
-			err = invariant(
-				objectArg != nil,
-				fmt.Sprintf(`%v.%v expects argument "%v" but `+
+			// Assert interface field arg exists on implementation field.
+			if objectArg == nil {
+				errs.addf(coordinate, `%v.%v expects argument "%v" but `+
 					`%v.%v does not provide it.`,
 					iface, fieldName, argName,
-					object, fieldName),
-			)
-			if err != nil {
-				return err
+					implementation, fieldName)
+				continue
 			}
 
-			// Assert interface field arg type matches object field arg type.
+			// Assert interface field arg type matches implementation field arg type.
 			// (invariant)
-			err = invariant(
+			errs.add(coordinate+"("+argName+":)", invariant(
 				isEqualType(ifaceArg.Type, objectArg.Type),
 				fmt.Sprintf(
 					`%v.%v(%v:) expects type "%v" `+
 						`but %v.%v(%v:) provides `+
 						`type "%v".`,
 					iface, fieldName, argName, ifaceArg.Type,
-					object, fieldName, argName, objectArg.Type),
-			)
-			if err != nil {
-				return err
-			}
+					implementation, fieldName, argName, objectArg.Type),
+			))
 		}
 		// Assert additional arguments must not be required.
 		for _, objectArg := range objectField.Args {
@@ -470,20 +461,17 @@ func assertObjectImplementsInterface(schema *Schema, object *Object, iface *Inte
 
 			if ifaceArg == nil {
 				_, ok := objectArg.Type.(*NonNull)
-				err = invariant(
+				errs.add(coordinate+"("+argName+":)", invariant(
 					!ok,
 					fmt.Sprintf(`%v.%v(%v:) is of required type `+
 						`"%v" but is not also provided by the interface %v.%v.`,
-						object, fieldName, argName,
+						implementation, fieldName, argName,
 						objectArg.Type, iface, fieldName),
-				)
-				if err != nil {
-					return err
-				}
+				))
 			}
 		}
 	}
-	return nil
+	return errs.err()
 }
 
 func isEqualType(typeA Type, typeB Type) bool {
@@ -544,6 +532,9 @@ func isTypeSubTypeOf(schema *Schema, maybeSubType Type, superType Type) bool {
 		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
 			return true
 		}
+		if maybeSubType, ok := maybeSubType.(*Interface); ok && declaresInterface(maybeSubType, superType) {
+			return true
+		}
 	}
 	if superType, ok := superType.(*Union); ok {
 		if maybeSubType, ok := maybeSubType.(*Object); ok && schema.IsPossibleType(superType, maybeSubType) {
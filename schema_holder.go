@@ -0,0 +1,158 @@
+package graphql
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/GannettDigital/graphql/language/ast"
+)
+
+// BreakingChangesError is returned by SchemaHolder.Swap when the new schema
+// has breaking changes from the current one. Its error message lists the
+// description of each change on a separate line.
+type BreakingChangesError []BreakingChange
+
+func (errs BreakingChangesError) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Description)
+	}
+	return strings.Join(messages, "\n")
+}
+
+// SchemaHolderConfig configures NewSchemaHolder.
+type SchemaHolderConfig struct {
+	// RejectBreakingChanges makes Swap fail when the new schema has breaking
+	// changes from the current one, as found by FindBreakingChanges.
+	RejectBreakingChanges bool
+
+	// DocumentCacheSize is the number of parsed and validated documents Do
+	// keeps for the current schema. Zero disables the cache.
+	DocumentCacheSize int
+}
+
+// SchemaHolder holds a schema which may be replaced while requests are
+// executed against it, for example when the schema is reloaded from a file.
+// It is safe for concurrent use.
+//
+// Executions started before Swap finish on the schema they started with,
+// while executions started after Swap use the new schema.
+type SchemaHolder struct {
+	config SchemaHolderConfig
+
+	// version holds the current *schemaVersion.
+	version atomic.Value
+	// swapMu serializes the checks and replacements of Swap.
+	swapMu sync.Mutex
+}
+
+// schemaVersion is a schema along with the documents validated against it.
+type schemaVersion struct {
+	schema Schema
+
+	mu        sync.Mutex
+	documents map[documentKey]*ast.Document
+}
+
+// documentKey identifies a cached document by its request and by the options
+// of Params changing whether it is valid.
+type documentKey struct {
+	requestString        string
+	disableIntrospection bool
+}
+
+// NewSchemaHolder returns a holder of schema.
+func NewSchemaHolder(schema Schema, config SchemaHolderConfig) *SchemaHolder {
+	holder := &SchemaHolder{config: config}
+	holder.version.Store(newSchemaVersion(schema))
+	return holder
+}
+
+func newSchemaVersion(schema Schema) *schemaVersion {
+	return &schemaVersion{
+		schema:    schema,
+		documents: map[documentKey]*ast.Document{},
+	}
+}
+
+// Load returns the current schema.
+func (h *SchemaHolder) Load() Schema {
+	return h.load().schema
+}
+
+func (h *SchemaHolder) load() *schemaVersion {
+	return h.version.Load().(*schemaVersion)
+}
+
+// Swap replaces the current schema with newSchema, once newSchema is checked
+// by ValidateSchema and, when configured, has no breaking changes from the
+// current schema. On failure, the current schema is kept and the error is a
+// SchemaErrors or a BreakingChangesError.
+//
+// The documents cached for the current schema are dropped.
+func (h *SchemaHolder) Swap(newSchema Schema) error {
+	h.swapMu.Lock()
+	defer h.swapMu.Unlock()
+
+	if errs := ValidateSchema(&newSchema); len(errs) > 0 {
+		return errs
+	}
+	if h.config.RejectBreakingChanges {
+		if changes := FindBreakingChanges(h.Load(), newSchema); len(changes) > 0 {
+			return BreakingChangesError(changes)
+		}
+	}
+	h.version.Store(newSchemaVersion(newSchema))
+	return nil
+}
+
+// Do executes a request as the Do function does, against the current schema
+// in place of p.Schema. The documents of valid requests are cached for the
+// current schema, as configured by DocumentCacheSize, unless the request has
+// a Visibility or is authorized on validation, whose validation may differ
+// from one request to another.
+func (h *SchemaHolder) Do(p Params) *Result {
+	version := h.load()
+	p.Schema = version.schema
+
+	cacheable := p.Visibility == nil && !p.AuthorizeOnValidation
+	key := documentKey{requestString: p.RequestString, disableIntrospection: p.DisableIntrospection}
+	var AST *ast.Document
+	if cacheable {
+		AST = version.document(key)
+	}
+	if AST == nil {
+		var result *Result
+		if AST, result = parseAndValidate(p); result != nil {
+			return result
+		}
+		if cacheable {
+			version.cacheDocument(key, AST, h.config.DocumentCacheSize)
+		}
+	}
+	return execute(p, AST)
+}
+
+func (v *schemaVersion) document(key documentKey) *ast.Document {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.documents[key]
+}
+
+// cacheDocument caches the document of a request, evicting another document
+// when the cache already holds size documents.
+func (v *schemaVersion) cacheDocument(key documentKey, document *ast.Document, size int) {
+	if size <= 0 {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.documents) >= size {
+		for cached := range v.documents {
+			delete(v.documents, cached)
+			break
+		}
+	}
+	v.documents[key] = document
+}
@@ -0,0 +1,156 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/gqlerrors"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+var helloTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": greetingField("hello"),
+		},
+	}),
+})
+
+var helloGoodbyeTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello":   greetingField("hello"),
+			"goodbye": greetingField("goodbye"),
+		},
+	}),
+})
+
+func greetingField(greeting string) *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.String,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return greeting, nil
+		},
+	}
+}
+
+func TestSchemaHolder_FinishesInFlightExecutionsOnOldSchema(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	oldSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						close(started)
+						<-release
+						return "old", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	holder := graphql.NewSchemaHolder(oldSchema, graphql.SchemaHolderConfig{DocumentCacheSize: 10})
+
+	results := make(chan *graphql.Result)
+	go func() {
+		results <- holder.Do(graphql.Params{RequestString: `{ hello }`})
+	}()
+	<-started
+	if err := holder.Swap(helloTestSchema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(release)
+
+	expected := &graphql.Result{Data: map[string]interface{}{"hello": "old"}}
+	if result := <-results; !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected in-flight result, Diff: %v", testutil.Diff(expected, result))
+	}
+	expected = &graphql.Result{Data: map[string]interface{}{"hello": "hello"}}
+	if result := holder.Do(graphql.Params{RequestString: `{ hello }`}); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaHolder_DropsDocumentsOfOldSchema(t *testing.T) {
+	holder := graphql.NewSchemaHolder(helloGoodbyeTestSchema, graphql.SchemaHolderConfig{DocumentCacheSize: 10})
+
+	expected := &graphql.Result{Data: map[string]interface{}{"goodbye": "goodbye"}}
+	if result := holder.Do(graphql.Params{RequestString: `{ goodbye }`}); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if err := holder.Swap(helloTestSchema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The cached document is validated again against the new schema.
+	expected = &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			testutil.RuleError(`Cannot query field "goodbye" on type "Query".`, 1, 3),
+		},
+	}
+	if result := holder.Do(graphql.Params{RequestString: `{ goodbye }`}); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestSchemaHolder_RejectsInvalidAndBreakingSchemas(t *testing.T) {
+	holder := graphql.NewSchemaHolder(helloGoodbyeTestSchema, graphql.SchemaHolderConfig{RejectBreakingChanges: true})
+
+	invalidSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"hello": greetingField("hello")},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	invalidSchema.QueryType().AddFieldConfig("greet", &graphql.Field{
+		Type: graphql.String,
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Type:              graphql.NewNonNull(graphql.String),
+				DeprecationReason: "Use hello.",
+			},
+		},
+	})
+	err = holder.Swap(invalidSchema)
+	if _, ok := err.(graphql.SchemaErrors); !ok {
+		t.Fatalf("expected schema errors, got %v", err)
+	}
+	err = holder.Swap(helloTestSchema)
+	expected := graphql.BreakingChangesError{
+		{Type: graphql.BreakingChangeFieldRemoved, Description: "Query.goodbye was removed."},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("Unexpected error, Diff: %v", testutil.Diff(expected, err))
+	}
+	if schema := holder.Load(); schema.QueryType() != helloGoodbyeTestSchema.QueryType() {
+		t.Fatalf("expected the old schema to be kept")
+	}
+
+	newSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello":   greetingField("hello"),
+				"goodbye": greetingField("goodbye"),
+				"welcome": greetingField("welcome"),
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := holder.Swap(newSchema); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
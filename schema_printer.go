@@ -0,0 +1,289 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PrintSchema prints a schema in the GraphQL schema definition language.
+//
+// Directives other than the specified ones, and every named type except the
+// introspection types and the specified scalars, are printed sorted by name,
+// together with their descriptions, deprecations and applied directives.
+func PrintSchema(schema Schema) string {
+	return PrintFilteredSchema(schema, nil, nil)
+}
+
+// PrintFilteredSchema prints a schema like PrintSchema, leaving out the
+// directives and types for which directiveFilter and typeFilter return false.
+// A nil filter keeps every directive or type.
+func PrintFilteredSchema(schema Schema, directiveFilter func(*Directive) bool, typeFilter func(Type) bool) string {
+	definitions := []string{}
+	if def := printSchemaDefinition(schema); def != "" {
+		definitions = append(definitions, def)
+	}
+
+	directives := []*Directive{}
+	for _, directive := range schema.Directives() {
+		if !isSpecifiedDirective(directive) && (directiveFilter == nil || directiveFilter(directive)) {
+			directives = append(directives, directive)
+		}
+	}
+	sort.Slice(directives, func(i, j int) bool {
+		return directives[i].Name < directives[j].Name
+	})
+	for _, directive := range directives {
+		definitions = append(definitions, printDirectiveDefinition(schema, directive))
+	}
+
+	typeMap := schema.TypeMap()
+	for _, name := range sortedTypeNames(typeMap) {
+		ttype := typeMap[name]
+		if strings.HasPrefix(name, "__") || isSpecifiedScalar(ttype) || (typeFilter != nil && !typeFilter(ttype)) {
+			continue
+		}
+		definitions = append(definitions, printTypeDefinition(schema, ttype))
+	}
+	return strings.Join(definitions, "\n\n") + "\n"
+}
+
+// printSchemaDefinition prints the schema definition, which is omitted when the
+// root types use the default names and the schema has no description nor
+// applied directives.
+func printSchemaDefinition(schema Schema) string {
+	operationTypes := []string{}
+	conventional := true
+	for _, root := range []struct {
+		operation string
+		ttype     *Object
+		name      string
+	}{
+		{"query", schema.QueryType(), "Query"},
+		{"mutation", schema.MutationType(), "Mutation"},
+		{"subscription", schema.SubscriptionType(), "Subscription"},
+	} {
+		if root.ttype == nil {
+			continue
+		}
+		if root.ttype.Name() != root.name {
+			conventional = false
+		}
+		operationTypes = append(operationTypes, fmt.Sprintf("  %v: %v", root.operation, root.ttype.Name()))
+	}
+	if conventional && schema.Description() == "" && len(schema.AppliedDirectives()) == 0 {
+		return ""
+	}
+	return printDescription(schema.Description(), "") + "schema" + printAppliedDirectives(schema, schema.AppliedDirectives()) +
+		" {\n" + strings.Join(operationTypes, "\n") + "\n}"
+}
+
+func printDirectiveDefinition(schema Schema, directive *Directive) string {
+	repeatable := ""
+	if directive.IsRepeatable {
+		repeatable = " repeatable"
+	}
+	return printDescription(directive.Description, "") +
+		"directive @" + directive.Name + printArgs(schema, directive.Args) + repeatable +
+		" on " + strings.Join(directive.Locations, " | ")
+}
+
+func printTypeDefinition(schema Schema, ttype Type) string {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		return printDescription(ttype.Description(), "") +
+			"scalar " + ttype.Name() + printSpecifiedByURL(ttype.SpecifiedByURL()) +
+			printAppliedDirectives(schema, ttype.AppliedDirectives())
+	case *Object:
+		// Object.Description() does not return the configured description.
+		return printDescription(ttype.typeConfig.Description, "") +
+			"type " + ttype.Name() + printImplementedInterfaces(ttype.Interfaces()) +
+			printAppliedDirectives(schema, ttype.AppliedDirectives()) + printFields(schema, ttype.Fields())
+	case *Interface:
+		return printDescription(ttype.Description(), "") +
+			"interface " + ttype.Name() + printImplementedInterfaces(ttype.Interfaces()) +
+			printAppliedDirectives(schema, ttype.AppliedDirectives()) + printFields(schema, ttype.Fields())
+	case *Union:
+		members := []string{}
+		for _, member := range ttype.Types() {
+			members = append(members, member.Name())
+		}
+		str := printDescription(ttype.Description(), "") +
+			"union " + ttype.Name() + printAppliedDirectives(schema, ttype.AppliedDirectives())
+		if len(members) > 0 {
+			str += " = " + strings.Join(members, " | ")
+		}
+		return str
+	case *Enum:
+		values := map[string]*EnumValueDefinition{}
+		for _, value := range ttype.Values() {
+			values[value.Name] = value
+		}
+		lines := []string{}
+		for _, name := range sortedEnumValueNames(ttype) {
+			value := values[name]
+			lines = append(lines, printDescription(value.Description, "  ")+"  "+name+
+				printDeprecated(value.DeprecationReason)+printAppliedDirectives(schema, value.AppliedDirectives))
+		}
+		return printDescription(ttype.Description(), "") +
+			"enum " + ttype.Name() + printAppliedDirectives(schema, ttype.AppliedDirectives()) + printBlock(lines)
+	case *InputObject:
+		fields := ttype.Fields()
+		lines := []string{}
+		for _, name := range sortedInputFieldNames(fields) {
+			field := fields[name]
+			lines = append(lines, printDescription(field.Description(), "  ")+"  "+
+				printInputValue(schema, name, field.Type, field.DefaultValue, field.DeprecationReason, field.AppliedDirectives))
+		}
+		return printDescription(ttype.Description(), "") +
+			"input " + ttype.Name() + printOneOf(ttype.IsOneOf()) +
+			printAppliedDirectives(schema, ttype.AppliedDirectives()) + printBlock(lines)
+	}
+	return ""
+}
+
+func printImplementedInterfaces(interfaces []*Interface) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+	names := []string{}
+	for _, iface := range interfaces {
+		names = append(names, iface.Name())
+	}
+	return " implements " + strings.Join(names, " & ")
+}
+
+func printFields(schema Schema, fields FieldDefinitionMap) string {
+	lines := []string{}
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		lines = append(lines, printDescription(field.Description, "  ")+"  "+name+
+			printArgs(schema, field.Args)+": "+field.Type.String()+
+			printDeprecated(field.DeprecationReason)+printAppliedDirectives(schema, field.AppliedDirectives))
+	}
+	return printBlock(lines)
+}
+
+// printArgs prints arguments sorted by name, on separate lines when any of
+// them has a description.
+func printArgs(schema Schema, args []*Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+	described := false
+	printed := []string{}
+	for _, arg := range sorted {
+		if arg.Description() != "" {
+			described = true
+		}
+		printed = append(printed, printInputValue(schema, arg.Name(), arg.Type, arg.DefaultValue, arg.DeprecationReason, arg.AppliedDirectives))
+	}
+	if !described {
+		return "(" + strings.Join(printed, ", ") + ")"
+	}
+	lines := []string{}
+	for i, arg := range sorted {
+		lines = append(lines, printDescription(arg.Description(), "    ")+"    "+printed[i])
+	}
+	return "(\n" + strings.Join(lines, "\n") + "\n  )"
+}
+
+func printInputValue(schema Schema, name string, ttype Input, defaultValue interface{}, deprecationReason string, directives []*AppliedDirective) string {
+	str := name + ": " + ttype.String()
+	if defaultValue != nil {
+		str += " = " + printDefaultValue(defaultValue, ttype)
+	}
+	return str + printDeprecated(deprecationReason) + printAppliedDirectives(schema, directives)
+}
+
+func printDeprecated(reason string) string {
+	switch reason {
+	case "":
+		return ""
+	case DefaultDeprecationReason:
+		return " @deprecated"
+	}
+	return " @deprecated(reason: " + printDefaultValue(reason, String) + ")"
+}
+
+func printSpecifiedByURL(url string) string {
+	if url == "" {
+		return ""
+	}
+	return " @specifiedBy(url: " + printDefaultValue(url, String) + ")"
+}
+
+func printOneOf(isOneOf bool) string {
+	if !isOneOf {
+		return ""
+	}
+	return " @oneOf"
+}
+
+// printAppliedDirectives prints directives applied to a schema element, with
+// their arguments sorted by name.
+func printAppliedDirectives(schema Schema, directives []*AppliedDirective) string {
+	str := ""
+	for _, applied := range directives {
+		if applied == nil {
+			continue
+		}
+		argTypes := map[string]Input{}
+		if directive := schema.Directive(applied.Name); directive != nil {
+			for _, arg := range directive.Args {
+				argTypes[arg.Name()] = arg.Type
+			}
+		}
+		argNames := []string{}
+		for argName := range applied.Args {
+			argNames = append(argNames, argName)
+		}
+		sort.Strings(argNames)
+		args := []string{}
+		for _, argName := range argNames {
+			args = append(args, argName+": "+printDefaultValue(applied.Args[argName], argTypes[argName]))
+		}
+		str += " @" + applied.Name
+		if len(args) > 0 {
+			str += "(" + strings.Join(args, ", ") + ")"
+		}
+	}
+	return str
+}
+
+// printDescription prints a description as a block string on the lines before
+// the element it describes.
+func printDescription(description string, indentation string) string {
+	if description == "" {
+		return ""
+	}
+	escaped := strings.Replace(description, `"""`, `\"""`, -1)
+	if !strings.Contains(escaped, "\n") && !strings.HasSuffix(escaped, `"`) {
+		return indentation + `"""` + escaped + `"""` + "\n"
+	}
+	str := indentation + `"""` + "\n"
+	for _, line := range strings.Split(escaped, "\n") {
+		str += indentation + line + "\n"
+	}
+	return str + indentation + `"""` + "\n"
+}
+
+func printBlock(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
+
+func isSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if directive == specified {
+			return true
+		}
+	}
+	return false
+}
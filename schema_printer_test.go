@@ -0,0 +1,191 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+func TestPrintSchema_PrintsTypesAndDirectives(t *testing.T) {
+	sdl := `schema @tag(names: ["public"]) {
+  query: Root
+}
+
+"""Restricts access to a role."""
+directive @auth(role: Role!) on OBJECT | FIELD_DEFINITION
+
+directive @tag(names: [String!]) on SCHEMA | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+
+input Filter {
+  limit: Int = 10
+  term: String @tag(names: ["search"])
+}
+
+interface Node {
+  id: ID!
+}
+
+enum Role {
+  ADMIN
+  """
+  Can read,
+  but not write.
+  """
+  VIEWER @deprecated(reason: "Use ADMIN.")
+}
+
+type Root implements Node @auth(role: VIEWER) {
+  id: ID!
+  users(
+    """The filter to apply."""
+    filter: Filter @tag(names: ["a", "b"])
+  ): [User] @auth(role: ADMIN)
+}
+
+scalar Time
+
+union User = Root
+`
+	schema := mustBuildSchema(t, sdl)
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+}
+
+func TestPrintSchema_RoundTrips(t *testing.T) {
+	printed := graphql.PrintSchema(testutil.StarWarsSchema)
+	schema := mustBuildSchema(t, printed)
+	if reprinted := graphql.PrintSchema(schema); reprinted != printed {
+		t.Fatalf("expected printed schema to round trip:\n%v\ngot:\n%v", printed, reprinted)
+	}
+	if changes := graphql.FindBreakingChanges(testutil.StarWarsSchema, schema); len(changes) != 0 {
+		t.Fatalf("unexpected breaking changes: %v", changes)
+	}
+}
+
+func TestPrintSchema_PrintsDeprecatedArgsAndInputFields(t *testing.T) {
+	sdl := `input Filter {
+  query: String @deprecated(reason: "Use term.")
+  term: String
+}
+
+type Query {
+  search(filter: Filter, text: String @deprecated): String
+}
+`
+	schema := mustBuildSchema(t, sdl)
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := graphql.PrintSchema(clientSchema); got != sdl {
+		t.Fatalf("unexpected client schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+}
+
+func TestPrintSchema_PrintsSpecifiedByURL(t *testing.T) {
+	sdl := `scalar DateTime @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+type Query {
+  createdAt: DateTime
+}
+`
+	schema := mustBuildSchema(t, sdl)
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+	if directives := schema.Type("DateTime").(*graphql.Scalar).AppliedDirectives(); len(directives) != 0 {
+		t.Fatalf("expected @specifiedBy not to be kept as an applied directive, got %v", directives)
+	}
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := graphql.PrintSchema(clientSchema); got != sdl {
+		t.Fatalf("unexpected client schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ dateTime: __type(name: "DateTime") { specifiedByURL } query: __type(name: "Query") { specifiedByURL } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"dateTime": map[string]interface{}{"specifiedByURL": "https://tools.ietf.org/html/rfc3339"},
+		"query":    map[string]interface{}{"specifiedByURL": nil},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+func TestPrintSchema_PrintsSchemaDescription(t *testing.T) {
+	sdl := `"""The public API."""
+schema {
+  query: Query
+}
+
+type Query {
+  hello: String
+}
+`
+	schema := mustBuildSchema(t, sdl)
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := graphql.PrintSchema(clientSchema); got != sdl {
+		t.Fatalf("unexpected client schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+}
+
+func TestPrintSchema_PrintsOneOfInputObjects(t *testing.T) {
+	sdl := `input Lookup @oneOf {
+  id: ID
+  slug: String
+}
+
+type Query {
+  page(by: Lookup): String
+}
+`
+	schema := mustBuildSchema(t, sdl)
+	if !schema.Type("Lookup").(*graphql.InputObject).IsOneOf() {
+		t.Fatalf("expected Lookup to be a OneOf input object")
+	}
+	if got := graphql.PrintSchema(schema); got != sdl {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+	clientSchema, err := graphql.BuildClientSchema(introspectSchema(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := graphql.PrintSchema(clientSchema); got != sdl {
+		t.Fatalf("unexpected client schema:\n%v\nexpected:\n%v", got, sdl)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ lookup: __type(name: "Lookup") { isOneOf } query: __type(name: "Query") { isOneOf } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"lookup": map[string]interface{}{"isOneOf": true},
+		"query":  map[string]interface{}{"isOneOf": nil},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
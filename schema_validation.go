@@ -0,0 +1,320 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+)
+
+// SchemaError is a violation of the type system rules found in a schema.
+type SchemaError struct {
+	// Coordinate locates the offending schema element, such as "Query",
+	// "Query.hero", "Query.hero(episode:)" or "@include". It is empty for
+	// errors about the schema as a whole.
+	Coordinate string `json:"coordinate,omitempty"`
+	Message    string `json:"message"`
+}
+
+func (e *SchemaError) Error() string {
+	return e.Message
+}
+
+// SchemaErrors is the list of violations returned by NewSchema and
+// ValidateSchema. Its error message lists the message of each violation on a
+// separate line.
+type SchemaErrors []*SchemaError
+
+func (errs SchemaErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "\n")
+}
+
+// add records err against coordinate, flattening errors which are themselves SchemaErrors.
+func (errs *SchemaErrors) add(coordinate string, err error) {
+	switch err := err.(type) {
+	case nil:
+	case SchemaErrors:
+		for _, err := range err {
+			errs.add(coordinate, err)
+		}
+	default:
+		// A type whose field type failed to build reports the same error as
+		// that field type.
+		for _, recorded := range *errs {
+			if recorded.Message == err.Error() {
+				return
+			}
+		}
+		if err, ok := err.(*SchemaError); ok {
+			*errs = append(*errs, err)
+			return
+		}
+		*errs = append(*errs, &SchemaError{Coordinate: coordinate, Message: err.Error()})
+	}
+}
+
+func (errs *SchemaErrors) addf(coordinate string, format string, a ...interface{}) {
+	errs.add(coordinate, invariantf(false, format, a...))
+}
+
+// err returns errs as an error, or nil when no violation was recorded.
+func (errs SchemaErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateSchema checks a schema against the rules of the type system and
+// returns every violation it finds, or nil when the schema is valid.
+//
+// NewSchema already returns these violations, so this is only needed to
+// re-check a schema whose types were changed afterwards, for example through
+// AppendType or AddFieldConfig.
+func ValidateSchema(schema *Schema) SchemaErrors {
+	errs := SchemaErrors{}
+
+	if schema.QueryType() == nil {
+		errs.addf("", "Schema query must be Object Type but got: nil.")
+	}
+	validateDirectives(schema, &errs)
+	validateAppliedDirectives(schema, "", DirectiveLocationSchema, schema.AppliedDirectives(), &errs)
+
+	typeMap := schema.TypeMap()
+	for _, name := range sortedTypeNames(typeMap) {
+		ttype := typeMap[name]
+		switch ttype := ttype.(type) {
+		case *Object:
+			ttype.Interfaces()
+			ttype.Fields()
+		case *Interface:
+			ttype.Interfaces()
+			ttype.Fields()
+		case *InputObject:
+			ttype.Fields()
+		}
+		// Types which failed to build report only the error which stopped them.
+		if err := ttype.Error(); err != nil {
+			errs.add(name, err)
+			continue
+		}
+		if strings.HasPrefix(name, "__") && !isIntrospectionType(ttype) {
+			errs.addf(name, `Name "%v" must not begin with "__", which is reserved by GraphQL introspection.`, name)
+		}
+
+		switch ttype := ttype.(type) {
+		case *Scalar:
+			validateAppliedDirectives(schema, name, DirectiveLocationScalar, ttype.AppliedDirectives(), &errs)
+		case *Object:
+			validateAppliedDirectives(schema, name, DirectiveLocationObject, ttype.AppliedDirectives(), &errs)
+			validateFields(schema, ttype, ttype.Fields(), &errs)
+			validateInterfaces(schema, ttype, &errs)
+		case *Interface:
+			validateAppliedDirectives(schema, name, DirectiveLocationInterface, ttype.AppliedDirectives(), &errs)
+			validateFields(schema, ttype, ttype.Fields(), &errs)
+			validateInterfaces(schema, ttype, &errs)
+		case *Union:
+			validateAppliedDirectives(schema, name, DirectiveLocationUnion, ttype.AppliedDirectives(), &errs)
+			validateUnionMembers(ttype, &errs)
+		case *Enum:
+			validateAppliedDirectives(schema, name, DirectiveLocationEnum, ttype.AppliedDirectives(), &errs)
+			for _, value := range ttype.Values() {
+				validateAppliedDirectives(schema, name+"."+value.Name, DirectiveLocationEnumValue, value.AppliedDirectives, &errs)
+			}
+		case *InputObject:
+			validateAppliedDirectives(schema, name, DirectiveLocationInputObject, ttype.AppliedDirectives(), &errs)
+			validateInputFields(schema, ttype, &errs)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateDirectives(schema *Schema, errs *SchemaErrors) {
+	seen := map[string]bool{}
+	for _, directive := range schema.Directives() {
+		// Directives which failed to build have no name.
+		coordinate := ""
+		if directive.Name != "" {
+			coordinate = "@" + directive.Name
+		}
+		if directive.err != nil {
+			errs.add(coordinate, directive.err)
+			continue
+		}
+		if seen[directive.Name] {
+			errs.addf(coordinate, `Schema must contain uniquely named directives but contains multiple directives named "%v".`, directive.Name)
+			continue
+		}
+		seen[directive.Name] = true
+		if directive.Resolve != nil && !containsString(directive.Locations, DirectiveLocationField) {
+			errs.addf(coordinate, `Directive "@%v" has an execution hook and must include the %v location.`, directive.Name, DirectiveLocationField)
+		}
+		for _, arg := range directive.Args {
+			if !IsInputType(arg.Type) {
+				errs.addf(coordinate+"("+arg.Name()+":)", `@%v(%v:) argument type must be Input Type but got: %v.`, directive.Name, arg.Name(), arg.Type)
+			}
+			if arg.DeprecationReason != "" && isRequiredInput(arg.Type, arg.DefaultValue) {
+				errs.addf(coordinate+"("+arg.Name()+":)", `Required argument @%v(%v:) cannot be deprecated.`, directive.Name, arg.Name())
+			}
+			validateAppliedDirectives(schema, coordinate+"("+arg.Name()+":)", DirectiveLocationArgumentDefinition, arg.AppliedDirectives, errs)
+		}
+	}
+}
+
+func validateFields(schema *Schema, ttype Type, fields FieldDefinitionMap, errs *SchemaErrors) {
+	for _, fieldName := range sortedFieldNames(fields) {
+		field := fields[fieldName]
+		coordinate := ttype.Name() + "." + fieldName
+		if !IsOutputType(field.Type) {
+			errs.addf(coordinate, `%v.%v field type must be Output Type but got: %v.`, ttype, fieldName, field.Type)
+		}
+		validateAppliedDirectives(schema, coordinate, DirectiveLocationFieldDefinition, field.AppliedDirectives, errs)
+		for _, arg := range field.Args {
+			if !IsInputType(arg.Type) {
+				errs.addf(coordinate+"("+arg.Name()+":)", `%v.%v(%v:) argument type must be Input Type but got: %v.`, ttype, fieldName, arg.Name(), arg.Type)
+			}
+			if arg.DeprecationReason != "" && isRequiredInput(arg.Type, arg.DefaultValue) {
+				errs.addf(coordinate+"("+arg.Name()+":)", `Required argument %v.%v(%v:) cannot be deprecated.`, ttype, fieldName, arg.Name())
+			}
+			validateAppliedDirectives(schema, coordinate+"("+arg.Name()+":)", DirectiveLocationArgumentDefinition, arg.AppliedDirectives, errs)
+		}
+	}
+}
+
+func validateInterfaces(schema *Schema, ttype implementingType, errs *SchemaErrors) {
+	seen := map[string]bool{}
+	for _, iface := range ttype.Interfaces() {
+		if Type(iface) == ttype {
+			errs.addf(ttype.Name(), `Type %v cannot implement itself because it would create a circular reference.`, ttype)
+			continue
+		}
+		if seen[iface.Name()] {
+			errs.addf(ttype.Name(), `%v may declare it implements %v only once.`, ttype, iface)
+			continue
+		}
+		seen[iface.Name()] = true
+		if iface.Error() != nil {
+			continue
+		}
+		for _, transitive := range iface.Interfaces() {
+			if declaresInterface(ttype, transitive) {
+				continue
+			}
+			if Type(transitive) == ttype {
+				errs.addf(ttype.Name(), `Type %v cannot implement %v because it would create a circular reference.`, ttype, iface)
+			} else {
+				errs.addf(ttype.Name(), `Type %v must implement %v because it is implemented by %v.`, ttype, transitive, iface)
+			}
+		}
+		errs.add(ttype.Name(), assertImplementsInterface(schema, ttype, iface))
+	}
+}
+
+func validateUnionMembers(union *Union, errs *SchemaErrors) {
+	seen := map[string]bool{}
+	for _, member := range union.Types() {
+		if seen[member.Name()] {
+			errs.addf(union.Name(), `Union type %v can only include type %v once.`, union, member)
+			continue
+		}
+		seen[member.Name()] = true
+	}
+}
+
+func validateInputFields(schema *Schema, inputObject *InputObject, errs *SchemaErrors) {
+	fields := inputObject.Fields()
+	for _, fieldName := range sortedInputFieldNames(fields) {
+		field := fields[fieldName]
+		coordinate := inputObject.Name() + "." + fieldName
+		if !IsInputType(field.Type) {
+			errs.addf(coordinate, `%v.%v field type must be Input Type but got: %v.`, inputObject, fieldName, field.Type)
+		}
+		if field.DeprecationReason != "" && isRequiredInput(field.Type, field.DefaultValue) {
+			errs.addf(coordinate, `Required input field %v.%v cannot be deprecated.`, inputObject, fieldName)
+		}
+		if inputObject.IsOneOf() {
+			if _, ok := field.Type.(*NonNull); ok {
+				errs.addf(coordinate, `OneOf input field %v.%v must be nullable.`, inputObject, fieldName)
+			}
+			if field.DefaultValue != nil {
+				errs.addf(coordinate, `OneOf input field %v.%v cannot have a default value.`, inputObject, fieldName)
+			}
+		}
+		validateAppliedDirectives(schema, coordinate, DirectiveLocationInputFieldDefinition, field.AppliedDirectives, errs)
+	}
+}
+
+// validateAppliedDirectives checks the directives applied to a schema element
+// at the given location against their definitions in the schema.
+func validateAppliedDirectives(schema *Schema, coordinate string, location string, directives []*AppliedDirective, errs *SchemaErrors) {
+	// Messages name the element, as the same directive is often applied in many places.
+	element := coordinate
+	if element == "" {
+		element = "schema"
+	}
+	seen := map[string]bool{}
+	for _, applied := range directives {
+		if applied == nil {
+			continue
+		}
+		directive := schema.Directive(applied.Name)
+		if directive == nil {
+			errs.addf(coordinate, `Unknown directive "@%v" applied to %v.`, applied.Name, element)
+			continue
+		}
+		if !containsString(directive.Locations, location) {
+			errs.addf(coordinate, `Directive "@%v" may not be used on %v %v.`, applied.Name, location, element)
+		}
+		if seen[applied.Name] && !directive.IsRepeatable {
+			errs.addf(coordinate, `The directive "@%v" can only be used once on %v.`, applied.Name, element)
+			continue
+		}
+		seen[applied.Name] = true
+
+		argDefs := map[string]*Argument{}
+		for _, argDef := range directive.Args {
+			argDefs[argDef.Name()] = argDef
+		}
+		argNames := []string{}
+		for argName := range applied.Args {
+			argNames = append(argNames, argName)
+		}
+		sort.Strings(argNames)
+		for _, argName := range argNames {
+			if _, ok := argDefs[argName]; !ok {
+				errs.addf(coordinate, `Unknown argument "%v" on directive "@%v" applied to %v.`, argName, applied.Name, element)
+			}
+		}
+		for _, argDef := range directive.Args {
+			value, ok := applied.Args[argDef.Name()]
+			if !ok {
+				if _, isNonNull := argDef.Type.(*NonNull); isNonNull && argDef.DefaultValue == nil {
+					errs.addf(coordinate, `Directive "@%v" argument "%v" of type "%v" is required but not provided on %v.`, applied.Name, argDef.Name(), argDef.Type, element)
+				}
+				continue
+			}
+			if isValid, messages := isValidInputValue(schema, value, argDef.Type); !isValid {
+				messagesStr := ""
+				if len(messages) > 0 {
+					messagesStr = "\n" + strings.Join(messages, "\n")
+				}
+				errs.addf(coordinate, `Directive "@%v" argument "%v" applied to %v has invalid value %v.%v`, applied.Name, argDef.Name(), element, value, messagesStr)
+			}
+		}
+	}
+}
+
+func isIntrospectionType(ttype Type) bool {
+	switch ttype {
+	case SchemaType, DirectiveType, TypeType, FieldType, InputValueType,
+		EnumValueType, TypeKindEnumType, DirectiveLocationEnumType:
+		return true
+	}
+	return false
+}
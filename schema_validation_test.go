@@ -0,0 +1,193 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+)
+
+func TestNewSchema_ReturnsAllViolations(t *testing.T) {
+	namedInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Named",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Args: graphql.FieldConfigArgument{
+					"short": &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+			},
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	})
+	badName := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "bad-name",
+		Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+	})
+	person := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Person",
+		Interfaces: []*graphql.Interface{namedInterface},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	reserved := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "__Reserved",
+		Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+	})
+	duplicateString := graphql.NewScalar(graphql.ScalarConfig{
+		Name:      "String",
+		Serialize: func(value interface{}) interface{} { return value },
+	})
+
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"person":   &graphql.Field{Type: person},
+				"reserved": &graphql.Field{Type: reserved},
+				"name":     &graphql.Field{Type: graphql.String},
+			},
+		}),
+		Types: []graphql.Type{badName, duplicateString},
+	})
+	errs, ok := err.(graphql.SchemaErrors)
+	if !ok {
+		t.Fatalf("expected SchemaErrors, got %T: %v", err, err)
+	}
+
+	expected := graphql.SchemaErrors{
+		{Coordinate: "", Message: `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "bad-name" does not.`},
+		{Coordinate: "String", Message: `Schema must contain unique named types but contains multiple types named "String".`},
+		{Coordinate: "Person", Message: `"Named" expects field "id" but "Person" does not provide it.`},
+		{Coordinate: "Person.name", Message: `Named.name expects type "String!" but Person.name provides type "String".`},
+		{Coordinate: "Person.name", Message: `Named.name expects argument "short" but Person.name does not provide it.`},
+		{Coordinate: "__Reserved", Message: `Name "__Reserved" must not begin with "__", which is reserved by GraphQL introspection.`},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Fatalf("unexpected errors:\n%v", err)
+	}
+	if badName.Error() == nil {
+		t.Fatalf("expected bad-name to fail to build")
+	}
+}
+
+func TestNewSchema_ReportsEveryInvalidField(t *testing.T) {
+	_, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ok":        &graphql.Field{Type: graphql.String},
+				"bad-field": &graphql.Field{Type: graphql.String},
+				"missing":   &graphql.Field{},
+				"search": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"bad-arg": &graphql.ArgumentConfig{Type: graphql.String},
+						"term":    &graphql.ArgumentConfig{},
+					},
+				},
+			},
+		}),
+	})
+	expected := graphql.SchemaErrors{
+		{Coordinate: "Query.bad-field", Message: `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "bad-field" does not.`},
+		{Coordinate: "Query.missing", Message: `Query.missing field type must be Output Type but got: <nil>.`},
+		{Coordinate: "Query.search(bad-arg:)", Message: `Names must match /^[_a-zA-Z][_a-zA-Z0-9]*$/ but "bad-arg" does not.`},
+		{Coordinate: "Query.search(term:)", Message: `Query.search(term:) argument type must be Input Type but got: <nil>.`},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("unexpected errors:\n%v\nexpected:\n%v", err, expected)
+	}
+}
+
+func TestValidateSchema_AcceptsValidSchema(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"a": &graphql.Field{Type: graphql.String}},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := graphql.ValidateSchema(&schema); errs != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestValidateSchema_ChecksAppendedFields(t *testing.T) {
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   "In",
+		Fields: graphql.InputObjectConfigFieldMap{"a": &graphql.InputObjectFieldConfig{Type: graphql.String}},
+	})
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{"in": &graphql.ArgumentConfig{Type: input}},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query.AddFieldConfig("b", &graphql.Field{Type: input})
+	query.Fields()
+	expected := graphql.SchemaErrors{
+		{Coordinate: "Query.b", Message: `Query.b field type must be Output Type but got: In.`},
+	}
+	if errs := graphql.ValidateSchema(&schema); !reflect.DeepEqual(errs, expected) {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestValidateSchema_RejectsDeprecatedRequiredInputs(t *testing.T) {
+	_, err := graphql.BuildSchema(`
+		directive @cached(ttl: Int! @deprecated, scope: String @deprecated) on FIELD
+
+		input Filter {
+			term: String! @deprecated(reason: "Use query.")
+			query: String! = "" @deprecated
+		}
+
+		type Query {
+			search(filter: Filter, text: String! @deprecated, limit: Int @deprecated): String
+		}
+	`)
+	expected := graphql.SchemaErrors{
+		{Coordinate: "@cached(ttl:)", Message: "Required argument @cached(ttl:) cannot be deprecated."},
+		{Coordinate: "Filter.term", Message: "Required input field Filter.term cannot be deprecated."},
+		{Coordinate: "Query.search(text:)", Message: "Required argument Query.search(text:) cannot be deprecated."},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("unexpected errors:\n%v\nexpected:\n%v", err, expected)
+	}
+}
+
+func TestValidateSchema_RejectsInvalidOneOfInputFields(t *testing.T) {
+	_, err := graphql.BuildSchema(`
+		input Lookup @oneOf {
+			id: ID!
+			slug: String = "home"
+			url: String
+		}
+
+		type Query {
+			page(by: Lookup): String
+		}
+	`)
+	expected := graphql.SchemaErrors{
+		{Coordinate: "Lookup.id", Message: "OneOf input field Lookup.id must be nullable."},
+		{Coordinate: "Lookup.slug", Message: "OneOf input field Lookup.slug cannot have a default value."},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Fatalf("unexpected errors:\n%v\nexpected:\n%v", err, expected)
+	}
+}
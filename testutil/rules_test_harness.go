@@ -293,6 +293,18 @@ func init() {
 			},
 		},
 	})
+	var oneOfInputObject = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "OneOfInput",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id": &graphql.InputObjectFieldConfig{
+				Type: graphql.ID,
+			},
+			"slug": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+		},
+	})
 	var complicatedArgs = graphql.NewObject(graphql.ObjectConfig{
 		Name: "ComplicatedArgs",
 		// TODO List
@@ -371,6 +383,14 @@ func init() {
 					},
 				},
 			},
+			"oneOfArgField": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"oneOfArg": &graphql.ArgumentConfig{
+						Type: oneOfInputObject,
+					},
+				},
+			},
 			"multipleReqs": &graphql.Field{
 				Type: graphql.String,
 				Args: graphql.FieldConfigArgument{
@@ -530,6 +550,15 @@ func init() {
 				Name:      "onInputFieldDefinition",
 				Locations: []string{graphql.DirectiveLocationInputFieldDefinition},
 			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name: "repeatable",
+				Locations: []string{
+					graphql.DirectiveLocationQuery,
+					graphql.DirectiveLocationField,
+					graphql.DirectiveLocationFragmentSpread,
+				},
+				IsRepeatable: true,
+			}),
 		},
 		Types: []graphql.Type{
 			catType,
@@ -64,6 +64,15 @@ func (ti *TypeInfo) InputType() Input {
 	}
 	return nil
 }
+
+// ParentInputType returns the input type which encloses the current input
+// type, such as the input object of an object field.
+func (ti *TypeInfo) ParentInputType() Input {
+	if len(ti.inputTypeStack) > 1 {
+		return ti.inputTypeStack[len(ti.inputTypeStack)-2]
+	}
+	return nil
+}
 func (ti *TypeInfo) FieldDef() *FieldDefinition {
 	if len(ti.fieldDefStack) > 0 {
 		return ti.fieldDefStack[len(ti.fieldDefStack)-1]
@@ -96,6 +105,9 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 		var fieldDef *FieldDefinition
 		if parentType != nil {
 			fieldDef = ti.getFieldDef(schema, parentType.(Type), node)
+			if !schema.isFieldVisible(parentType.(Type), fieldDef) {
+				fieldDef = nil
+			}
 		}
 		ti.fieldDefStack = append(ti.fieldDefStack, fieldDef)
 		if fieldDef != nil {
@@ -153,7 +165,7 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 				}
 			}
 		} else if fieldDef != nil {
-			for _, arg := range fieldDef.Args {
+			for _, arg := range schema.visibleArgs(fieldDef) {
 				if arg.Name() == nameVal {
 					argDef = arg
 				}
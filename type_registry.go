@@ -0,0 +1,639 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StructTag is the struct tag read when generating GraphQL types from Go structs.
+//
+// The tag holds the field name followed by options:
+//
+//	Name  string `graphql:"fullName,desc=The full name.,deprecated=Use names."`
+//	Notes string `graphql:"-"`
+//
+// An empty name falls back to the name of the json tag, then to the Go field
+// name with its first word lower-cased. A deprecated option without a value
+// uses the default deprecation reason.
+const StructTag = "graphql"
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// TypeRegistry generates GraphQL types from Go types.
+//
+// Every Go type is generated once, so recursive Go types produce recursive
+// GraphQL types. Go types map as follows:
+//
+//   - strings, booleans, integers and floats map to the specified scalars, and
+//     time.Time maps to DateTime;
+//   - maps map to the Map scalar, and slices and arrays map to lists;
+//   - structs map to objects, or to input objects when used as inputs;
+//   - interfaces map to GraphQL interfaces whose fields are the interface
+//     methods, implemented by every generated object whose Go type implements
+//     the interface.
+//
+// Values which cannot be nil map to non-null types, while pointers, slices,
+// maps and interfaces are nullable. Methods used as fields take no arguments
+// other than an optional context.Context and return a value, optionally
+// followed by an error.
+type TypeRegistry struct {
+	names        map[reflect.Type]string
+	bound        map[reflect.Type]Type
+	objects      map[reflect.Type]*Object
+	objectFields map[reflect.Type]Fields
+	methodFields map[reflect.Type]map[string]bool
+	interfaces   map[reflect.Type]*Interface
+	inputs       map[reflect.Type]*InputObject
+	types        []Type
+}
+
+// NewTypeRegistry creates an empty TypeRegistry.
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		names:        map[reflect.Type]string{},
+		bound:        map[reflect.Type]Type{},
+		objects:      map[reflect.Type]*Object{},
+		objectFields: map[reflect.Type]Fields{},
+		methodFields: map[reflect.Type]map[string]bool{},
+		interfaces:   map[reflect.Type]*Interface{},
+		inputs:       map[reflect.Type]*InputObject{},
+	}
+}
+
+// Name overrides the GraphQL name of the Go type of value, which defaults to
+// the name of the Go type. Input objects append "Input" to the name unless it
+// already ends with it. Interfaces are given as a pointer to the interface,
+// e.g. (*Node)(nil).
+func (r *TypeRegistry) Name(value interface{}, name string) {
+	r.names[registryType(value)] = name
+}
+
+// Bind maps the Go type of value to an existing GraphQL type, e.g. to map a
+// string type to an enum.
+func (r *TypeRegistry) Bind(value interface{}, ttype Type) {
+	r.bound[registryType(value)] = ttype
+}
+
+// Output returns the output type generated from the Go type of value.
+func (r *TypeRegistry) Output(value interface{}) (Output, error) {
+	return r.outputType(registryType(value))
+}
+
+// Input returns the input type generated from the Go type of value.
+func (r *TypeRegistry) Input(value interface{}) (Input, error) {
+	return r.inputType(registryType(value))
+}
+
+// Object returns the object generated from the Go struct type of value.
+func (r *TypeRegistry) Object(value interface{}) (*Object, error) {
+	t := registryType(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := invariantf(t.Kind() == reflect.Struct, `Go type %v is not a struct.`, t); err != nil {
+		return nil, err
+	}
+	return r.object(t)
+}
+
+// Fields returns the fields generated from the Go struct type of value,
+// resolved from struct sources.
+func (r *TypeRegistry) Fields(value interface{}) (Fields, error) {
+	object, err := r.Object(value)
+	if err != nil {
+		return nil, err
+	}
+	return object.typeConfig.Fields.(Fields), nil
+}
+
+// Arguments returns the arguments generated from the fields of the Go struct
+// type of value, typically decoded again with ResolveParams.DecodeArgs.
+func (r *TypeRegistry) Arguments(value interface{}) (FieldConfigArgument, error) {
+	t := registryType(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := invariantf(t.Kind() == reflect.Struct, `Go type %v is not a struct.`, t); err != nil {
+		return nil, err
+	}
+	args := FieldConfigArgument{}
+	err := eachStructField(t, func(field reflect.StructField, tag structTag) error {
+		argType, err := r.inputType(field.Type)
+		if err != nil {
+			return err
+		}
+		args[tag.name] = &ArgumentConfig{
+			Type:              argType,
+			Description:       tag.description,
+			DeprecationReason: tag.deprecationReason,
+		}
+		return nil
+	})
+	return args, err
+}
+
+// Types returns every named type generated so far, sorted by name, to be
+// listed in SchemaConfig.Types so that implementations of interfaces are
+// known to the schema.
+func (r *TypeRegistry) Types() []Type {
+	types := append([]Type{}, r.types...)
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name() < types[j].Name()
+	})
+	return types
+}
+
+func (r *TypeRegistry) outputType(t reflect.Type) (Output, error) {
+	if t.Kind() == reflect.Ptr {
+		ttype, err := r.outputType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return GetNullable(ttype).(Output), nil
+	}
+	if bound, ok := r.bound[t]; ok {
+		output, ok := bound.(Output)
+		if err := invariantf(ok, `Type "%v" bound to Go type %v is not an output type.`, bound, t); err != nil {
+			return nil, err
+		}
+		return nonNullType(t, output).(Output), nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		itemType, err := r.outputType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nonNullType(t, NewList(itemType)).(Output), nil
+	case reflect.Interface:
+		iface, err := r.iface(t)
+		if err != nil {
+			return nil, err
+		}
+		return iface, nil
+	case reflect.Struct:
+		if t == timeType {
+			return NewNonNull(DateTime), nil
+		}
+		object, err := r.object(t)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(object), nil
+	}
+	scalar, err := scalarType(t)
+	if err != nil {
+		return nil, err
+	}
+	return nonNullType(t, scalar).(Output), nil
+}
+
+func (r *TypeRegistry) inputType(t reflect.Type) (Input, error) {
+	if t.Kind() == reflect.Ptr {
+		ttype, err := r.inputType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return GetNullable(ttype).(Input), nil
+	}
+	if bound, ok := r.bound[t]; ok {
+		input, ok := bound.(Input)
+		if err := invariantf(ok && IsInputType(bound), `Type "%v" bound to Go type %v is not an input type.`, bound, t); err != nil {
+			return nil, err
+		}
+		return nonNullType(t, input).(Input), nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		itemType, err := r.inputType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nonNullType(t, NewList(itemType)).(Input), nil
+	case reflect.Struct:
+		if t == timeType {
+			return NewNonNull(DateTime), nil
+		}
+		inputObject, err := r.inputObject(t)
+		if err != nil {
+			return nil, err
+		}
+		return NewNonNull(inputObject), nil
+	}
+	scalar, err := scalarType(t)
+	if err != nil {
+		return nil, err
+	}
+	return nonNullType(t, scalar).(Input), nil
+}
+
+func (r *TypeRegistry) object(t reflect.Type) (*Object, error) {
+	if object, ok := r.objects[t]; ok {
+		return object, nil
+	}
+	name, err := r.typeName(t, "")
+	if err != nil {
+		return nil, err
+	}
+	fields := Fields{}
+	object := NewObject(ObjectConfig{
+		Name:   name,
+		Fields: fields,
+		Interfaces: InterfacesThunk(func() []*Interface {
+			return r.implementedInterfaces(t)
+		}),
+	})
+	if err := object.Error(); err != nil {
+		return nil, err
+	}
+	// The object is registered before its fields are generated, so that
+	// fields referring back to it reuse it.
+	r.objects[t] = object
+	r.objectFields[t] = fields
+	r.types = append(r.types, object)
+
+	err = eachStructField(t, func(field reflect.StructField, tag structTag) error {
+		fieldType, err := r.outputType(field.Type)
+		if err != nil {
+			return err
+		}
+		index := field.Index
+		fields[tag.name] = &Field{
+			Type:              fieldType,
+			Description:       tag.description,
+			DeprecationReason: tag.deprecationReason,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return structFieldValue(p.Source, index), nil
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for ifaceType := range r.interfaces {
+		if err := r.addMethodFields(t, ifaceType); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+func (r *TypeRegistry) iface(t reflect.Type) (*Interface, error) {
+	if iface, ok := r.interfaces[t]; ok {
+		return iface, nil
+	}
+	if err := invariantf(t.NumMethod() > 0, `Go type %v has no methods to map to fields.`, t); err != nil {
+		return nil, err
+	}
+	name, err := r.typeName(t, "")
+	if err != nil {
+		return nil, err
+	}
+	fields := Fields{}
+	iface := NewInterface(InterfaceConfig{
+		Name:   name,
+		Fields: fields,
+		ResolveType: func(p ResolveTypeParams) *Object {
+			valueType := reflect.TypeOf(p.Value)
+			for valueType != nil && valueType.Kind() == reflect.Ptr {
+				valueType = valueType.Elem()
+			}
+			return r.objects[valueType]
+		},
+	})
+	if err := iface.Error(); err != nil {
+		return nil, err
+	}
+	r.interfaces[t] = iface
+	r.types = append(r.types, iface)
+
+	if err := r.addFields(t, t, fields); err != nil {
+		return nil, err
+	}
+	for objectType := range r.objects {
+		if err := r.addMethodFields(objectType, t); err != nil {
+			return nil, err
+		}
+	}
+	return iface, nil
+}
+
+func (r *TypeRegistry) inputObject(t reflect.Type) (*InputObject, error) {
+	if inputObject, ok := r.inputs[t]; ok {
+		return inputObject, nil
+	}
+	name, err := r.typeName(t, "Input")
+	if err != nil {
+		return nil, err
+	}
+	fields := InputObjectConfigFieldMap{}
+	inputObject := NewInputObject(InputObjectConfig{
+		Name: name,
+		Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			return fields
+		}),
+	})
+	if err := inputObject.Error(); err != nil {
+		return nil, err
+	}
+	r.inputs[t] = inputObject
+	r.types = append(r.types, inputObject)
+
+	err = eachStructField(t, func(field reflect.StructField, tag structTag) error {
+		fieldType, err := r.inputType(field.Type)
+		if err != nil {
+			return err
+		}
+		fields[tag.name] = &InputObjectFieldConfig{
+			Type:              fieldType,
+			Description:       tag.description,
+			DeprecationReason: tag.deprecationReason,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inputObject, nil
+}
+
+// addMethodFields adds the methods of an interface to the fields of an object
+// whose Go type implements it.
+func (r *TypeRegistry) addMethodFields(objectType reflect.Type, ifaceType reflect.Type) error {
+	if !objectType.Implements(ifaceType) && !reflect.PtrTo(objectType).Implements(ifaceType) {
+		return nil
+	}
+	return r.addFields(objectType, ifaceType, r.objectFields[objectType])
+}
+
+// addFields adds a field for each method of an interface to the fields of the
+// owner, which is the interface itself or a Go type implementing it.
+func (r *TypeRegistry) addFields(owner reflect.Type, ifaceType reflect.Type, fields Fields) error {
+	if r.methodFields[owner] == nil {
+		r.methodFields[owner] = map[string]bool{}
+	}
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		method := ifaceType.Method(i)
+		name := lowerFirstWord(method.Name)
+		if r.methodFields[owner][method.Name] {
+			// Another interface declares the same method.
+			continue
+		}
+		if _, ok := fields[name]; ok {
+			return invariantf(false, `Method %v of Go type %v conflicts with field "%v" of Go type %v.`, method.Name, ifaceType, name, owner)
+		}
+		methodType := method.Type
+		takesContext := methodType.NumIn() == 1 && methodType.In(0) == contextType
+		returnsError := methodType.NumOut() == 2 && methodType.Out(1) == errorType
+		if err := invariantf(
+			(methodType.NumIn() == 0 || takesContext) && (methodType.NumOut() == 1 || returnsError),
+			`Method %v of Go type %v must take no arguments other than a context and return a value and an optional error.`,
+			method.Name, ifaceType,
+		); err != nil {
+			return err
+		}
+		fieldType, err := r.outputType(methodType.Out(0))
+		if err != nil {
+			return err
+		}
+		methodName := method.Name
+		fields[name] = &Field{
+			Type: fieldType,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				return callMethod(p, methodName, takesContext, returnsError)
+			},
+		}
+		r.methodFields[owner][method.Name] = true
+	}
+	return nil
+}
+
+func (r *TypeRegistry) implementedInterfaces(t reflect.Type) []*Interface {
+	interfaces := []*Interface{}
+	for ifaceType, iface := range r.interfaces {
+		if t.Implements(ifaceType) || reflect.PtrTo(t).Implements(ifaceType) {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Name() < interfaces[j].Name()
+	})
+	return interfaces
+}
+
+func (r *TypeRegistry) typeName(t reflect.Type, suffix string) (string, error) {
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+	}
+	if err := invariantf(name != "", `Go type %v must be named or given a name.`, t); err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(name, suffix) {
+		name += suffix
+	}
+	return name, nil
+}
+
+// registryType returns the Go type of value, where pointers to interfaces
+// stand for the interface itself.
+func registryType(value interface{}) reflect.Type {
+	t := reflect.TypeOf(value)
+	if t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface {
+		return t.Elem()
+	}
+	return t
+}
+
+func scalarType(t reflect.Type) (*Scalar, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return String, nil
+	case reflect.Bool:
+		return Boolean, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Int, nil
+	case reflect.Float32, reflect.Float64:
+		return Float, nil
+	case reflect.Map:
+		return Map, nil
+	}
+	return nil, invariantf(false, `Go type %v cannot be mapped to a GraphQL type.`, t)
+}
+
+// nonNullType wraps a type in NonNull when values of the Go type cannot be nil.
+func nonNullType(t reflect.Type, ttype Type) Type {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Interface:
+		return ttype
+	}
+	return NewNonNull(ttype)
+}
+
+type structTag struct {
+	name              string
+	description       string
+	deprecationReason string
+}
+
+// eachStructField calls fn for each exported field of a struct, including the
+// fields of exported embedded structs which are not given a name.
+func eachStructField(t reflect.Type, fn func(field reflect.StructField, tag structTag) error) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, skip := parseStructTag(field)
+		if skip || field.PkgPath != "" {
+			continue
+		}
+		embedded := field.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if field.Anonymous && embedded.Kind() == reflect.Struct && field.Tag.Get(StructTag) == "" {
+			err := eachStructField(embedded, func(inner reflect.StructField, tag structTag) error {
+				inner.Index = append([]int{i}, inner.Index...)
+				return fn(inner, tag)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseStructTag reads the name and options of a field from its graphql tag.
+// Option values may contain commas, which are only treated as separators
+// before another option.
+func parseStructTag(field reflect.StructField) (structTag, bool) {
+	tag := structTag{}
+	parts := strings.Split(field.Tag.Get(StructTag), ",")
+	if parts[0] == "-" {
+		return tag, true
+	}
+	tag.name = parts[0]
+	var value *string
+	for _, part := range parts[1:] {
+		switch {
+		case strings.HasPrefix(part, "desc="):
+			tag.description = strings.TrimPrefix(part, "desc=")
+			value = &tag.description
+		case part == "deprecated":
+			tag.deprecationReason = DefaultDeprecationReason
+			value = nil
+		case strings.HasPrefix(part, "deprecated="):
+			tag.deprecationReason = strings.TrimPrefix(part, "deprecated=")
+			value = &tag.deprecationReason
+		case value != nil:
+			*value += "," + part
+		}
+	}
+	if tag.name == "" {
+		tag.name = strings.Split(field.Tag.Get(TAG), ",")[0]
+	}
+	if tag.name == "-" {
+		return tag, true
+	}
+	if tag.name == "" {
+		tag.name = lowerFirstWord(field.Name)
+	}
+	return tag, false
+}
+
+// lowerFirstWord lower-cases the first word of a Go name, e.g. "URLPath"
+// becomes "urlPath" and "ID" becomes "id".
+func lowerFirstWord(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// structFieldValue returns the value of a struct field of source, following
+// embedded pointers and dereferencing pointer values.
+func structFieldValue(source interface{}, index []int) interface{} {
+	value := reflect.ValueOf(source)
+	for _, i := range index {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil
+		}
+		value = value.Field(i)
+	}
+	return indirectValue(value)
+}
+
+func callMethod(p ResolveParams, name string, takesContext bool, returnsError bool) (interface{}, error) {
+	value := reflect.ValueOf(p.Source)
+	method := value.MethodByName(name)
+	if !method.IsValid() && value.Kind() != reflect.Ptr {
+		// The method has a pointer receiver, so it is called on a copy.
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		method = ptr.MethodByName(name)
+	}
+	if !method.IsValid() {
+		return nil, invariantf(false, `Go type %v has no method %v.`, value.Type(), name)
+	}
+	in := []reflect.Value{}
+	if takesContext {
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		in = append(in, reflect.ValueOf(ctx))
+	}
+	out := method.Call(in)
+	if returnsError && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return indirectValue(out[0]), nil
+}
+
+// indirectValue returns the value behind pointers, or nil for nil pointers,
+// slices, maps and interfaces.
+func indirectValue(value reflect.Value) interface{} {
+	for {
+		switch value.Kind() {
+		case reflect.Ptr, reflect.Interface:
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+			continue
+		case reflect.Slice, reflect.Map:
+			if value.IsNil() {
+				return nil
+			}
+		case reflect.Invalid:
+			return nil
+		}
+		return value.Interface()
+	}
+}
@@ -0,0 +1,211 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GannettDigital/graphql"
+)
+
+type registryNode interface {
+	ID() string
+}
+
+type registryUser struct {
+	Key      string          `graphql:"-"`
+	Name     string          `graphql:",desc=The name, as given."`
+	Email    *string         `json:"email"`
+	JoinedAt time.Time       `graphql:"joined"`
+	Nickname string          `graphql:"nick,deprecated=Use name."`
+	Friends  []registryUser  `graphql:"friends"`
+	Manager  *registryUser   `graphql:"manager"`
+	Posts    []*registryPost `graphql:"posts"`
+	password string
+}
+
+func (u *registryUser) ID() string {
+	return "user:" + u.Key
+}
+
+type registryPost struct {
+	registryTimestamps
+	Timestamps
+	Key   string `graphql:"-"`
+	Title string
+}
+
+func (p registryPost) ID() string {
+	return "post:" + p.Key
+}
+
+type registryTimestamps struct {
+	Hidden string
+}
+
+type Timestamps struct {
+	UpdatedAt *time.Time
+}
+
+func TestTypeRegistry_GeneratesSchemaFromStructs(t *testing.T) {
+	registry := graphql.NewTypeRegistry()
+	registry.Name(registryUser{}, "User")
+	registry.Name(registryPost{}, "Post")
+	registry.Name((*registryNode)(nil), "Node")
+
+	node, err := registry.Output((*registryNode)(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	user, err := registry.Object(registryUser{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	joined := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	me := &registryUser{
+		Key:      "1",
+		Name:     "Ada",
+		JoinedAt: joined,
+		Friends:  []registryUser{{Key: "2", Name: "Grace", JoinedAt: joined}},
+		Posts:    []*registryPost{{Key: "3", Title: "Notes", Timestamps: Timestamps{UpdatedAt: &joined}}},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"me": &graphql.Field{
+					Type: user,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return me, nil
+					},
+				},
+				"nodes": &graphql.Field{
+					Type: graphql.NewList(node),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []registryNode{me, me.Posts[0]}, nil
+					},
+				},
+			},
+		}),
+		Types: registry.Types(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSDL := `"""The ` + "`DateTime`" + ` scalar type represents a DateTime. The DateTime is serialized as an RFC 3339 quoted string"""
+scalar DateTime
+
+interface Node {
+  id: String!
+}
+
+type Post implements Node {
+  id: String!
+  title: String!
+  updatedAt: DateTime
+}
+
+type Query {
+  me: User
+  nodes: [Node]
+}
+
+type User implements Node {
+  email: String
+  friends: [User!]
+  id: String!
+  joined: DateTime!
+  manager: User
+  """The name, as given."""
+  name: String!
+  nick: String! @deprecated(reason: "Use name.")
+  posts: [Post]
+}
+`
+	if got := graphql.PrintSchema(schema); got != expectedSDL {
+		t.Fatalf("unexpected schema:\n%v\nexpected:\n%v", got, expectedSDL)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			me { id name email joined friends { id name manager { id } } posts { id title updatedAt } }
+			nodes { __typename id }
+		}`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	expected := map[string]interface{}{
+		"me": map[string]interface{}{
+			"id":     "user:1",
+			"name":   "Ada",
+			"email":  nil,
+			"joined": "2020-01-02T03:04:05Z",
+			"friends": []interface{}{
+				map[string]interface{}{"id": "user:2", "name": "Grace", "manager": nil},
+			},
+			"posts": []interface{}{
+				map[string]interface{}{"id": "post:3", "title": "Notes", "updatedAt": "2020-01-02T03:04:05Z"},
+			},
+		},
+		"nodes": []interface{}{
+			map[string]interface{}{"__typename": "User", "id": "user:1"},
+			map[string]interface{}{"__typename": "Post", "id": "post:3"},
+		},
+	}
+	if !reflect.DeepEqual(result.Data, expected) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+}
+
+type registryFilter struct {
+	Term   string          `graphql:"term"`
+	Limit  *int            `graphql:"limit,desc=At most, this many."`
+	Within *registryFilter `graphql:"within"`
+	Tags   []string        `graphql:"tags"`
+}
+
+func TestTypeRegistry_GeneratesInputsAndArguments(t *testing.T) {
+	registry := graphql.NewTypeRegistry()
+	filter, err := registry.Input(registryFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.String() != "registryFilterInput!" {
+		t.Fatalf("unexpected input type %v", filter)
+	}
+	args, err := registry.Arguments(registryFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args["limit"].Type != graphql.Int || args["limit"].Description != "At most, this many." {
+		t.Fatalf("unexpected limit argument: %+v", args["limit"])
+	}
+	fields := graphql.GetNullable(filter).(*graphql.InputObject).Fields()
+	if fields["within"].Type != graphql.GetNullable(filter) {
+		t.Fatalf("expected within to refer back to the input object, got %v", fields["within"].Type)
+	}
+	if fields["tags"].Type.String() != "[String!]" || fields["term"].Type.String() != "String!" {
+		t.Fatalf("unexpected fields: %v, %v", fields["tags"].Type, fields["term"].Type)
+	}
+}
+
+func TestTypeRegistry_RejectsUnsupportedTypes(t *testing.T) {
+	registry := graphql.NewTypeRegistry()
+	_, err := registry.Object(struct {
+		Callback func()
+	}{})
+	if err == nil || err.Error() != "Go type struct { Callback func() } must be named or given a name." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type withCallback struct {
+		Callback func()
+	}
+	_, err = registry.Object(withCallback{})
+	if err == nil || err.Error() != "Go type func() cannot be mapped to a GraphQL type." {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -163,7 +163,7 @@ func TestUnionIntersectionTypes_CanIntrospectOnUnionAndIntersectionTypes(t *test
 						"name": "name",
 					},
 				},
-				"interfaces": nil,
+				"interfaces": []interface{}{},
 				"possibleTypes": []interface{}{
 					map[string]interface{}{
 						"name": "Dog",
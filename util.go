@@ -14,6 +14,8 @@ const TAG = "json"
 //	Friends []Person
 // }
 // it will throw panic stack-overflow
+//
+// TypeRegistry generates recursive types, non-null fields and interfaces.
 func BindFields(obj interface{}) Fields {
 	t := reflect.TypeOf(obj)
 	v := reflect.ValueOf(obj)
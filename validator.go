@@ -83,6 +83,8 @@ var _ HasSelectionSet = (*ast.FragmentDefinition)(nil)
 type VariableUsage struct {
 	Node *ast.Variable
 	Type Input
+	// ParentType is the input type enclosing the variable, if any.
+	ParentType Input
 }
 
 type ValidationContext struct {
@@ -239,8 +241,9 @@ func (ctx *ValidationContext) VariableUsages(node HasSelectionSet) []*VariableUs
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if node, ok := p.Node.(*ast.Variable); ok && node != nil {
 						usages = append(usages, &VariableUsage{
-							Node: node,
-							Type: typeInfo.InputType(),
+							Node:       node,
+							Type:       typeInfo.InputType(),
+							ParentType: typeInfo.ParentInputType(),
 						})
 					}
 					return visitor.ActionNoChange, nil
@@ -57,12 +57,47 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 			value = argDef.DefaultValue
 		}
 		if !isNullish(value) {
+			if message := oneOfViolation(value, argDef.Type); message != "" {
+				return nil, gqlerrors.NewFormattedError(fmt.Sprintf(
+					"Argument \"%v\" has invalid value %v.\n%v", name, printer.Print(valueAST), message))
+			}
 			results[name] = value
 		}
 	}
 	return results, nil
 }
 
+// oneOfViolation returns why a coerced value breaks the rule that OneOf input
+// objects have exactly one field, or "" when it does not. Fields given through
+// variables which were omitted leave the object with no field.
+func oneOfViolation(value interface{}, ttype Input) string {
+	switch ttype := ttype.(type) {
+	case *NonNull:
+		return oneOfViolation(value, ttype.OfType)
+	case *List:
+		values, _ := value.([]interface{})
+		for _, value := range values {
+			if message := oneOfViolation(value, ttype.OfType); message != "" {
+				return message
+			}
+		}
+	case *InputObject:
+		valueMap, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if ttype.IsOneOf() && len(valueMap) != 1 {
+			return fmt.Sprintf(`Exactly one key must be specified for OneOf type "%v".`, ttype.Name())
+		}
+		for fieldName, field := range ttype.Fields() {
+			if message := oneOfViolation(valueMap[fieldName], field.Type); message != "" {
+				return message
+			}
+		}
+	}
+	return ""
+}
+
 // Given a variable definition, and any value of input, return a value which
 // adheres to the variable definition, or throw an error.
 func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
@@ -84,7 +119,7 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		)
 	}
 
-	isValid, messages := isValidInputValue(input, ttype)
+	isValid, messages := isValidInputValue(&schema, input, ttype)
 	if isValid {
 		if isNullish(input) {
 			defaultValue := definitionAST.DefaultValue
@@ -176,7 +211,7 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 	switch ttype := ttype.(type) {
 	case *Scalar:
 		parsed := ttype.ParseValue(value)
-		if !isNullish(parsed) {
+		if !isNullish(parsed) && !isScalarError(parsed) {
 			return parsed
 		}
 	case *Enum:
@@ -210,7 +245,7 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 		if inputTypeAST.Name != nil {
 			nameValue = inputTypeAST.Name.Value
 		}
-		ttype := schema.Type(nameValue)
+		ttype := schema.visibleType(nameValue)
 		return ttype, nil
 	default:
 		return nil, invariant(inputTypeAST.GetKind() == kinds.Named, "Must be a named type.")
@@ -221,7 +256,7 @@ func typeFromAST(schema Schema, inputTypeAST ast.Type) (Type, error) {
 // Given a value and a GraphQL type, determine if the value will be
 // accepted for that type. This is primarily useful for validating the
 // runtime values of query variables.
-func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
+func isValidInputValue(schema *Schema, value interface{}, ttype Input) (bool, []string) {
 	if ttype, ok := ttype.(*NonNull); ok {
 		if isNullish(value) {
 			if ttype.OfType.Name() != "" {
@@ -229,7 +264,7 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 			}
 			return false, []string{"Expected non-null value, found null."}
 		}
-		return isValidInputValue(value, ttype.OfType)
+		return isValidInputValue(schema, value, ttype.OfType)
 	}
 
 	if isNullish(value) {
@@ -247,14 +282,14 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 			messagesReduce := []string{}
 			for i := 0; i < valType.Len(); i++ {
 				val := valType.Index(i).Interface()
-				_, messages := isValidInputValue(val, itemType)
+				_, messages := isValidInputValue(schema, val, itemType)
 				for idx, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In element #%v: %v`, idx+1, message))
 				}
 			}
 			return (len(messagesReduce) == 0), messagesReduce
 		}
-		return isValidInputValue(value, itemType)
+		return isValidInputValue(schema, value, itemType)
 
 	case *InputObject:
 		messagesReduce := []string{}
@@ -263,7 +298,7 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 		if !ok {
 			return false, []string{fmt.Sprintf(`Expected "%v", found not an object.`, ttype.Name())}
 		}
-		fields := ttype.Fields()
+		fields := schema.visibleInputFields(ttype)
 
 		// to ensure stable order of field evaluation
 		fieldNames := []string{}
@@ -285,10 +320,20 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 				messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": Unknown field.`, fieldName))
 			}
 		}
+		if ttype.IsOneOf() {
+			if len(valueMap) != 1 {
+				messagesReduce = append(messagesReduce, fmt.Sprintf(`Exactly one key must be specified for OneOf type "%v".`, ttype.Name()))
+			}
+			for _, fieldName := range valueMapFieldNames {
+				if isNullish(valueMap[fieldName]) {
+					messagesReduce = append(messagesReduce, fmt.Sprintf(`Field "%v.%v" must be non-null.`, ttype.Name(), fieldName))
+				}
+			}
+		}
 
 		// Ensure every defined field is valid.
 		for _, fieldName := range fieldNames {
-			_, messages := isValidInputValue(valueMap[fieldName], fields[fieldName].Type)
+			_, messages := isValidInputValue(schema, valueMap[fieldName], fields[fieldName].Type)
 			if messages != nil {
 				for _, message := range messages {
 					messagesReduce = append(messagesReduce, fmt.Sprintf(`In field "%v": %v`, fieldName, message))
@@ -301,6 +346,9 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 	switch ttype := ttype.(type) {
 	case *Scalar:
 		parsedVal := ttype.ParseValue(value)
+		if err, ok := parsedVal.(error); ok {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v"; %v`, ttype.Name(), value, err.Error())}
+		}
 		if isNullish(parsedVal) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
@@ -308,7 +356,10 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 
 	case *Enum:
 		parsedVal := ttype.ParseValue(value)
-		if isNullish(parsedVal) {
+		if name, ok := value.(*string); ok {
+			value = *name
+		}
+		if name, _ := value.(string); isNullish(parsedVal) || !schema.isEnumNameVisible(ttype, name) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
 		return true, nil
@@ -316,6 +367,12 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 	return true, nil
 }
 
+// isScalarError reports whether a scalar rejected a value by returning an error.
+func isScalarError(value interface{}) bool {
+	_, ok := value.(error)
+	return ok
+}
+
 // Returns true if a value is null, undefined, or NaN.
 func isNullish(src interface{}) bool {
 	if src == nil {
@@ -323,6 +380,9 @@ func isNullish(src interface{}) bool {
 	}
 	value := reflect.ValueOf(src)
 	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true
+		}
 		value = value.Elem()
 	}
 	switch value.Kind() {
@@ -435,7 +495,7 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 	switch ttype := ttype.(type) {
 	case *Scalar:
 		parsed := ttype.ParseLiteral(valueAST)
-		if !isNullish(parsed) {
+		if !isNullish(parsed) && !isScalarError(parsed) {
 			return parsed
 		}
 	case *Enum:
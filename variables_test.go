@@ -2,6 +2,7 @@ package graphql_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -1502,3 +1503,75 @@ func TestVariables_UsesArgumentDefaultValues_WhenArgumentProvidedCannotBeParsed(
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+func TestVariables_OneOfInputObject_RequiresExactlyOneNonNullField(t *testing.T) {
+	lookup := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:    "Lookup",
+		IsOneOf: true,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+			"slug": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"page": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"by": &graphql.ArgumentConfig{Type: lookup},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						for key, value := range p.Args["by"].(map[string]interface{}) {
+							return fmt.Sprintf("%v:%v", key, value), nil
+						}
+						return nil, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{map[string]interface{}{"slug": "home"}, ""},
+		{map[string]interface{}{}, "Variable \"$by\" got invalid value {}.\nExactly one key must be specified for OneOf type \"Lookup\"."},
+		{map[string]interface{}{"id": "1", "slug": "home"}, "Variable \"$by\" got invalid value {\"id\":\"1\",\"slug\":\"home\"}.\nExactly one key must be specified for OneOf type \"Lookup\"."},
+		{map[string]interface{}{"id": nil}, "Variable \"$by\" got invalid value {\"id\":null}.\nField \"Lookup.id\" must be non-null."},
+	}
+	for _, c := range cases {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query ($by: Lookup) { page(by: $by) }`,
+			VariableValues: map[string]interface{}{"by": c.input},
+		})
+		if c.expected == "" {
+			if len(result.Errors) > 0 {
+				t.Fatalf("unexpected errors for %v: %v", c.input, result.Errors)
+			}
+			if expected := map[string]interface{}{"page": "slug:home"}; !reflect.DeepEqual(result.Data, expected) {
+				t.Fatalf("expected %v, got %v", expected, result.Data)
+			}
+			continue
+		}
+		if len(result.Errors) != 1 || result.Errors[0].Message != c.expected {
+			t.Fatalf("expected error %q for %v, got %v", c.expected, c.input, result.Errors)
+		}
+	}
+
+	// Without validation, an omitted variable leaves the OneOf input object with no field.
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `query ($slug: String) { page(by: {slug: $slug}) }`),
+	})
+	expected := "Argument \"by\" has invalid value {slug: $slug}.\nExactly one key must be specified for OneOf type \"Lookup\"."
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("expected error %q, got %v", expected, result.Errors)
+	}
+}
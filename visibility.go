@@ -0,0 +1,226 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+)
+
+// VisibilityParams describes the schema element whose visibility is checked
+// by a VisibilityFn.
+type VisibilityParams struct {
+	// Context is the context of the request.
+	Context context.Context
+
+	// Type is the named type checked, the type defining the field checked,
+	// the input object defining the input field checked, or the enum defining
+	// the enum value checked. It is nil for arguments.
+	Type Type
+
+	// Field is the field checked, or the field defining the argument checked.
+	Field *FieldDefinition
+
+	// Argument is the field argument checked.
+	Argument *Argument
+
+	// InputField is the input object field checked.
+	InputField *InputObjectField
+
+	// EnumValue is the enum value checked.
+	EnumValue *EnumValueDefinition
+}
+
+// VisibilityFn reports whether a type, field, field argument, input field or
+// enum value of a schema is visible to a request. Hidden elements are treated
+// as if they did not exist by validation, suggestions and introspection.
+//
+// Fields, arguments and input fields whose types are hidden are hidden as
+// well. Introspection
+// types and fields are always visible. The function is called many times per
+// request, so it should be cheap.
+type VisibilityFn func(p VisibilityParams) bool
+
+// schemaVisibility is the visibility of the elements of a schema to a request.
+type schemaVisibility struct {
+	ctx     context.Context
+	visible VisibilityFn
+}
+
+// withVisibility returns a copy of schema whose elements are visible to the
+// request of ctx as reported by visible.
+func (gq Schema) withVisibility(ctx context.Context, visible VisibilityFn) Schema {
+	if visible == nil {
+		return gq
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	gq.visibility = &schemaVisibility{ctx: ctx, visible: visible}
+	return gq
+}
+
+func (gq *Schema) isTypeVisible(ttype Type) bool {
+	if gq.visibility == nil {
+		return true
+	}
+	named, ok := GetNamed(ttype).(Type)
+	if !ok || named == nil || strings.HasPrefix(named.Name(), "__") {
+		return true
+	}
+	return gq.visibility.visible(VisibilityParams{
+		Context: gq.visibility.ctx,
+		Type:    named,
+	})
+}
+
+func (gq *Schema) isFieldVisible(parentType Type, field *FieldDefinition) bool {
+	if gq.visibility == nil || field == nil || strings.HasPrefix(field.Name, "__") {
+		return true
+	}
+	return gq.isTypeVisible(field.Type) && gq.visibility.visible(VisibilityParams{
+		Context: gq.visibility.ctx,
+		Type:    parentType,
+		Field:   field,
+	})
+}
+
+func (gq *Schema) isArgumentVisible(field *FieldDefinition, arg *Argument) bool {
+	if gq.visibility == nil || field == nil || strings.HasPrefix(field.Name, "__") {
+		return true
+	}
+	return gq.isTypeVisible(arg.Type) && gq.visibility.visible(VisibilityParams{
+		Context:  gq.visibility.ctx,
+		Field:    field,
+		Argument: arg,
+	})
+}
+
+func (gq *Schema) isInputFieldVisible(inputObject *InputObject, field *InputObjectField) bool {
+	if gq.visibility == nil || strings.HasPrefix(inputObject.Name(), "__") {
+		return true
+	}
+	return gq.isTypeVisible(field.Type) && gq.visibility.visible(VisibilityParams{
+		Context:    gq.visibility.ctx,
+		Type:       inputObject,
+		InputField: field,
+	})
+}
+
+func (gq *Schema) isEnumValueVisible(enum *Enum, value *EnumValueDefinition) bool {
+	if gq.visibility == nil || strings.HasPrefix(enum.Name(), "__") {
+		return true
+	}
+	return gq.visibility.visible(VisibilityParams{
+		Context:   gq.visibility.ctx,
+		Type:      enum,
+		EnumValue: value,
+	})
+}
+
+// visibleType returns the type of the schema with the given name, or nil when
+// it does not exist or is hidden.
+func (gq *Schema) visibleType(name string) Type {
+	ttype := gq.Type(name)
+	if ttype == nil || !gq.isTypeVisible(ttype) {
+		return nil
+	}
+	return ttype
+}
+
+// visibleTypeNames returns the names of the types of the schema which are
+// visible.
+func (gq *Schema) visibleTypeNames() []string {
+	names := []string{}
+	for name, ttype := range gq.TypeMap() {
+		if gq.isTypeVisible(ttype) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// visibleFields returns the fields of ttype which are visible.
+func (gq *Schema) visibleFields(ttype Type) FieldDefinitionMap {
+	var fields FieldDefinitionMap
+	switch ttype := ttype.(type) {
+	case *Object:
+		fields = ttype.Fields()
+	case *Interface:
+		fields = ttype.Fields()
+	default:
+		return FieldDefinitionMap{}
+	}
+	if gq.visibility == nil {
+		return fields
+	}
+	visible := FieldDefinitionMap{}
+	for name, field := range fields {
+		if gq.isFieldVisible(ttype, field) {
+			visible[name] = field
+		}
+	}
+	return visible
+}
+
+// visibleArgs returns the arguments of field which are visible.
+func (gq *Schema) visibleArgs(field *FieldDefinition) []*Argument {
+	if gq.visibility == nil {
+		return field.Args
+	}
+	args := []*Argument{}
+	for _, arg := range field.Args {
+		if gq.isArgumentVisible(field, arg) {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// visibleInputFields returns the fields of inputObject which are visible.
+func (gq *Schema) visibleInputFields(inputObject *InputObject) InputObjectFieldMap {
+	fields := inputObject.Fields()
+	if gq.visibility == nil {
+		return fields
+	}
+	visible := InputObjectFieldMap{}
+	for name, field := range fields {
+		if gq.isInputFieldVisible(inputObject, field) {
+			visible[name] = field
+		}
+	}
+	return visible
+}
+
+// isEnumNameVisible reports whether the value of enum with the given name is
+// visible. Unknown names are left to be reported as invalid values.
+func (gq *Schema) isEnumNameVisible(enum *Enum, name string) bool {
+	value, ok := enum.getNameLookup()[name]
+	return !ok || gq.isEnumValueVisible(enum, value)
+}
+
+// visibleObjects returns the objects which are visible.
+func (gq *Schema) visibleObjects(objects []*Object) []*Object {
+	if gq.visibility == nil {
+		return objects
+	}
+	visible := []*Object{}
+	for _, object := range objects {
+		if gq.isTypeVisible(object) {
+			visible = append(visible, object)
+		}
+	}
+	return visible
+}
+
+// visibleInterfaces returns the interfaces which are visible.
+func (gq *Schema) visibleInterfaces(interfaces []*Interface) []*Interface {
+	if gq.visibility == nil {
+		return interfaces
+	}
+	visible := []*Interface{}
+	for _, iface := range interfaces {
+		if gq.isTypeVisible(iface) {
+			visible = append(visible, iface)
+		}
+	}
+	return visible
+}
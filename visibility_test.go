@@ -0,0 +1,221 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GannettDigital/graphql"
+	"github.com/GannettDigital/graphql/testutil"
+)
+
+type partnerKey struct{}
+
+// partnerVisibility hides the elements marked internal from partner requests.
+func partnerVisibility(p graphql.VisibilityParams) bool {
+	if partner, _ := p.Context.Value(partnerKey{}).(bool); !partner {
+		return true
+	}
+	switch {
+	case p.Argument != nil:
+		return p.Argument.Name() != "debug"
+	case p.EnumValue != nil:
+		return p.EnumValue.Name != "ADMIN"
+	case p.InputField != nil:
+		return p.InputField.Name() != "internalScore"
+	case p.Field != nil:
+		return p.Field.Name != "internalNotes"
+	}
+	return !strings.HasPrefix(p.Type.Name(), "Internal")
+}
+
+var visibilityRole = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Role",
+	Values: graphql.EnumValueConfigMap{
+		"ADMIN": &graphql.EnumValueConfig{Value: "admin"},
+		"USER":  &graphql.EnumValueConfig{Value: "user"},
+	},
+})
+
+var visibilityInternalStats = graphql.NewObject(graphql.ObjectConfig{
+	Name: "InternalStats",
+	Fields: graphql.Fields{
+		"logins": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var visibilityInternalFilter = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "InternalFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"minLogins": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+	},
+})
+
+var visibilityUserFilter = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UserFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":          &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"internalScore": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"internal":      &graphql.InputObjectFieldConfig{Type: visibilityInternalFilter},
+	},
+})
+
+var visibilityUser = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"name":          &graphql.Field{Type: graphql.String},
+		"internalNotes": &graphql.Field{Type: graphql.String},
+		"loginStats":    &graphql.Field{Type: visibilityInternalStats},
+	},
+})
+
+var visibilityTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"users": &graphql.Field{
+				Type: graphql.NewList(visibilityUser),
+				Args: graphql.FieldConfigArgument{
+					"role":   &graphql.ArgumentConfig{Type: visibilityRole},
+					"debug":  &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"filter": &graphql.ArgumentConfig{Type: visibilityUserFilter},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []interface{}{map[string]interface{}{"name": "Ada", "internalNotes": "VIP"}}, nil
+				},
+			},
+		},
+	}),
+})
+
+func TestVisibility_HidesElementsFromValidation(t *testing.T) {
+	partner := context.WithValue(context.Background(), partnerKey{}, true)
+	tests := []struct {
+		query     string
+		variables map[string]interface{}
+		messages  []string
+	}{
+		{
+			query:    `{ users { internalNote } }`,
+			messages: []string{`Cannot query field "internalNote" on type "User".`},
+		},
+		{
+			query:    `{ users { internalNotes loginStats { logins } } }`,
+			messages: []string{`Cannot query field "internalNotes" on type "User".`, `Cannot query field "loginStats" on type "User".`},
+		},
+		{
+			query:    `{ users(debug: true) { name } }`,
+			messages: []string{`Unknown argument "debug" on field "users" of type "Query".`},
+		},
+		{
+			query:    `{ users(role: ADMIN) { name } }`,
+			messages: []string{"Argument \"role\" has invalid value ADMIN.\nExpected type \"Role\", found ADMIN."},
+		},
+		{
+			query:    `{ users { ...stats } } fragment stats on InternalStats { logins }`,
+			messages: []string{`Unknown type "InternalStats".`},
+		},
+		{
+			query:     `query ($role: Role) { users(role: $role) { name } }`,
+			variables: map[string]interface{}{"role": "ADMIN"},
+			messages:  []string{"Variable \"$role\" got invalid value \"ADMIN\".\nExpected type \"Role\", found \"ADMIN\"."},
+		},
+		{
+			query:    `{ users(filter: {internalScore: 1, internal: {minLogins: 1}}) { name } }`,
+			messages: []string{"Argument \"filter\" has invalid value {internalScore: 1, internal: {minLogins: 1}}.\nIn field \"internalScore\": Unknown field.\nIn field \"internal\": Unknown field."},
+		},
+		{
+			query:     `query ($filter: UserFilter) { users(filter: $filter) { name } }`,
+			variables: map[string]interface{}{"filter": map[string]interface{}{"internalScore": 1}},
+			messages:  []string{"Variable \"$filter\" got invalid value {\"internalScore\":1}.\nIn field \"internalScore\": Unknown field."},
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:         visibilityTestSchema,
+			RequestString:  test.query,
+			VariableValues: test.variables,
+			Context:        partner,
+			Visibility:     partnerVisibility,
+		})
+		messages := []string{}
+		for _, err := range result.Errors {
+			messages = append(messages, err.Message)
+		}
+		if !reflect.DeepEqual(messages, test.messages) {
+			t.Errorf("Test %q - Diff: %v", test.query, testutil.Diff(test.messages, messages))
+		}
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        visibilityTestSchema,
+		RequestString: `{ users(role: ADMIN, debug: true) { internalNote } }`,
+		Context:       context.Background(),
+		Visibility:    partnerVisibility,
+	})
+	expected := []string{`Cannot query field "internalNote" on type "User". Did you mean "internalNotes"?`}
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected[0] {
+		t.Fatalf("expected internal elements to be visible to other requests, got %v", result.Errors)
+	}
+}
+
+func TestVisibility_HidesElementsFromIntrospection(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: visibilityTestSchema,
+		RequestString: `{
+			internal: __type(name: "InternalStats") { name }
+			user: __type(name: "User") { fields { name } }
+			role: __type(name: "Role") { enumValues { name } }
+			filter: __type(name: "UserFilter") { inputFields { name } }
+			query: __type(name: "Query") { fields { args { name } } }
+		}`,
+		Context:    context.WithValue(context.Background(), partnerKey{}, true),
+		Visibility: partnerVisibility,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"internal": nil,
+			"user": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"name": "name"},
+				},
+			},
+			"role": map[string]interface{}{
+				"enumValues": []interface{}{
+					map[string]interface{}{"name": "USER"},
+				},
+			},
+			"filter": map[string]interface{}{
+				"inputFields": []interface{}{
+					map[string]interface{}{"name": "name"},
+				},
+			},
+			"query": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"args": []interface{}{
+							map[string]interface{}{"name": "filter"},
+							map[string]interface{}{"name": "role"},
+						},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        visibilityTestSchema,
+		RequestString: `{ __schema { types { name } } }`,
+		Context:       context.WithValue(context.Background(), partnerKey{}, true),
+		Visibility:    partnerVisibility,
+	})
+	for _, ttype := range result.Data.(map[string]interface{})["__schema"].(map[string]interface{})["types"].([]interface{}) {
+		if ttype.(map[string]interface{})["name"] == "InternalStats" {
+			t.Fatalf("expected InternalStats to be hidden")
+		}
+	}
+}